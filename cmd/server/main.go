@@ -1,25 +1,78 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"flag"
 	"log"
+	"net"
+	"net/http"
 	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
 
 	// "os"
 
+	"topic-service/internal/gateway"
+	"topic-service/internal/topic/fixture"
+	"topic-service/internal/topic/middleware"
+	"topic-service/internal/topic/migration"
+	"topic-service/internal/topic/repository"
+	"topic-service/internal/topic/service"
+	"topic-service/pkg/cache"
 	"topic-service/pkg/config"
+	"topic-service/pkg/constants"
 	"topic-service/pkg/consul"
 	"topic-service/pkg/db"
+	"topic-service/pkg/grpcserver"
+	"topic-service/pkg/kafka"
+	"topic-service/pkg/migrate"
 	"topic-service/pkg/router"
 
 	"topic-service/pkg/zap"
+
+	"github.com/EventStore/EventStore-Client-Go/esdb"
+	"github.com/hashicorp/consul/api"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const (
+	// defaultDevPort dùng khi chạy --dev mà không có file config
+	defaultDevPort = "8012"
+	// defaultShutdownTimeout là thời gian chờ request đang xử lý khi tắt server
+	defaultShutdownTimeout = 15 * time.Second
 )
 
 func main() {
-	filePath := os.Args[1]
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		runMigrate(os.Args[2:])
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "replay" {
+		runReplay(os.Args[2:])
+		return
+	}
+
+	devMode := flag.Bool("dev", false, "run with in-memory storage and a stub user service, without MongoDB or Consul")
+	seedPath := flag.String("seed", "", "fixture JSON file to load into in-memory storage (requires --dev)")
+	runMigrations := flag.Bool("migrate", false, "apply pending MongoDB migrations before starting the server")
+	flag.Parse()
+
+	filePath := flag.Arg(0)
 	if filePath == "" {
 		filePath = "configs/config.yaml"
 	}
 
+	if *devMode {
+		runDev(filePath, *seedPath)
+		return
+	}
+	if *seedPath != "" {
+		log.Fatal("--seed is only supported together with --dev")
+	}
+
 	config.LoadConfig(filePath)
 
 	cfg := config.AppConfig
@@ -38,11 +91,402 @@ func main() {
 	defer consulConn.Deregister()
 
 	//db
-	db.ConnectMongoDB()
+	mongoDB := connectMongo(cfg)
+	defer mongoDB.Close(context.Background())
+	requireMongoTransactions(cfg, mongoDB)
+	if *runMigrations {
+		migrateOnStartup(cfg, mongoDB.Database)
+	}
+
+	// Init Consul client
+	consulClient, _ := api.NewClient(api.DefaultConfig())
+
+	// Tạo UserGateway
+	userGateway := gateway.NewUserGateway("go-main-service", consulClient)
+
+	repos := repository.NewMongoRepositories(mongoDB.Database)
+	if eventStore, closeEventStore := connectEventStore(cfg); eventStore != nil {
+		defer closeEventStore()
+		projection := repository.NewMongoTopicProjection(mongoDB.Database)
+		projector := service.NewTopicProjector(eventStore, projection, repos.Outbox, logger)
+		defer projector.Close()
+		repos.Topic = repository.NewEventSourcedTopicRepository(repos.Topic, eventStore, projection,
+			repository.EventSourcedTopicOptions{ProjectionWait: cfg.EventStore.ProjectionWait})
+	}
+	repos.Topic, err = repository.NewCachedTopicRepository(context.Background(), repos.Topic, topicCacheOptions(cfg))
+	if err != nil {
+		log.Fatalf("Failed to initialize topic cache: %v", err)
+	}
+
+	kafkaClient := newKafkaClient(cfg)
+	if kafkaClient != nil {
+		defer kafkaClient.Close()
+	}
+	topicChanges := service.NewTopicChangeHub(repos.Outbox, cfg.Stream.ResumeLimit)
+	publisher := newEventPublisher(cfg, kafkaClient)
+	// Instance nào cũng nhận event để stream SSE trên mọi instance đều nhận đủ thay đổi:
+	// đọc từ Kafka nếu có, không thì đọc thẳng outbox
+	if kafkaClient != nil {
+		streamConsumer := startTopicChangeConsumer(cfg, kafkaClient, logger, topicChanges)
+		defer streamConsumer.Close()
+	} else {
+		feed := service.NewOutboxTopicChangeFeed(repos.Outbox, topicChanges)
+		defer feed.Close()
+	}
+	publisher = service.NewWebhookEventPublisher(publisher, repos.WebhookSubscriptions, repos.WebhookDeliveries)
+	relay := service.NewOutboxRelay(repos.Outbox, publisher, service.OutboxRelayOptions{})
+	defer relay.Close()
+	dispatcher := service.NewWebhookDispatcher(repos.WebhookSubscriptions, repos.WebhookDeliveries, service.WebhookDispatcherOptions{
+		Workers:        cfg.Webhooks.Workers,
+		MaxAttempts:    cfg.Webhooks.MaxAttempts,
+		RequestTimeout: cfg.Webhooks.RequestTimeout,
+		DisableAfter:   cfg.Webhooks.DisableAfter,
+	})
+	defer dispatcher.Close()
+
+	userLifecycle := service.NewUserLifecycleService(repos.Topic, repos.Audit, repos.ProcessedEvents, repos.Outbox, repos.Transactions, userGateway,
+		service.UserLifecycleOptions{FallbackOwnerID: cfg.UserEvents.FallbackOwnerID})
+	if kafkaClient != nil {
+		consumer := startUserEventConsumer(cfg, kafkaClient, logger, userLifecycle)
+		defer consumer.Close()
+	}
+
+	r := router.SetupRouter(repos, userGateway, router.Options{
+		UserLifecycle:           userLifecycle,
+		UserEventsWebhookSecret: cfg.UserEvents.WebhookSecret,
+		TopicChanges:            topicChanges,
+		StreamHeartbeat:         cfg.Stream.Heartbeat,
+		ValidateRequests:        cfg.Server.ValidateRequests,
+	})
+
+	var grpcAPI *grpcEndpoint
+	if port := grpcPort(cfg); port != "" {
+		if verifier := tokenVerifier(cfg); verifier != nil {
+			grpcAPI = listenGrpc(port, grpcserver.NewServer(repos, userGateway, grpcserver.Options{Logger: logger, TokenVerifier: verifier}))
+			// Chỉ đăng ký với Consul khi port đã được mở
+			consulConn.RegisterGrpc(port)
+		}
+	}
+	serve(":"+cfg.Server.Port, r, grpcAPI, cfg.Stream.ShutdownTimeout)
+}
+
+// runDev chạy service chỉ với bộ nhớ trong: không kết nối MongoDB, không đăng ký Consul,
+// thông tin user lấy từ stub. File config là tuỳ chọn, chỉ dùng để đọc port.
+func runDev(filePath, seedPath string) {
+	if _, err := os.Stat(filePath); err == nil {
+		config.LoadConfig(filePath)
+	} else {
+		// Không có file config thì mọi tuỳ chọn lấy giá trị mặc định
+		config.AppConfig = &config.AppConfigStruct{}
+	}
+	port := defaultDevPort
+	if config.AppConfig.Server.Port != "" {
+		port = config.AppConfig.Server.Port
+	}
+
+	repos := repository.NewMemoryRepositories()
+	if seedPath != "" {
+		if err := fixture.Load(context.Background(), repos, seedPath); err != nil {
+			log.Fatalf("Failed to load fixtures: %v", err)
+		}
+		log.Printf("Loaded fixtures from %s", seedPath)
+	}
+
+	topicChanges := service.NewTopicChangeHub(repos.Outbox, service.DefaultTopicChangeResumeLimit)
+	feed := service.NewOutboxTopicChangeFeed(repos.Outbox, topicChanges)
+	defer feed.Close()
+	publisher := service.NewWebhookEventPublisher(service.NewLogEventPublisher(), repos.WebhookSubscriptions, repos.WebhookDeliveries)
+	relay := service.NewOutboxRelay(repos.Outbox, publisher, service.OutboxRelayOptions{})
+	defer relay.Close()
+	dispatcher := service.NewWebhookDispatcher(repos.WebhookSubscriptions, repos.WebhookDeliveries, service.WebhookDispatcherOptions{})
+	defer dispatcher.Close()
+
+	log.Printf("Running in dev mode with in-memory storage on port %s", port)
+	userGateway := gateway.NewStubUserGateway()
+	r := router.SetupRouter(repos, userGateway, router.Options{
+		TopicChanges:     topicChanges,
+		ValidateRequests: config.AppConfig.Server.ValidateRequests,
+	})
+
+	var grpcAPI *grpcEndpoint
+	if rpcPort := grpcPort(config.AppConfig); rpcPort != "" {
+		if verifier := tokenVerifier(config.AppConfig); verifier != nil {
+			grpcAPI = listenGrpc(rpcPort, grpcserver.NewServer(repos, userGateway, grpcserver.Options{TokenVerifier: verifier}))
+		}
+	}
+	serve(":"+port, r, grpcAPI, 0)
+}
+
+// grpcEndpoint là gRPC server chạy song song với HTTP server
+type grpcEndpoint struct {
+	listener net.Listener
+	server   *grpcserver.Server
+}
+
+// listenGrpc mở port gRPC trước khi HTTP server chạy, không mở được thì dừng process
+func listenGrpc(port string, server *grpcserver.Server) *grpcEndpoint {
+	lis, err := net.Listen("tcp", ":"+port)
+	if err != nil {
+		log.Fatalf("Failed to listen for gRPC on :%s: %v", port, err)
+	}
+	return &grpcEndpoint{listener: lis, server: server}
+}
+
+// tokenVerifier đọc khoá kiểm tra JWT cho API gRPC, biến môi trường JWT_SECRET ghi đè auth.jwt_secret.
+// Không có khoá nào thì trả về nil và gRPC không được mở.
+func tokenVerifier(cfg *config.AppConfigStruct) *middleware.TokenVerifier {
+	var secret, keyFile string
+	if cfg != nil {
+		secret, keyFile = cfg.Auth.JWTSecret, cfg.Auth.JWTPublicKeyFile
+	}
+	if env := os.Getenv(constants.JWTSecret); env != "" {
+		secret = env
+	}
+
+	var publicKey []byte
+	if keyFile != "" {
+		data, err := os.ReadFile(keyFile)
+		if err != nil {
+			log.Fatalf("Failed to read JWT public key: %v", err)
+		}
+		publicKey = data
+	}
+
+	verifier, err := middleware.NewTokenVerifier(secret, publicKey)
+	if err != nil {
+		log.Fatalf("Failed to load JWT public key: %v", err)
+	}
+	if verifier == nil {
+		log.Printf("gRPC API is disabled: set auth.jwt_secret or auth.jwt_public_key_file to verify tokens")
+	}
+	return verifier
+}
+
+// grpcPort đọc port gRPC, biến môi trường GRPC_PORT ghi đè server.grpc_port. Rỗng thì không mở gRPC.
+func grpcPort(cfg *config.AppConfigStruct) string {
+	if port := os.Getenv(constants.GrpcPort); port != "" {
+		return port
+	}
+	if cfg == nil {
+		return ""
+	}
+	return cfg.Server.GrpcPort
+}
+
+// serve chạy HTTP server (và gRPC server nếu có) tới khi nhận SIGINT/SIGTERM rồi tắt êm: đóng các stream SSE,
+// chờ request đang xử lý tối đa shutdownTimeout, sau đó dừng các worker nền của router.
+// Các defer của main chạy sau khi serve trả về.
+func serve(addr string, r *router.Router, grpcAPI *grpcEndpoint, shutdownTimeout time.Duration) {
+	if shutdownTimeout <= 0 {
+		shutdownTimeout = defaultShutdownTimeout
+	}
+
+	srv := &http.Server{Addr: addr, Handler: r}
+	// Stream SSE không bao giờ tự kết thúc, phải đóng trước thì Shutdown mới chờ được các request còn lại
+	srv.RegisterOnShutdown(r.TopicChanges.Close)
+	defer r.Close()
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	errCh := make(chan error, 2)
+	go func() {
+		errCh <- srv.ListenAndServe()
+	}()
+	if grpcAPI != nil {
+		log.Printf("gRPC server listening on %s", grpcAPI.listener.Addr())
+		go func() {
+			if err := grpcAPI.server.Serve(grpcAPI.listener); err != nil {
+				errCh <- err
+			}
+		}()
+	}
 
-	r := router.SetupRouter(db.TopicCollection)
-	port := cfg.Server.Port
-	if err := r.Run(":" + port); err != nil {
-		log.Fatal("Failed to run server:", err)
+	select {
+	case err := <-errCh:
+		if !errors.Is(err, http.ErrServerClosed) {
+			log.Printf("Failed to run server: %v", err)
+		}
+		return
+	case <-ctx.Done():
+	}
+
+	log.Printf("Shutting down server")
+	grpcStopped := make(chan struct{})
+	go func() {
+		defer close(grpcStopped)
+		if grpcAPI != nil {
+			grpcAPI.server.Shutdown(shutdownTimeout)
+		}
+	}()
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		log.Printf("Server shutdown did not complete: %v", err)
+	}
+	<-grpcStopped
+}
+
+// migrateOnStartup áp dụng migration còn thiếu trước khi nhận request. Nhiều instance cùng khởi động
+// thì chỉ một instance migrate, các instance còn lại chờ lock rồi thấy không còn gì để chạy.
+func migrateOnStartup(cfg *config.AppConfigStruct, database *mongo.Database) {
+	runner, err := migration.NewMongoRunner(database)
+	if err != nil {
+		log.Fatalf("Failed to initialize migrations: %v", err)
+	}
+	runMigrationsUp("MongoDB", runner)
+
+	// Topic luôn nằm trong MongoDB, MySQL chỉ được migrate thêm khi nó là database đang chọn
+	if cfg.Database.Active == "mysql" {
+		db.ConnectMySQL()
+		runner, err := migration.NewMySQLRunner(db.MySqlDB)
+		if err != nil {
+			log.Fatalf("Failed to initialize MySQL migrations: %v", err)
+		}
+		runMigrationsUp("MySQL", runner)
+	}
+}
+
+func runMigrationsUp(database string, migrator migrate.Migrator) {
+	applied, err := migrator.Up(context.Background(), 0)
+	if err != nil {
+		log.Fatalf("%s migration failed: %v", database, err)
+	}
+	log.Printf("Applied %d %s migration(s)", applied, database)
+}
+
+// topicCacheOptions đọc cấu hình cache, có REDIS_ADDR thì dùng Redis làm cache chung và kênh invalidation
+func topicCacheOptions(cfg *config.AppConfigStruct) repository.TopicCacheOptions {
+	opts := repository.TopicCacheOptions{Size: cfg.Cache.Size, TTL: cfg.Cache.TTL}
+
+	if addr := os.Getenv(constants.RedisAddr); addr != "" {
+		opts.Redis = cache.NewRedis(addr)
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := opts.Redis.Ping(ctx); err != nil {
+			log.Printf("Redis at %s is not reachable, topic cache will retry: %v", addr, err)
+		} else {
+			log.Printf("Topic cache uses Redis at %s", addr)
+		}
+	}
+	return opts
+}
+
+// newKafkaClient kết nối Kafka nếu có cấu hình broker, không thì trả về nil
+func newKafkaClient(cfg *config.AppConfigStruct) *kafka.Client {
+	brokers := cfg.Kafka.Brokers
+	if env := os.Getenv(constants.KafkaBrokers); env != "" {
+		brokers = strings.Split(env, ",")
+	}
+	if len(brokers) == 0 {
+		return nil
+	}
+
+	client, err := kafka.NewClient(kafka.ClientConfig{Brokers: brokers, ClientID: cfg.Kafka.ClientID})
+	if err != nil {
+		log.Fatalf("Failed to initialize Kafka client: %v", err)
+	}
+	log.Printf("Connected to Kafka at %s", strings.Join(brokers, ","))
+	return client
+}
+
+// newEventPublisher gửi event thay đổi topic vào Kafka nếu có client, không thì chỉ ghi log
+func newEventPublisher(cfg *config.AppConfigStruct, client *kafka.Client) service.EventPublisher {
+	if client == nil {
+		log.Printf("Kafka is not configured, topic events are only logged")
+		return service.NewLogEventPublisher()
+	}
+	return service.NewKafkaEventPublisher(kafka.NewProducer(client), cfg.Kafka.TopicEventsTopic)
+}
+
+// startTopicChangeConsumer đọc event thay đổi topic mà relay đã gửi vào Kafka để phát cho các stream SSE
+func startTopicChangeConsumer(cfg *config.AppConfigStruct, client *kafka.Client, logger kafka.Logger, hub service.TopicChangeHub) *kafka.ConsumerGroup {
+	topic := cfg.Kafka.TopicEventsTopic
+	if topic == "" {
+		topic = service.DefaultTopicEventsTopic
+	}
+
+	consumer, err := kafka.NewConsumerGroup(client, logger, kafka.ConsumerGroupConfig{
+		GroupID:  service.TopicChangeGroupID(),
+		Topics:   []string{topic},
+		PoolSize: 1,
+	}, service.NewTopicChangeHandler(hub))
+	if err != nil {
+		log.Fatalf("Failed to start topic stream consumer: %v", err)
+	}
+	return consumer
+}
+
+// startUserEventConsumer nhận sự kiện user bị xoá/vô hiệu hoá từ go-main-service để chuyển topic của họ cho người khác
+func startUserEventConsumer(cfg *config.AppConfigStruct, client *kafka.Client, logger kafka.Logger, svc service.UserLifecycleService) *kafka.ConsumerGroup {
+	topic := cfg.UserEvents.Topic
+	if topic == "" {
+		topic = service.DefaultUserEventsTopic
+	}
+	groupID := cfg.UserEvents.GroupID
+	if groupID == "" {
+		groupID = service.DefaultUserEventsGroup
+	}
+
+	consumer, err := kafka.NewConsumerGroup(client, logger, kafka.ConsumerGroupConfig{
+		GroupID: groupID,
+		Topics:  []string{topic},
+	}, service.NewUserEventHandler(svc))
+	if err != nil {
+		log.Fatalf("Failed to start user event consumer: %v", err)
+	}
+	return consumer
+}
+
+// connectEventStore kết nối EventStoreDB nếu có cấu hình, không thì trả về nil và topic được ghi thẳng vào MongoDB
+func connectEventStore(cfg *config.AppConfigStruct) (repository.TopicEventStore, func()) {
+	connectionString := cfg.EventStore.ConnectionString
+	if env := os.Getenv(constants.EventStoreConnectionString); env != "" {
+		connectionString = env
+	}
+	if connectionString == "" {
+		return nil, func() {}
+	}
+
+	esCfg, err := esdb.ParseConnectionString(connectionString)
+	if err != nil {
+		log.Fatalf("Invalid EventStoreDB connection string: %v", err)
+	}
+	client, err := esdb.NewClient(esCfg)
+	if err != nil {
+		log.Fatalf("Failed to connect to EventStoreDB: %v", err)
+	}
+	log.Printf("Topic writes are event-sourced on EventStoreDB")
+	return repository.NewTopicEventStore(client), func() { client.Close() }
+}
+
+// connectMongo kết nối MongoDB theo config, biến môi trường MONGO_URI ghi đè uri trong file config
+func connectMongo(cfg *config.AppConfigStruct) *db.Mongo {
+	mongoCfg := cfg.Database.Mongo
+	if uri := os.Getenv(constants.MongoDbURI); uri != "" {
+		mongoCfg.URI = uri
+	}
+
+	mongoDB, err := db.NewMongo(context.Background(), mongoCfg)
+	if err != nil {
+		log.Fatalf("Failed to connect to MongoDB: %v", err)
+	}
+	return mongoDB
+}
+
+// requireMongoTransactions dừng server khi MongoDB không hỗ trợ transaction, trừ khi bật database.mongodb.allow_standalone
+func requireMongoTransactions(cfg *config.AppConfigStruct, mongoDB *db.Mongo) {
+	supported, err := repository.MongoSupportsTransactions(context.Background(), mongoDB.Client)
+	if err != nil {
+		log.Fatalf("Failed to detect MongoDB topology: %v", err)
+	}
+	if supported {
+		return
+	}
+	if !cfg.Database.Mongo.AllowStandalone {
+		log.Fatal("MongoDB is a standalone server without transactions, run it as a replica set " +
+			"or set database.mongodb.allow_standalone: true to accept non-atomic outbox writes")
 	}
+	log.Printf("MongoDB is a standalone server, allow_standalone is set: writes and outbox events are not atomic")
 }
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"topic-service/internal/topic/migration"
+	"topic-service/pkg/config"
+	"topic-service/pkg/db"
+	"topic-service/pkg/migrate"
+)
+
+const migrateUsage = `usage: api migrate [flags] up|down|status [config]
+
+  up      apply pending migrations (up to -to when set)
+  down    roll back the latest -steps migrations
+  status  list migrations and when they were applied
+
+flags:
+`
+
+// runMigrate xử lý lệnh "migrate", chạy độc lập với server
+func runMigrate(args []string) {
+	fs := flag.NewFlagSet("migrate", flag.ExitOnError)
+	target := fs.Int64("to", 0, "version to migrate up to (default: latest)")
+	steps := fs.Int("steps", 1, "number of migrations to roll back with down")
+	database := fs.String("db", "", "mongodb or mysql (default: database.active from config)")
+	fs.Usage = func() {
+		fmt.Fprint(fs.Output(), migrateUsage)
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+
+	action := fs.Arg(0)
+	filePath := fs.Arg(1)
+	if filePath == "" {
+		filePath = "configs/config.yaml"
+	}
+
+	config.LoadConfig(filePath)
+	if *database == "" {
+		*database = config.AppConfig.Database.Active
+	}
+
+	migrator, err := newMigrator(*database)
+	if err != nil {
+		log.Fatalf("Failed to initialize migrations: %v", err)
+	}
+
+	ctx := context.Background()
+	switch action {
+	case "up":
+		applied, err := migrator.Up(ctx, *target)
+		if err != nil {
+			log.Fatalf("Migration failed after %d step(s): %v", applied, err)
+		}
+		log.Printf("Applied %d migration(s)", applied)
+	case "down":
+		rolledBack, err := migrator.Down(ctx, *steps)
+		if err != nil {
+			log.Fatalf("Rollback failed after %d step(s): %v", rolledBack, err)
+		}
+		log.Printf("Rolled back %d migration(s)", rolledBack)
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			log.Fatalf("Failed to load migration status: %v", err)
+		}
+		printMigrationStatus(statuses)
+	default:
+		fs.Usage()
+		os.Exit(2)
+	}
+}
+
+// newMigrator kết nối database đã chọn và trả về runner tương ứng
+func newMigrator(database string) (migrate.Migrator, error) {
+	switch database {
+	case "mysql":
+		db.ConnectMySQL()
+		return migration.NewMySQLRunner(db.MySqlDB)
+	case "", "mongodb":
+		return migration.NewMongoRunner(connectMongo(config.AppConfig).Database)
+	default:
+		return nil, fmt.Errorf("unsupported database %q", database)
+	}
+}
+
+func printMigrationStatus(statuses []migrate.Status) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tNAME\tAPPLIED AT")
+	for _, s := range statuses {
+		appliedAt := "pending"
+		if s.AppliedAt != nil {
+			appliedAt = s.AppliedAt.Local().Format(time.RFC3339)
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\n", s.Version, s.Name, appliedAt)
+	}
+	w.Flush()
+}
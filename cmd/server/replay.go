@@ -0,0 +1,52 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+
+	"topic-service/internal/topic/repository"
+	"topic-service/internal/topic/service"
+	"topic-service/pkg/config"
+)
+
+const replayUsage = `usage: api replay [config]
+
+Rebuilds the topics collection from the topic streams on EventStoreDB. Stop every
+running instance first, otherwise their projectors race with the rebuild.
+`
+
+// runReplay xử lý lệnh "replay": dựng lại projection topic từ đầu, chạy độc lập với server
+func runReplay(args []string) {
+	fs := flag.NewFlagSet("replay", flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprint(fs.Output(), replayUsage)
+	}
+	fs.Parse(args)
+
+	filePath := fs.Arg(0)
+	if filePath == "" {
+		filePath = "configs/config.yaml"
+	}
+	config.LoadConfig(filePath)
+	cfg := config.AppConfig
+
+	eventStore, closeEventStore := connectEventStore(cfg)
+	if eventStore == nil {
+		log.Fatal("EventStoreDB is not configured, set event_store.connection_string or EVENT_STORE_CONNECTION_STRING")
+	}
+	defer closeEventStore()
+
+	mongoDB := connectMongo(cfg)
+	defer mongoDB.Close(context.Background())
+
+	repos := repository.NewMongoRepositories(mongoDB.Database)
+	projection := repository.NewMongoTopicProjection(mongoDB.Database)
+
+	count, err := service.RebuildTopicProjection(context.Background(), eventStore, projection, repos.Topic)
+	if err != nil {
+		log.Fatalf("Replay failed after %d event(s): %v", count, err)
+	}
+	log.Printf("Replayed %d topic event(s)", count)
+}
@@ -0,0 +1,35 @@
+package gateway
+
+import (
+	"context"
+	"fmt"
+)
+
+// stubUserGateway trả về user giả, dùng cho chế độ --dev khi không có service user và Consul
+type stubUserGateway struct{}
+
+// NewStubUserGateway khởi tạo UserGateway không gọi ra ngoài
+func NewStubUserGateway() UserGateway {
+	return stubUserGateway{}
+}
+
+func (stubUserGateway) GetAuthorInfo(ctx context.Context, userID string) (*User, error) {
+	return &User{
+		ID:    userID,
+		Name:  fmt.Sprintf("Dev User %s", userID),
+		Email: fmt.Sprintf("%s@dev.local", userID),
+	}, nil
+}
+
+// GetOrganizationAdmins trả về một admin giả cho mỗi tổ chức
+func (stubUserGateway) GetOrganizationAdmins(ctx context.Context, organizationID string) ([]User, error) {
+	if organizationID == "" {
+		return nil, nil
+	}
+	id := "admin-" + organizationID
+	return []User{{
+		ID:    id,
+		Name:  fmt.Sprintf("Dev Admin %s", organizationID),
+		Email: fmt.Sprintf("%s@dev.local", id),
+	}}, nil
+}
@@ -3,7 +3,9 @@ package gateway
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"topic-service/pkg/i18n"
 
 	"github.com/hashicorp/consul/api"
 )
@@ -19,6 +21,7 @@ type User struct {
 // UserGateway là interface để tương tác với service user
 type UserGateway interface {
 	GetAuthorInfo(ctx context.Context, userID string) (*User, error)
+	GetOrganizationAdmins(ctx context.Context, organizationID string) ([]User, error)
 }
 
 // userGatewayImpl là implementation của UserGateway
@@ -39,23 +42,46 @@ func NewUserGateway(serviceName string, consulClient *api.Client) UserGateway {
 func (g *userGatewayImpl) GetAuthorInfo(ctx context.Context, userID string) (*User, error) {
 	token, ok := ctx.Value("token").(string) // hoặc dùng constants.TokenKey
 	if !ok || token == "" {
-		return nil, fmt.Errorf("token không tồn tại trong context")
+		return nil, errors.New(i18n.T(ctx, "gateway.token_missing"))
 	}
 
 	client, err := NewGatewayClient(g.serviceName, token, g.consul, nil)
 	if err != nil {
-		return nil, fmt.Errorf("khởi tạo GatewayClient thất bại: %w", err)
+		return nil, fmt.Errorf("%s: %w", i18n.T(ctx, "gateway.client_init_failed"), err)
 	}
 
 	resp, err := client.Call("GET", "/v1/user/"+userID, nil)
 	if err != nil {
-		return nil, fmt.Errorf("gọi API user thất bại: %w", err)
+		return nil, fmt.Errorf("%s: %w", i18n.T(ctx, "gateway.call_failed"), err)
 	}
 
 	var user User
 	if err := json.Unmarshal(resp, &user); err != nil {
-		return nil, fmt.Errorf("giải mã response thất bại: %w", err)
+		return nil, fmt.Errorf("%s: %w", i18n.T(ctx, "gateway.decode_failed"), err)
 	}
 
 	return &user, nil
 }
+
+// GetOrganizationAdmins lấy danh sách admin của tổ chức. Được gọi cả khi xử lý event nền không có token của user,
+// khi đó request đi qua mạng nội bộ không kèm Authorization.
+func (g *userGatewayImpl) GetOrganizationAdmins(ctx context.Context, organizationID string) ([]User, error) {
+	token, _ := ctx.Value("token").(string)
+
+	client, err := NewGatewayClient(g.serviceName, token, g.consul, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", i18n.T(ctx, "gateway.client_init_failed"), err)
+	}
+
+	resp, err := client.Call("GET", "/v1/organization/"+organizationID+"/admins", nil)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", i18n.T(ctx, "gateway.call_failed"), err)
+	}
+
+	var admins []User
+	if err := json.Unmarshal(resp, &admins); err != nil {
+		return nil, fmt.Errorf("%s: %w", i18n.T(ctx, "gateway.decode_failed"), err)
+	}
+
+	return admins, nil
+}
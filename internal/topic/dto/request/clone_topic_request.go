@@ -0,0 +1,15 @@
+package request
+
+// CloneTopicRequest chọn các dữ liệu liên quan được sao chép sang topic mới
+type CloneTopicRequest struct {
+	// TitleSuffix được nối vào tiêu đề gốc, mặc định " (copy)"
+	TitleSuffix *string `json:"title_suffix"`
+	// IncludeIcon mặc định true
+	IncludeIcon          *bool `json:"include_icon"`
+	IncludeItems         bool  `json:"include_items"`
+	IncludePrerequisites bool  `json:"include_prerequisites"`
+}
+
+type SetTemplateRequest struct {
+	IsTemplate *bool `json:"is_template" binding:"required"`
+}
@@ -1,6 +1,14 @@
 package request
 
 type CreateTopicRequest struct {
+	Title       string `json:"title" binding:"required"`
+	Icon        string `json:"icon" binding:"required"`
+	Description string `json:"description" binding:"max=2000"`
+	// Locale là ngôn ngữ của title/description và trở thành locale mặc định của topic
+	Locale string `json:"locale" binding:"omitempty,oneof=en vi"`
+}
+
+// UpdateTopicRequest chỉ đổi tiêu đề ở locale mặc định, bản dịch khác sửa qua /translations
+type UpdateTopicRequest struct {
 	Title string `json:"title" binding:"required"`
-	Icon  string `json:"icon" binding:"required"`
 }
@@ -0,0 +1,28 @@
+package request
+
+type CurriculumEntryRequest struct {
+	TopicID string `json:"topic_id" binding:"required"`
+	Week    *int   `json:"week" binding:"omitempty,min=1"`
+	Term    string `json:"term"`
+}
+
+type CreateCurriculumRequest struct {
+	Title       string                   `json:"title" binding:"required"`
+	Description string                   `json:"description"`
+	Entries     []CurriculumEntryRequest `json:"entries" binding:"dive"`
+}
+
+type UpdateCurriculumRequest struct {
+	Title       string                   `json:"title" binding:"required"`
+	Description string                   `json:"description"`
+	Entries     []CurriculumEntryRequest `json:"entries" binding:"dive"`
+}
+
+// ReorderCurriculumRequest liệt kê toàn bộ topic của curriculum theo thứ tự mới
+type ReorderCurriculumRequest struct {
+	TopicIDs []string `json:"topic_ids" binding:"required"`
+}
+
+type CloneCurriculumRequest struct {
+	Title string `json:"title"`
+}
@@ -0,0 +1,10 @@
+package request
+
+// MergeTopicsRequest gộp MergedID vào SurvivorID.
+// Resolution chọn giá trị cho từng field xung đột ("survivor" hoặc "merged"),
+// field không được liệt kê giữ giá trị của survivor.
+type MergeTopicsRequest struct {
+	SurvivorID string            `json:"survivor_id" binding:"required"`
+	MergedID   string            `json:"merged_id" binding:"required"`
+	Resolution map[string]string `json:"resolution"`
+}
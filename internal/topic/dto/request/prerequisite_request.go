@@ -0,0 +1,11 @@
+package request
+
+type AddPrerequisiteRequest struct {
+	PrerequisiteID string `json:"prerequisite_id" binding:"required"`
+}
+
+// LearningPathRequest: sắp xếp các topic theo thứ tự học dựa trên prerequisite
+type LearningPathRequest struct {
+	TopicIDs             []string `json:"topic_ids" binding:"required,min=1"`
+	IncludePrerequisites bool     `json:"include_prerequisites"`
+}
@@ -0,0 +1,10 @@
+package request
+
+// TopicAnalyticsQuery là query string của các endpoint thống kê, ngày theo định dạng YYYY-MM-DD.
+// OrganizationID chỉ có tác dụng với admin, người dùng khác luôn bị giới hạn trong tổ chức của mình.
+type TopicAnalyticsQuery struct {
+	From           string `form:"from"`
+	To             string `form:"to"`
+	OrganizationID string `form:"organization_id"`
+	Limit          int    `form:"limit" binding:"omitempty,min=1,max=100"`
+}
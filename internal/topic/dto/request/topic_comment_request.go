@@ -0,0 +1,20 @@
+package request
+
+type CreateCommentRequest struct {
+	Content  string `json:"content" binding:"required,max=5000"`
+	ParentID string `json:"parent_id"`
+}
+
+type UpdateCommentRequest struct {
+	Content string `json:"content" binding:"required,max=5000"`
+}
+
+type ModerateCommentRequest struct {
+	Hidden *bool `json:"hidden" binding:"required"`
+}
+
+// ListCommentsQuery phân trang theo thread gốc
+type ListCommentsQuery struct {
+	Page int `form:"page" binding:"omitempty,min=1"`
+	Size int `form:"size" binding:"omitempty,min=1,max=100"`
+}
@@ -0,0 +1,22 @@
+package request
+
+type CreateTopicItemRequest struct {
+	Word       string   `json:"word" binding:"required"`
+	Images     []string `json:"images"`
+	CoverImage string   `json:"cover_image"`
+	AudioUrl   string   `json:"audio_url"`
+	VideoUrl   string   `json:"video_url"`
+}
+
+type UpdateTopicItemRequest struct {
+	Word       string   `json:"word" binding:"required"`
+	Images     []string `json:"images"`
+	CoverImage string   `json:"cover_image"`
+	AudioUrl   string   `json:"audio_url"`
+	VideoUrl   string   `json:"video_url"`
+}
+
+// ReorderTopicItemsRequest liệt kê toàn bộ item của topic theo thứ tự mới
+type ReorderTopicItemsRequest struct {
+	ItemIDs []string `json:"item_ids" binding:"required"`
+}
@@ -0,0 +1,19 @@
+package request
+
+type RateTopicRequest struct {
+	Score    int    `json:"score" binding:"required,min=1,max=5"`
+	Feedback string `json:"feedback" binding:"max=2000"`
+}
+
+type ListRatingsQuery struct {
+	Page int `form:"page" binding:"omitempty,min=1"`
+	Size int `form:"size" binding:"omitempty,min=1,max=100"`
+}
+
+// ListTopicsQuery lọc và sắp xếp danh sách topic theo đánh giá
+type ListTopicsQuery struct {
+	Sort           string   `form:"sort" binding:"omitempty,oneof=rating -rating rating_count -rating_count created_at -created_at"`
+	MinRating      *float64 `form:"min_rating" binding:"omitempty,min=0,max=5"`
+	MaxRating      *float64 `form:"max_rating" binding:"omitempty,min=0,max=5"`
+	MinRatingCount int      `form:"min_rating_count" binding:"omitempty,min=0"`
+}
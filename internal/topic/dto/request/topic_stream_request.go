@@ -0,0 +1,7 @@
+package request
+
+// StreamTopicsQuery: OrganizationID chỉ có tác dụng với admin, TopicIDs là danh sách ID cách nhau bởi dấu phẩy
+type StreamTopicsQuery struct {
+	OrganizationID string `form:"organization_id"`
+	TopicIDs       string `form:"topic_ids" binding:"omitempty,max=2500"`
+}
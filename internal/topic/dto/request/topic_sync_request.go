@@ -0,0 +1,8 @@
+package request
+
+// TopicChangesQuery: Since là sync token của lần đồng bộ trước, để trống khi client chưa có dữ liệu.
+// Limit là số thay đổi được đọc mỗi trang.
+type TopicChangesQuery struct {
+	Since string `form:"since" binding:"omitempty,max=200"`
+	Limit int    `form:"limit" binding:"omitempty,min=1,max=1000"`
+}
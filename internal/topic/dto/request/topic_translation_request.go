@@ -0,0 +1,14 @@
+package request
+
+type UpsertTranslationRequest struct {
+	Title       string `json:"title" binding:"required,max=200"`
+	Description string `json:"description" binding:"max=2000"`
+	// IsDefault đặt locale này làm locale mặc định của topic
+	IsDefault bool `json:"is_default"`
+}
+
+// SearchTopicsQuery tìm topic theo tiêu đề ở mọi locale, từ cuối được khớp theo tiền tố (typeahead)
+type SearchTopicsQuery struct {
+	Q     string `form:"q" binding:"required,max=200"`
+	Limit int    `form:"limit" binding:"omitempty,min=1,max=50"`
+}
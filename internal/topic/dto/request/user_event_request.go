@@ -0,0 +1,11 @@
+package request
+
+import "time"
+
+type UserEventRequest struct {
+	EventID        string    `json:"event_id" binding:"required"`
+	Type           string    `json:"type" binding:"required"`
+	UserID         string    `json:"user_id" binding:"required"`
+	OrganizationID string    `json:"organization_id"`
+	OccurredAt     time.Time `json:"occurred_at"`
+}
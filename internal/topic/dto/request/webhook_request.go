@@ -0,0 +1,23 @@
+package request
+
+// CreateWebhookSubscriptionRequest: Secret để trống thì service tự sinh, Events rỗng là nhận mọi event
+type CreateWebhookSubscriptionRequest struct {
+	URL    string   `json:"url" binding:"required,http_url,max=2048"`
+	Secret string   `json:"secret" binding:"omitempty,min=16,max=256"`
+	Events []string `json:"events" binding:"omitempty,dive,oneof=TopicCreated TopicUpdated TopicDeleted"`
+	Active *bool    `json:"active"`
+}
+
+// UpdateWebhookSubscriptionRequest chỉ sửa các field được gửi lên, bật lại subscription thì xoá bộ đếm lỗi
+type UpdateWebhookSubscriptionRequest struct {
+	URL    *string   `json:"url" binding:"omitempty,http_url,max=2048"`
+	Secret *string   `json:"secret" binding:"omitempty,min=16,max=256"`
+	Events *[]string `json:"events" binding:"omitempty,dive,oneof=TopicCreated TopicUpdated TopicDeleted"`
+	Active *bool     `json:"active"`
+}
+
+// ListWebhookDeliveriesQuery phân trang nhật ký gửi, mới nhất trước
+type ListWebhookDeliveriesQuery struct {
+	Page int `form:"page" binding:"omitempty,min=1"`
+	Size int `form:"size" binding:"omitempty,min=1,max=100"`
+}
@@ -0,0 +1,32 @@
+package response
+
+import "time"
+
+type CurriculumEntryResponse struct {
+	TopicID    string `json:"topic_id"`
+	TopicTitle string `json:"topic_title,omitempty"`
+	Order      int    `json:"order"`
+	Week       *int   `json:"week,omitempty"`
+	Term       string `json:"term,omitempty"`
+}
+
+type CurriculumResponse struct {
+	ID                 string                    `json:"id"`
+	Title              string                    `json:"title"`
+	Description        string                    `json:"description"`
+	Entries            []CurriculumEntryResponse `json:"entries"`
+	Status             string                    `json:"status"`
+	SourceCurriculumID string                    `json:"source_curriculum_id,omitempty"`
+	CreatedBy          string                    `json:"created_by"`
+	OrganizationID     string                    `json:"organization_id,omitempty"`
+	PublishedAt        *time.Time                `json:"published_at,omitempty"`
+	CreatedAt          time.Time                 `json:"created_at"`
+	UpdatedAt          time.Time                 `json:"updated_at"`
+}
+
+// CurriculumRefResponse dùng khi cần báo curriculum nào đang tham chiếu một topic
+type CurriculumRefResponse struct {
+	ID     string `json:"id"`
+	Title  string `json:"title"`
+	Status string `json:"status"`
+}
@@ -4,4 +4,6 @@ type FailedResponse struct {
 	Code    int    `json:"status_code"`
 	Message string `json:"message"`
 	Error   string `json:"error"`
+	// Data chứa thông tin bổ sung cho lỗi, ví dụ các curriculum đang tham chiếu topic
+	Data interface{} `json:"data,omitempty"`
 }
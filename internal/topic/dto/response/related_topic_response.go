@@ -0,0 +1,8 @@
+package response
+
+type RelatedTopicResponse struct {
+	Topic       TopicResponse `json:"topic"`
+	Score       float64       `json:"score"`
+	TitleScore  float64       `json:"title_score"`
+	CoViewScore float64       `json:"co_view_score"`
+}
@@ -0,0 +1,16 @@
+package response
+
+type TopicPopularityResponse struct {
+	TopicID string `json:"topic_id"`
+	Title   string `json:"title"`
+	Views   int64  `json:"views"`
+}
+
+// TopicTrendResponse so sánh lượt xem trong khoảng được chọn với khoảng liền trước có cùng độ dài
+type TopicTrendResponse struct {
+	TopicID       string  `json:"topic_id"`
+	Title         string  `json:"title"`
+	Views         int64   `json:"views"`
+	PreviousViews int64   `json:"previous_views"`
+	Growth        float64 `json:"growth"`
+}
@@ -0,0 +1,12 @@
+package response
+
+import "time"
+
+type TopicAuditLogResponse struct {
+	ID        string                 `json:"id"`
+	Action    string                 `json:"action"`
+	TopicID   string                 `json:"topic_id"`
+	ActorID   string                 `json:"actor_id"`
+	Details   map[string]interface{} `json:"details"`
+	CreatedAt time.Time              `json:"created_at"`
+}
@@ -0,0 +1,12 @@
+package response
+
+import "time"
+
+// TopicChangeResponse là data của một event SSE, Topic rỗng với TopicDeleted
+type TopicChangeResponse struct {
+	Type           string         `json:"type"`
+	TopicID        string         `json:"topic_id"`
+	OrganizationID string         `json:"organization_id"`
+	OccurredAt     time.Time      `json:"occurred_at"`
+	Topic          *TopicResponse `json:"topic,omitempty"`
+}
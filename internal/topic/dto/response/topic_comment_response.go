@@ -0,0 +1,30 @@
+package response
+
+import "time"
+
+type CommentMentionResponse struct {
+	UserID string `json:"user_id"`
+	Name   string `json:"name"`
+}
+
+type CommentResponse struct {
+	ID        string                   `json:"id"`
+	TopicID   string                   `json:"topic_id"`
+	ParentID  string                   `json:"parent_id,omitempty"`
+	AuthorID  string                   `json:"author_id"`
+	Content   string                   `json:"content"`
+	Mentions  []CommentMentionResponse `json:"mentions"`
+	Hidden    bool                     `json:"hidden"`
+	Deleted   bool                     `json:"deleted"`
+	EditedAt  *time.Time               `json:"edited_at,omitempty"`
+	CreatedAt time.Time                `json:"created_at"`
+	UpdatedAt time.Time                `json:"updated_at"`
+	Replies   []CommentResponse        `json:"replies,omitempty"`
+}
+
+type CommentPageResponse struct {
+	Items []CommentResponse `json:"items"`
+	Page  int               `json:"page"`
+	Size  int               `json:"size"`
+	Total int64             `json:"total"`
+}
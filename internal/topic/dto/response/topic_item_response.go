@@ -0,0 +1,16 @@
+package response
+
+import "time"
+
+type TopicItemResponse struct {
+	ID         string    `json:"id"`
+	TopicID    string    `json:"topic_id"`
+	Word       string    `json:"word"`
+	Images     []string  `json:"images"`
+	CoverImage string    `json:"cover_image"`
+	AudioUrl   string    `json:"audio_url"`
+	VideoUrl   string    `json:"video_url"`
+	Order      int       `json:"order"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
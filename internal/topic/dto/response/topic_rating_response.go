@@ -0,0 +1,20 @@
+package response
+
+import "time"
+
+type RatingResponse struct {
+	ID        string    `json:"id"`
+	TopicID   string    `json:"topic_id"`
+	UserID    string    `json:"user_id"`
+	Score     int       `json:"score"`
+	Feedback  string    `json:"feedback"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type RatingPageResponse struct {
+	Items []RatingResponse `json:"items"`
+	Page  int              `json:"page"`
+	Size  int              `json:"size"`
+	Total int64            `json:"total"`
+}
@@ -3,9 +3,28 @@ package response
 import "time"
 
 type TopicResponse struct {
-	ID        string    `json:"id"`
-	Title     string    `json:"title"`
-	Icon      string    `json:"icon"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID          string `json:"id"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	// Locale là locale của Title/Description đã chọn theo ngôn ngữ của request
+	Locale          string            `json:"locale,omitempty"`
+	DefaultLocale   string            `json:"default_locale,omitempty"`
+	Titles          map[string]string `json:"titles,omitempty"`
+	Descriptions    map[string]string `json:"descriptions,omitempty"`
+	Icon            string            `json:"icon"`
+	ItemCount       int               `json:"item_count"`
+	CommentCount    int               `json:"comment_count"`
+	RatingCount     int               `json:"rating_count"`
+	RatingAverage   float64           `json:"rating_average"`
+	PrerequisiteIDs []string          `json:"prerequisite_ids"`
+	CreatedBy       string            `json:"created_by"`
+	OrganizationID  string            `json:"organization_id"`
+	IsTemplate      bool              `json:"is_template"`
+	IsFavorite      bool              `json:"is_favorite"`
+	SourceTopicID   string            `json:"source_topic_id,omitempty"`
+	ClonedAt        *time.Time        `json:"cloned_at,omitempty"`
+	// RedirectedFrom là ID được yêu cầu khi topic đó đã bị gộp vào topic này
+	RedirectedFrom string    `json:"redirected_from,omitempty"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
 }
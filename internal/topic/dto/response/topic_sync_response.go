@@ -0,0 +1,19 @@
+package response
+
+import "time"
+
+// TopicTombstoneResponse đánh dấu topic đã bị xoá (hoặc gộp vào topic khác) để client xoá bản lưu offline
+type TopicTombstoneResponse struct {
+	ID        string    `json:"id"`
+	DeletedAt time.Time `json:"deleted_at"`
+}
+
+// TopicChangesResponse là một trang thay đổi. ResyncRequired true thì client phải bỏ dữ liệu đang có,
+// tải lại toàn bộ danh sách topic rồi đồng bộ tiếp từ NextToken.
+type TopicChangesResponse struct {
+	Topics         []TopicResponse          `json:"topics"`
+	Deleted        []TopicTombstoneResponse `json:"deleted"`
+	NextToken      string                   `json:"next_token"`
+	HasMore        bool                     `json:"has_more"`
+	ResyncRequired bool                     `json:"resync_required"`
+}
@@ -0,0 +1,12 @@
+package response
+
+type TopicTranslationResponse struct {
+	Title       string `json:"title"`
+	Description string `json:"description"`
+}
+
+type TopicTranslationsResponse struct {
+	TopicID       string                              `json:"topic_id"`
+	DefaultLocale string                              `json:"default_locale"`
+	Translations  map[string]TopicTranslationResponse `json:"translations"`
+}
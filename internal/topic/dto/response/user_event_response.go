@@ -0,0 +1,12 @@
+package response
+
+// UserEventResultResponse là kết quả xử lý một event vòng đời user
+type UserEventResultResponse struct {
+	// Duplicate: event đã được xử lý trước đó nên không làm gì thêm
+	Duplicate bool `json:"duplicate"`
+	// Ignored: loại event không cần xử lý
+	Ignored     bool `json:"ignored"`
+	Transferred int  `json:"transferred"`
+	// Skipped là số topic chưa chuyển được vì không có admin của tổ chức và không cấu hình fallback owner
+	Skipped int `json:"skipped"`
+}
@@ -0,0 +1,41 @@
+package response
+
+import "time"
+
+type WebhookSubscriptionResponse struct {
+	ID             string `json:"id"`
+	OrganizationID string `json:"organization_id"`
+	URL            string `json:"url"`
+	// Secret chỉ có trong response lúc tạo subscription hoặc đổi secret
+	Secret              string     `json:"secret,omitempty"`
+	Events              []string   `json:"events"`
+	Active              bool       `json:"active"`
+	ConsecutiveFailures int        `json:"consecutive_failures"`
+	DisabledAt          *time.Time `json:"disabled_at,omitempty"`
+	DisabledReason      string     `json:"disabled_reason,omitempty"`
+	CreatedBy           string     `json:"created_by"`
+	CreatedAt           time.Time  `json:"created_at"`
+	UpdatedAt           time.Time  `json:"updated_at"`
+}
+
+type WebhookDeliveryResponse struct {
+	ID             string     `json:"id"`
+	SubscriptionID string     `json:"subscription_id"`
+	EventID        string     `json:"event_id"`
+	EventType      string     `json:"event_type"`
+	ReplayOf       string     `json:"replay_of,omitempty"`
+	Status         string     `json:"status"`
+	Attempts       int        `json:"attempts"`
+	LastStatusCode int        `json:"last_status_code,omitempty"`
+	LastError      string     `json:"last_error,omitempty"`
+	NextAttemptAt  *time.Time `json:"next_attempt_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	DeliveredAt    *time.Time `json:"delivered_at,omitempty"`
+}
+
+type WebhookDeliveryPageResponse struct {
+	Items []WebhookDeliveryResponse `json:"items"`
+	Page  int                       `json:"page"`
+	Size  int                       `json:"size"`
+	Total int64                     `json:"total"`
+}
@@ -0,0 +1,101 @@
+package fixture
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+	"topic-service/internal/topic/model"
+	"topic-service/internal/topic/repository"
+	"topic-service/pkg/i18n"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Fixture là dữ liệu mẫu nạp vào repository khi chạy --dev, ObjectID viết dạng chuỗi hex
+type Fixture struct {
+	Topics     []*model.Topic      `json:"topics"`
+	TopicItems []*model.TopicItem  `json:"topic_items"`
+	Curricula  []*model.Curriculum `json:"curricula"`
+}
+
+// Load đọc file fixture JSON và ghi dữ liệu qua repository.
+// item_count của topic được tính lại từ topic_items trong file.
+func Load(ctx context.Context, repos *repository.Repositories, path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("read fixture: %w", err)
+	}
+
+	var f Fixture
+	if err := json.Unmarshal(data, &f); err != nil {
+		return fmt.Errorf("decode fixture: %w", err)
+	}
+
+	now := time.Now()
+	for _, topic := range f.Topics {
+		if topic.ID.IsZero() {
+			topic.ID = primitive.NewObjectID()
+		}
+		if topic.DefaultLocale == "" {
+			topic.DefaultLocale = i18n.DefaultLocale
+		}
+		if topic.Titles[topic.DefaultLocale] == "" {
+			topic.SetTranslation(topic.DefaultLocale, topic.Title, topic.Descriptions[topic.DefaultLocale])
+		} else {
+			topic.Title = topic.Titles[topic.DefaultLocale]
+		}
+		topic.RefreshSearchTerms()
+		topic.ItemCount = 0
+		if topic.PrerequisiteIDs == nil {
+			topic.PrerequisiteIDs = []string{}
+		}
+		if topic.CreatedAt.IsZero() {
+			topic.CreatedAt = now
+		}
+		if topic.UpdatedAt.IsZero() {
+			topic.UpdatedAt = topic.CreatedAt
+		}
+		if _, err := repos.Topic.Create(ctx, topic); err != nil {
+			return fmt.Errorf("create topic %q: %w", topic.Title, err)
+		}
+	}
+
+	for _, item := range f.TopicItems {
+		if item.ID.IsZero() {
+			item.ID = primitive.NewObjectID()
+		}
+		if item.CreatedAt.IsZero() {
+			item.CreatedAt = now
+		}
+		if item.UpdatedAt.IsZero() {
+			item.UpdatedAt = item.CreatedAt
+		}
+		if _, err := repos.TopicItem.Create(ctx, item); err != nil {
+			return fmt.Errorf("create item %q: %w", item.Word, err)
+		}
+		if err := repos.Topic.IncrementItemCount(ctx, item.TopicID.Hex(), 1); err != nil {
+			return fmt.Errorf("item %q: topic %s: %w", item.Word, item.TopicID.Hex(), err)
+		}
+	}
+
+	for _, curriculum := range f.Curricula {
+		if curriculum.ID.IsZero() {
+			curriculum.ID = primitive.NewObjectID()
+		}
+		if curriculum.Status == "" {
+			curriculum.Status = model.CurriculumStatusDraft
+		}
+		if curriculum.CreatedAt.IsZero() {
+			curriculum.CreatedAt = now
+		}
+		if curriculum.UpdatedAt.IsZero() {
+			curriculum.UpdatedAt = curriculum.CreatedAt
+		}
+		if _, err := repos.Curriculum.Create(ctx, curriculum); err != nil {
+			return fmt.Errorf("create curriculum %q: %w", curriculum.Title, err)
+		}
+	}
+	return nil
+}
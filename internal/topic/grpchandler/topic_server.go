@@ -0,0 +1,214 @@
+package grpchandler
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"slices"
+	"topic-service/internal/topic/dto/request"
+	"topic-service/internal/topic/dto/response"
+	"topic-service/internal/topic/mapper"
+	"topic-service/internal/topic/middleware"
+	"topic-service/internal/topic/model"
+	"topic-service/internal/topic/service"
+	"topic-service/pkg/i18n"
+	topicv1 "topic-service/pkg/pb/topic/v1"
+
+	"github.com/gin-gonic/gin/binding"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// TopicServer cài đặt topicv1.TopicServiceServer trên cùng TopicService với API HTTP
+type TopicServer struct {
+	topicv1.UnimplementedTopicServiceServer
+	service service.TopicService
+}
+
+func NewTopicServer(service service.TopicService) *TopicServer {
+	return &TopicServer{service: service}
+}
+
+func (s *TopicServer) CreateTopic(ctx context.Context, req *topicv1.CreateTopicRequest) (*topicv1.Topic, error) {
+	dto := &request.CreateTopicRequest{
+		Title:       req.GetTitle(),
+		Icon:        req.GetIcon(),
+		Description: req.GetDescription(),
+		Locale:      req.GetLocale(),
+	}
+	if err := validate(ctx, dto); err != nil {
+		return nil, err
+	}
+
+	result, err := s.service.CreateTopic(ctx, currentActor(ctx), dto)
+	if err != nil {
+		return nil, handleError(ctx, err, "topic.create_failed")
+	}
+	return mapper.MapTopicResponseToProto(result), nil
+}
+
+// GetTopic không ghi lượt xem như API HTTP vì request đến từ service khác, không phải người dùng
+func (s *TopicServer) GetTopic(ctx context.Context, req *topicv1.GetTopicRequest) (*topicv1.Topic, error) {
+	if err := validateID(ctx, req.GetId()); err != nil {
+		return nil, err
+	}
+
+	topic, err := s.visibleTopic(ctx, req.GetId())
+	if err != nil {
+		return nil, handleError(ctx, err, "topic.get_failed")
+	}
+	return mapper.MapTopicResponseToProto(topic), nil
+}
+
+func (s *TopicServer) BatchGetTopics(ctx context.Context, req *topicv1.BatchGetTopicsRequest) (*topicv1.BatchGetTopicsResponse, error) {
+	for _, id := range req.GetIds() {
+		if err := validateID(ctx, id); err != nil {
+			return nil, err
+		}
+	}
+
+	topics, notFound, err := s.service.BatchGetTopics(ctx, currentActor(ctx), req.GetIds())
+	if err != nil {
+		return nil, handleError(ctx, err, "topic.get_failed")
+	}
+	return &topicv1.BatchGetTopicsResponse{
+		Topics:      mapper.MapTopicResponsesToProto(topics),
+		NotFoundIds: notFound,
+	}, nil
+}
+
+func (s *TopicServer) UpdateTopic(ctx context.Context, req *topicv1.UpdateTopicRequest) (*topicv1.Topic, error) {
+	if err := validateID(ctx, req.GetId()); err != nil {
+		return nil, err
+	}
+	dto := &request.UpdateTopicRequest{Title: req.GetTitle()}
+	if err := validate(ctx, dto); err != nil {
+		return nil, err
+	}
+
+	if err := s.service.UpdateTopic(ctx, currentActor(ctx), req.GetId(), &model.Topic{Title: dto.Title}); err != nil {
+		return nil, handleError(ctx, err, "topic.update_failed")
+	}
+	topic, err := s.visibleTopic(ctx, req.GetId())
+	if err != nil {
+		return nil, handleError(ctx, err, "topic.update_failed")
+	}
+	return mapper.MapTopicResponseToProto(topic), nil
+}
+
+func (s *TopicServer) DeleteTopic(ctx context.Context, req *topicv1.DeleteTopicRequest) (*topicv1.DeleteTopicResponse, error) {
+	if err := validateID(ctx, req.GetId()); err != nil {
+		return nil, err
+	}
+
+	if err := s.service.DeleteTopic(ctx, currentActor(ctx), req.GetId()); err != nil {
+		return nil, handleError(ctx, err, "topic.delete_failed")
+	}
+	return &topicv1.DeleteTopicResponse{}, nil
+}
+
+func (s *TopicServer) ListTopics(ctx context.Context, req *topicv1.ListTopicsRequest) (*topicv1.ListTopicsResponse, error) {
+	query := &request.ListTopicsQuery{
+		Sort:           req.GetSort(),
+		MinRating:      req.MinRating,
+		MaxRating:      req.MaxRating,
+		MinRatingCount: int(req.GetMinRatingCount()),
+	}
+	if err := validate(ctx, query); err != nil {
+		return nil, err
+	}
+
+	topics, err := s.service.ListTopics(ctx, currentActor(ctx), query)
+	if err != nil {
+		return nil, handleError(ctx, err, "topics.list_failed")
+	}
+	return &topicv1.ListTopicsResponse{Topics: mapper.MapTopicResponsesToProto(topics)}, nil
+}
+
+func (s *TopicServer) SearchTopics(ctx context.Context, req *topicv1.SearchTopicsRequest) (*topicv1.SearchTopicsResponse, error) {
+	query := &request.SearchTopicsQuery{Q: req.GetQ(), Limit: int(req.GetLimit())}
+	if err := validate(ctx, query); err != nil {
+		return nil, err
+	}
+
+	topics, err := s.service.SearchTopics(ctx, currentActor(ctx), query)
+	if err != nil {
+		return nil, handleError(ctx, err, "topics.search_failed")
+	}
+	return &topicv1.SearchTopicsResponse{Topics: mapper.MapTopicResponsesToProto(topics)}, nil
+}
+
+// visibleTopic đọc topic (kể cả qua redirect) mà actor được xem, topic của tổ chức khác trả về NotFound
+func (s *TopicServer) visibleTopic(ctx context.Context, id string) (*response.TopicResponse, error) {
+	topics, _, err := s.service.BatchGetTopics(ctx, currentActor(ctx), []string{id})
+	if err != nil {
+		return nil, err
+	}
+	if len(topics) == 0 {
+		return nil, mongo.ErrNoDocuments
+	}
+	return &topics[0], nil
+}
+
+// currentActor dựng Actor từ claims mà UnaryAuthInterceptor đã gắn vào context
+func currentActor(ctx context.Context) service.Actor {
+	claims, _ := middleware.ClaimsFromContext(ctx)
+	return service.Actor{
+		UserID:         claims.UserID,
+		OrganizationID: claims.OrganizationID,
+		IsAdmin:        middleware.HasAdminRole(claims.UserRoles),
+	}
+}
+
+// validate dùng chung tag binding của DTO với API HTTP, lỗi trả về InvalidArgument kèm lỗi theo từng field
+func validate(ctx context.Context, obj any) error {
+	err := binding.Validator.ValidateStruct(obj)
+	if err == nil {
+		return nil
+	}
+	return invalidArgument(ctx, "request.invalid_body", i18n.ValidationErrors(ctx, err))
+}
+
+func validateID(ctx context.Context, id string) error {
+	if primitive.IsValidObjectID(id) {
+		return nil
+	}
+	return invalidArgument(ctx, "request.invalid_id", map[string]string{"id": i18n.T(ctx, "request.invalid_id")})
+}
+
+func invalidArgument(ctx context.Context, messageKey string, fields map[string]string) error {
+	st := status.New(codes.InvalidArgument, i18n.T(ctx, messageKey))
+	if len(fields) == 0 {
+		return st.Err()
+	}
+
+	details := &errdetails.BadRequest{}
+	for _, field := range slices.Sorted(maps.Keys(fields)) {
+		details.FieldViolations = append(details.FieldViolations, &errdetails.BadRequest_FieldViolation{
+			Field:       field,
+			Description: fields[field],
+		})
+	}
+	if withDetails, err := st.WithDetails(details); err == nil {
+		st = withDetails
+	}
+	return st.Err()
+}
+
+// handleError chuyển lỗi của service thành status gRPC tương ứng với mã HTTP của API REST
+func handleError(ctx context.Context, err error, messageKey string) error {
+	var inUse *service.TopicInUseError
+	switch {
+	case errors.Is(err, mongo.ErrNoDocuments):
+		return status.Error(codes.NotFound, i18n.T(ctx, "topic.not_found"))
+	case errors.As(err, &inUse):
+		return status.Error(codes.FailedPrecondition, i18n.T(ctx, "topic.in_use_by_curricula"))
+	case errors.Is(err, service.ErrTooManyTopicIDs):
+		return status.Error(codes.InvalidArgument, i18n.T(ctx, "topic.too_many_ids", service.MaxBatchTopicIDs))
+	default:
+		return status.Errorf(codes.Internal, "%s: %v", i18n.T(ctx, messageKey), err)
+	}
+}
@@ -0,0 +1,40 @@
+package handler
+
+import (
+	"net/http"
+
+	"topic-service/internal/topic/dto/response"
+	"topic-service/internal/topic/middleware"
+	"topic-service/internal/topic/service"
+	"topic-service/pkg/constants"
+	"topic-service/pkg/i18n"
+
+	"github.com/gin-gonic/gin"
+)
+
+// currentUserID lấy user_id do middleware.Secured gắn vào context
+func currentUserID(c *gin.Context) string {
+	return c.GetString(constants.UserID)
+}
+
+// currentActor gom các thông tin của user hiện tại cho tầng service
+func currentActor(c *gin.Context) service.Actor {
+	return service.Actor{
+		UserID:         c.GetString(constants.UserID),
+		OrganizationID: c.GetString(constants.OrganizationID),
+		IsAdmin:        middleware.IsAdmin(c),
+	}
+}
+
+// respondBindError trả về 400 với lỗi validate đã được dịch theo từng field
+func respondBindError(c *gin.Context, err error, messageKey string) {
+	res := response.FailedResponse{
+		Code:    http.StatusBadRequest,
+		Message: i18n.T(c, messageKey),
+		Error:   i18n.TranslateError(c, err),
+	}
+	if fields := i18n.ValidationErrors(c, err); fields != nil {
+		res.Data = fields
+	}
+	c.JSON(http.StatusBadRequest, res)
+}
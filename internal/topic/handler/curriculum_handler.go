@@ -0,0 +1,226 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"topic-service/internal/topic/dto/request"
+	"topic-service/internal/topic/dto/response"
+	"topic-service/internal/topic/service"
+	"topic-service/pkg/i18n"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type CurriculumHandler struct {
+	service service.CurriculumService
+}
+
+func NewCurriculumHandler(service service.CurriculumService) *CurriculumHandler {
+	return &CurriculumHandler{service: service}
+}
+
+// POST /curriculum
+func (h *CurriculumHandler) CreateCurriculum(c *gin.Context) {
+	var req request.CreateCurriculumRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err, "request.invalid_body")
+		return
+	}
+
+	result, err := h.service.CreateCurriculum(c.Request.Context(), currentActor(c), &req)
+	if err != nil {
+		h.handleError(c, err, "curriculum.create_failed")
+		return
+	}
+
+	c.JSON(http.StatusCreated, response.SucceedResponse{
+		Code:    http.StatusCreated,
+		Message: i18n.T(c, "curriculum.created"),
+		Data:    result,
+	})
+}
+
+// GET /curriculum/:id
+func (h *CurriculumHandler) GetCurriculumByID(c *gin.Context) {
+	id := c.Param("id")
+
+	curriculum, err := h.service.GetCurriculumByID(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, response.FailedResponse{
+			Code:    http.StatusNotFound,
+			Message: i18n.T(c, "curriculum.not_found"),
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, response.SucceedResponse{
+		Code:    http.StatusOK,
+		Message: i18n.T(c, "curriculum.retrieved"),
+		Data:    curriculum,
+	})
+}
+
+// GET /curriculum
+func (h *CurriculumHandler) ListCurricula(c *gin.Context) {
+	curricula, err := h.service.ListCurricula(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.FailedResponse{
+			Code:    http.StatusInternalServerError,
+			Message: i18n.T(c, "curricula.list_failed"),
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, response.SucceedResponse{
+		Code:    http.StatusOK,
+		Message: i18n.T(c, "curricula.retrieved"),
+		Data:    curricula,
+	})
+}
+
+// PUT /curriculum/:id
+func (h *CurriculumHandler) UpdateCurriculum(c *gin.Context) {
+	id := c.Param("id")
+
+	var req request.UpdateCurriculumRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err, "request.invalid_body")
+		return
+	}
+
+	result, err := h.service.UpdateCurriculum(c.Request.Context(), currentActor(c), id, &req)
+	if err != nil {
+		h.handleError(c, err, "curriculum.update_failed")
+		return
+	}
+
+	c.JSON(http.StatusOK, response.SucceedResponse{
+		Code:    http.StatusOK,
+		Message: i18n.T(c, "curriculum.updated"),
+		Data:    result,
+	})
+}
+
+// PUT /curriculum/:id/order
+func (h *CurriculumHandler) ReorderCurriculum(c *gin.Context) {
+	id := c.Param("id")
+
+	var req request.ReorderCurriculumRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err, "request.invalid_body")
+		return
+	}
+
+	result, err := h.service.ReorderCurriculum(c.Request.Context(), currentActor(c), id, &req)
+	if err != nil {
+		h.handleError(c, err, "curriculum.reorder_failed")
+		return
+	}
+
+	c.JSON(http.StatusOK, response.SucceedResponse{
+		Code:    http.StatusOK,
+		Message: i18n.T(c, "curriculum.reordered"),
+		Data:    result,
+	})
+}
+
+// POST /curriculum/:id/clone
+func (h *CurriculumHandler) CloneCurriculum(c *gin.Context) {
+	id := c.Param("id")
+
+	var req request.CloneCurriculumRequest
+	// Body không bắt buộc
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			respondBindError(c, err, "request.invalid_body")
+			return
+		}
+	}
+
+	result, err := h.service.CloneCurriculum(c.Request.Context(), currentActor(c), id, &req)
+	if err != nil {
+		h.handleError(c, err, "curriculum.clone_failed")
+		return
+	}
+
+	c.JSON(http.StatusCreated, response.SucceedResponse{
+		Code:    http.StatusCreated,
+		Message: i18n.T(c, "curriculum.cloned"),
+		Data:    result,
+	})
+}
+
+// POST /curriculum/:id/publish
+func (h *CurriculumHandler) PublishCurriculum(c *gin.Context) {
+	id := c.Param("id")
+
+	result, err := h.service.PublishCurriculum(c.Request.Context(), currentActor(c), id)
+	if err != nil {
+		h.handleError(c, err, "curriculum.publish_failed")
+		return
+	}
+
+	c.JSON(http.StatusOK, response.SucceedResponse{
+		Code:    http.StatusOK,
+		Message: i18n.T(c, "curriculum.published"),
+		Data:    result,
+	})
+}
+
+// DELETE /curriculum/:id
+func (h *CurriculumHandler) DeleteCurriculum(c *gin.Context) {
+	id := c.Param("id")
+
+	err := h.service.DeleteCurriculum(c.Request.Context(), currentActor(c), id)
+	if err != nil {
+		h.handleError(c, err, "curriculum.delete_failed")
+		return
+	}
+
+	c.JSON(http.StatusOK, response.SucceedResponse{
+		Code:    http.StatusOK,
+		Message: i18n.T(c, "curriculum.deleted"),
+		Data:    nil,
+	})
+}
+
+func (h *CurriculumHandler) handleError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, mongo.ErrNoDocuments):
+		c.JSON(http.StatusNotFound, response.FailedResponse{
+			Code:    http.StatusNotFound,
+			Message: i18n.T(c, "curriculum.not_found"),
+			Error:   err.Error(),
+		})
+	case errors.Is(err, service.ErrCurriculumTopicNotFound),
+		errors.Is(err, service.ErrCurriculumDuplicateTopic),
+		errors.Is(err, service.ErrInvalidCurriculumOrder):
+		c.JSON(http.StatusBadRequest, response.FailedResponse{
+			Code:    http.StatusBadRequest,
+			Message: i18n.T(c, message),
+			Error:   err.Error(),
+		})
+	case errors.Is(err, service.ErrCurriculumForbidden):
+		c.JSON(http.StatusForbidden, response.FailedResponse{
+			Code:    http.StatusForbidden,
+			Message: i18n.T(c, message),
+			Error:   err.Error(),
+		})
+	case errors.Is(err, service.ErrCurriculumPublished):
+		c.JSON(http.StatusConflict, response.FailedResponse{
+			Code:    http.StatusConflict,
+			Message: i18n.T(c, message),
+			Error:   err.Error(),
+		})
+	default:
+		c.JSON(http.StatusInternalServerError, response.FailedResponse{
+			Code:    http.StatusInternalServerError,
+			Message: i18n.T(c, message),
+			Error:   err.Error(),
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"topic-service/internal/topic/dto/request"
+	"topic-service/internal/topic/dto/response"
+	"topic-service/internal/topic/service"
+	"topic-service/pkg/i18n"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type MergeHandler struct {
+	service service.MergeService
+}
+
+func NewMergeHandler(service service.MergeService) *MergeHandler {
+	return &MergeHandler{service: service}
+}
+
+// POST /topic/merge
+func (h *MergeHandler) MergeTopics(c *gin.Context) {
+	var req request.MergeTopicsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err, "request.invalid_body")
+		return
+	}
+
+	result, err := h.service.MergeTopics(c.Request.Context(), currentUserID(c), &req)
+	if err != nil {
+		switch {
+		case errors.Is(err, mongo.ErrNoDocuments):
+			c.JSON(http.StatusNotFound, response.FailedResponse{
+				Code:    http.StatusNotFound,
+				Message: i18n.T(c, "topic.not_found"),
+				Error:   err.Error(),
+			})
+		case errors.Is(err, service.ErrMergeSameTopic),
+			errors.Is(err, service.ErrInvalidMergeResolution):
+			c.JSON(http.StatusBadRequest, response.FailedResponse{
+				Code:    http.StatusBadRequest,
+				Message: i18n.T(c, "topics.invalid_merge_request"),
+				Error:   err.Error(),
+			})
+		case errors.Is(err, service.ErrMergeCycle):
+			c.JSON(http.StatusConflict, response.FailedResponse{
+				Code:    http.StatusConflict,
+				Message: i18n.T(c, "topics.merge_failed"),
+				Error:   err.Error(),
+			})
+		default:
+			c.JSON(http.StatusInternalServerError, response.FailedResponse{
+				Code:    http.StatusInternalServerError,
+				Message: i18n.T(c, "topics.merge_failed"),
+				Error:   err.Error(),
+			})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, response.SucceedResponse{
+		Code:    http.StatusOK,
+		Message: i18n.T(c, "topics.merged"),
+		Data:    result,
+	})
+}
+
+// GET /topic/:id/audit
+func (h *MergeHandler) GetAuditTrail(c *gin.Context) {
+	id := c.Param("id")
+
+	logs, err := h.service.GetAuditTrail(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.FailedResponse{
+			Code:    http.StatusInternalServerError,
+			Message: i18n.T(c, "topic.audit_trail_get_failed"),
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, response.SucceedResponse{
+		Code:    http.StatusOK,
+		Message: i18n.T(c, "topic.audit_trail_retrieved"),
+		Data:    logs,
+	})
+}
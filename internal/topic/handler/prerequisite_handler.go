@@ -0,0 +1,124 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"topic-service/internal/topic/dto/request"
+	"topic-service/internal/topic/dto/response"
+	"topic-service/internal/topic/service"
+	"topic-service/pkg/i18n"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type PrerequisiteHandler struct {
+	service service.PrerequisiteService
+}
+
+func NewPrerequisiteHandler(service service.PrerequisiteService) *PrerequisiteHandler {
+	return &PrerequisiteHandler{service: service}
+}
+
+// POST /topic/:id/prerequisites
+func (h *PrerequisiteHandler) AddPrerequisite(c *gin.Context) {
+	topicID := c.Param("id")
+
+	var req request.AddPrerequisiteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err, "request.invalid_body")
+		return
+	}
+
+	topic, err := h.service.AddPrerequisite(c.Request.Context(), topicID, &req)
+	if err != nil {
+		h.handleError(c, err, "prerequisite.add_failed")
+		return
+	}
+
+	c.JSON(http.StatusOK, response.SucceedResponse{
+		Code:    http.StatusOK,
+		Message: i18n.T(c, "prerequisite.added"),
+		Data:    topic,
+	})
+}
+
+// DELETE /topic/:id/prerequisites/:prerequisite_id
+func (h *PrerequisiteHandler) RemovePrerequisite(c *gin.Context) {
+	topicID := c.Param("id")
+	prerequisiteID := c.Param("prerequisite_id")
+
+	err := h.service.RemovePrerequisite(c.Request.Context(), topicID, prerequisiteID)
+	if err != nil {
+		h.handleError(c, err, "prerequisite.remove_failed")
+		return
+	}
+
+	c.JSON(http.StatusOK, response.SucceedResponse{
+		Code:    http.StatusOK,
+		Message: i18n.T(c, "prerequisite.removed"),
+		Data:    nil,
+	})
+}
+
+// GET /topic/:id/prerequisites
+func (h *PrerequisiteHandler) GetPrerequisites(c *gin.Context) {
+	topicID := c.Param("id")
+
+	topics, err := h.service.GetPrerequisites(c.Request.Context(), topicID)
+	if err != nil {
+		h.handleError(c, err, "prerequisites.get_failed")
+		return
+	}
+
+	c.JSON(http.StatusOK, response.SucceedResponse{
+		Code:    http.StatusOK,
+		Message: i18n.T(c, "prerequisites.retrieved"),
+		Data:    topics,
+	})
+}
+
+// POST /topic/learning-path
+func (h *PrerequisiteHandler) GetLearningPath(c *gin.Context) {
+	var req request.LearningPathRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err, "request.invalid_body")
+		return
+	}
+
+	topics, err := h.service.GetLearningPath(c.Request.Context(), &req)
+	if err != nil {
+		h.handleError(c, err, "learning_path.build_failed")
+		return
+	}
+
+	c.JSON(http.StatusOK, response.SucceedResponse{
+		Code:    http.StatusOK,
+		Message: i18n.T(c, "learning_path.retrieved"),
+		Data:    topics,
+	})
+}
+
+func (h *PrerequisiteHandler) handleError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, mongo.ErrNoDocuments):
+		c.JSON(http.StatusNotFound, response.FailedResponse{
+			Code:    http.StatusNotFound,
+			Message: i18n.T(c, "topic.not_found"),
+			Error:   err.Error(),
+		})
+	case errors.Is(err, service.ErrPrerequisiteCycle), errors.Is(err, service.ErrPrerequisiteBusy):
+		c.JSON(http.StatusConflict, response.FailedResponse{
+			Code:    http.StatusConflict,
+			Message: i18n.T(c, message),
+			Error:   err.Error(),
+		})
+	default:
+		c.JSON(http.StatusInternalServerError, response.FailedResponse{
+			Code:    http.StatusInternalServerError,
+			Message: i18n.T(c, message),
+			Error:   err.Error(),
+		})
+	}
+}
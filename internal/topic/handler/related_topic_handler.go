@@ -0,0 +1,57 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"topic-service/internal/topic/dto/request"
+	"topic-service/internal/topic/dto/response"
+	"topic-service/internal/topic/service"
+	"topic-service/pkg/i18n"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type RelatedTopicHandler struct {
+	service service.RelatedTopicService
+}
+
+func NewRelatedTopicHandler(service service.RelatedTopicService) *RelatedTopicHandler {
+	return &RelatedTopicHandler{service: service}
+}
+
+// GET /topic/:id/related
+func (h *RelatedTopicHandler) GetRelated(c *gin.Context) {
+	topicID := c.Param("id")
+
+	var query request.RelatedTopicsQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		respondBindError(c, err, "request.invalid_query")
+		return
+	}
+
+	result, err := h.service.GetRelated(c.Request.Context(), currentActor(c), topicID, query.Limit)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			c.JSON(http.StatusNotFound, response.FailedResponse{
+				Code:    http.StatusNotFound,
+				Message: i18n.T(c, "topic.not_found"),
+				Error:   err.Error(),
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, response.FailedResponse{
+			Code:    http.StatusInternalServerError,
+			Message: i18n.T(c, "related_topics.get_failed"),
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, response.SucceedResponse{
+		Code:    http.StatusOK,
+		Message: i18n.T(c, "related_topics.retrieved"),
+		Data:    result,
+	})
+}
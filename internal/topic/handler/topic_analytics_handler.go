@@ -0,0 +1,102 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"topic-service/internal/topic/dto/request"
+	"topic-service/internal/topic/dto/response"
+	"topic-service/internal/topic/service"
+	"topic-service/pkg/i18n"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TopicAnalyticsHandler struct {
+	service service.TopicAnalyticsService
+}
+
+func NewTopicAnalyticsHandler(service service.TopicAnalyticsService) *TopicAnalyticsHandler {
+	return &TopicAnalyticsHandler{service: service}
+}
+
+// GET /topic/analytics/most-viewed
+func (h *TopicAnalyticsHandler) MostViewed(c *gin.Context) {
+	var query request.TopicAnalyticsQuery
+	if !h.bindQuery(c, &query) {
+		return
+	}
+
+	result, err := h.service.MostViewed(c.Request.Context(), currentActor(c), &query)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.SucceedResponse{
+		Code:    http.StatusOK,
+		Message: i18n.T(c, "analytics.most_viewed_retrieved"),
+		Data:    result,
+	})
+}
+
+// GET /topic/analytics/trending
+func (h *TopicAnalyticsHandler) Trending(c *gin.Context) {
+	var query request.TopicAnalyticsQuery
+	if !h.bindQuery(c, &query) {
+		return
+	}
+
+	result, err := h.service.Trending(c.Request.Context(), currentActor(c), &query)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.SucceedResponse{
+		Code:    http.StatusOK,
+		Message: i18n.T(c, "analytics.trending_retrieved"),
+		Data:    result,
+	})
+}
+
+// GET /topic/analytics/never-used
+func (h *TopicAnalyticsHandler) NeverUsed(c *gin.Context) {
+	var query request.TopicAnalyticsQuery
+	if !h.bindQuery(c, &query) {
+		return
+	}
+
+	result, err := h.service.NeverUsed(c.Request.Context(), currentActor(c), &query)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.SucceedResponse{
+		Code:    http.StatusOK,
+		Message: i18n.T(c, "analytics.never_used_retrieved"),
+		Data:    result,
+	})
+}
+
+func (h *TopicAnalyticsHandler) bindQuery(c *gin.Context, query *request.TopicAnalyticsQuery) bool {
+	if err := c.ShouldBindQuery(query); err != nil {
+		respondBindError(c, err, "request.invalid_query")
+		return false
+	}
+	return true
+}
+
+func (h *TopicAnalyticsHandler) handleError(c *gin.Context, err error) {
+	if errors.Is(err, service.ErrInvalidDateRange) {
+		respondBindError(c, err, "request.invalid_query")
+		return
+	}
+
+	c.JSON(http.StatusInternalServerError, response.FailedResponse{
+		Code:    http.StatusInternalServerError,
+		Message: i18n.T(c, "analytics.compute_failed"),
+		Error:   err.Error(),
+	})
+}
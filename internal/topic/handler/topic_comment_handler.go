@@ -0,0 +1,170 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"topic-service/internal/topic/dto/request"
+	"topic-service/internal/topic/dto/response"
+	"topic-service/internal/topic/service"
+	"topic-service/pkg/i18n"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type TopicCommentHandler struct {
+	service service.TopicCommentService
+}
+
+func NewTopicCommentHandler(service service.TopicCommentService) *TopicCommentHandler {
+	return &TopicCommentHandler{service: service}
+}
+
+// GET /topic/:id/comments
+func (h *TopicCommentHandler) ListComments(c *gin.Context) {
+	topicID := c.Param("id")
+
+	var query request.ListCommentsQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		respondBindError(c, err, "request.invalid_query")
+		return
+	}
+
+	result, err := h.service.ListComments(c.Request.Context(), currentActor(c), topicID, &query)
+	if err != nil {
+		h.handleError(c, err, "comments.list_failed")
+		return
+	}
+
+	c.JSON(http.StatusOK, response.SucceedResponse{
+		Code:    http.StatusOK,
+		Message: i18n.T(c, "comments.retrieved"),
+		Data:    result,
+	})
+}
+
+// POST /topic/:id/comments
+func (h *TopicCommentHandler) CreateComment(c *gin.Context) {
+	topicID := c.Param("id")
+
+	var req request.CreateCommentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err, "request.invalid_body")
+		return
+	}
+
+	// Truyền gin.Context để UserGateway đọc được token khi giải quyết mention
+	result, err := h.service.CreateComment(c, currentActor(c), topicID, &req)
+	if err != nil {
+		h.handleError(c, err, "comment.create_failed")
+		return
+	}
+
+	c.JSON(http.StatusCreated, response.SucceedResponse{
+		Code:    http.StatusCreated,
+		Message: i18n.T(c, "comment.created"),
+		Data:    result,
+	})
+}
+
+// PUT /topic/:id/comments/:comment_id
+func (h *TopicCommentHandler) UpdateComment(c *gin.Context) {
+	topicID := c.Param("id")
+	commentID := c.Param("comment_id")
+
+	var req request.UpdateCommentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err, "request.invalid_body")
+		return
+	}
+
+	result, err := h.service.UpdateComment(c, currentActor(c), topicID, commentID, &req)
+	if err != nil {
+		h.handleError(c, err, "comment.update_failed")
+		return
+	}
+
+	c.JSON(http.StatusOK, response.SucceedResponse{
+		Code:    http.StatusOK,
+		Message: i18n.T(c, "comment.updated"),
+		Data:    result,
+	})
+}
+
+// DELETE /topic/:id/comments/:comment_id
+func (h *TopicCommentHandler) DeleteComment(c *gin.Context) {
+	topicID := c.Param("id")
+	commentID := c.Param("comment_id")
+
+	err := h.service.DeleteComment(c.Request.Context(), currentActor(c), topicID, commentID)
+	if err != nil {
+		h.handleError(c, err, "comment.delete_failed")
+		return
+	}
+
+	c.JSON(http.StatusOK, response.SucceedResponse{
+		Code:    http.StatusOK,
+		Message: i18n.T(c, "comment.deleted"),
+		Data:    nil,
+	})
+}
+
+// PUT /topic/:id/comments/:comment_id/moderation
+func (h *TopicCommentHandler) ModerateComment(c *gin.Context) {
+	topicID := c.Param("id")
+	commentID := c.Param("comment_id")
+
+	var req request.ModerateCommentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err, "request.invalid_body")
+		return
+	}
+
+	result, err := h.service.ModerateComment(c.Request.Context(), currentActor(c), topicID, commentID, &req)
+	if err != nil {
+		h.handleError(c, err, "comment.moderate_failed")
+		return
+	}
+
+	c.JSON(http.StatusOK, response.SucceedResponse{
+		Code:    http.StatusOK,
+		Message: i18n.T(c, "comment.moderated"),
+		Data:    result,
+	})
+}
+
+func (h *TopicCommentHandler) handleError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, mongo.ErrNoDocuments):
+		c.JSON(http.StatusNotFound, response.FailedResponse{
+			Code:    http.StatusNotFound,
+			Message: i18n.T(c, "comment.not_found"),
+			Error:   err.Error(),
+		})
+	case errors.Is(err, service.ErrCommentForbidden):
+		c.JSON(http.StatusForbidden, response.FailedResponse{
+			Code:    http.StatusForbidden,
+			Message: i18n.T(c, message),
+			Error:   err.Error(),
+		})
+	case errors.Is(err, service.ErrCommentParentNotFound):
+		c.JSON(http.StatusBadRequest, response.FailedResponse{
+			Code:    http.StatusBadRequest,
+			Message: i18n.T(c, message),
+			Error:   err.Error(),
+		})
+	case errors.Is(err, service.ErrCommentDeleted):
+		c.JSON(http.StatusConflict, response.FailedResponse{
+			Code:    http.StatusConflict,
+			Message: i18n.T(c, message),
+			Error:   err.Error(),
+		})
+	default:
+		c.JSON(http.StatusInternalServerError, response.FailedResponse{
+			Code:    http.StatusInternalServerError,
+			Message: i18n.T(c, message),
+			Error:   err.Error(),
+		})
+	}
+}
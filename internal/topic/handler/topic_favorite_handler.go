@@ -0,0 +1,109 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"topic-service/internal/topic/dto/response"
+	"topic-service/internal/topic/service"
+	"topic-service/pkg/i18n"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type TopicFavoriteHandler struct {
+	service service.TopicFavoriteService
+}
+
+func NewTopicFavoriteHandler(service service.TopicFavoriteService) *TopicFavoriteHandler {
+	return &TopicFavoriteHandler{service: service}
+}
+
+// POST /topic/:id/favorite
+func (h *TopicFavoriteHandler) AddFavorite(c *gin.Context) {
+	topicID := c.Param("id")
+
+	err := h.service.AddFavorite(c.Request.Context(), currentUserID(c), topicID)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			c.JSON(http.StatusNotFound, response.FailedResponse{
+				Code:    http.StatusNotFound,
+				Message: i18n.T(c, "topic.not_found"),
+				Error:   err.Error(),
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, response.FailedResponse{
+			Code:    http.StatusInternalServerError,
+			Message: i18n.T(c, "favorite.add_failed"),
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, response.SucceedResponse{
+		Code:    http.StatusOK,
+		Message: i18n.T(c, "favorite.added"),
+		Data:    nil,
+	})
+}
+
+// DELETE /topic/:id/favorite
+func (h *TopicFavoriteHandler) RemoveFavorite(c *gin.Context) {
+	topicID := c.Param("id")
+
+	err := h.service.RemoveFavorite(c.Request.Context(), currentUserID(c), topicID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, response.FailedResponse{
+			Code:    http.StatusNotFound,
+			Message: i18n.T(c, "favorite.not_found"),
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, response.SucceedResponse{
+		Code:    http.StatusOK,
+		Message: i18n.T(c, "favorite.removed"),
+		Data:    nil,
+	})
+}
+
+// GET /topic/favorites
+func (h *TopicFavoriteHandler) ListFavorites(c *gin.Context) {
+	topics, err := h.service.ListFavorites(c.Request.Context(), currentUserID(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.FailedResponse{
+			Code:    http.StatusInternalServerError,
+			Message: i18n.T(c, "favorites.list_failed"),
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, response.SucceedResponse{
+		Code:    http.StatusOK,
+		Message: i18n.T(c, "favorites.retrieved"),
+		Data:    topics,
+	})
+}
+
+// GET /topic/recent
+func (h *TopicFavoriteHandler) ListRecentlyViewed(c *gin.Context) {
+	topics, err := h.service.ListRecentlyViewed(c.Request.Context(), currentUserID(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.FailedResponse{
+			Code:    http.StatusInternalServerError,
+			Message: i18n.T(c, "recent_topics.list_failed"),
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, response.SucceedResponse{
+		Code:    http.StatusOK,
+		Message: i18n.T(c, "recent_topics.retrieved"),
+		Data:    topics,
+	})
+}
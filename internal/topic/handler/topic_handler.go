@@ -1,41 +1,45 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
 
 	"topic-service/internal/topic/dto/request"
 	"topic-service/internal/topic/dto/response"
 	"topic-service/internal/topic/model"
 	"topic-service/internal/topic/service"
+	"topic-service/pkg/constants"
+	"topic-service/pkg/i18n"
 
 	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/mongo"
 )
 
 type TopicHandler struct {
-	service service.TopicService
+	service      service.TopicService
+	viewRecorder service.ViewRecorder
 }
 
-func NewTopicHandler(service service.TopicService) *TopicHandler {
-	return &TopicHandler{service: service}
+func NewTopicHandler(service service.TopicService, viewRecorder service.ViewRecorder) *TopicHandler {
+	return &TopicHandler{
+		service:      service,
+		viewRecorder: viewRecorder,
+	}
 }
 
 // POST /topics
 func (h *TopicHandler) CreateTopic(c *gin.Context) {
 	var req request.CreateTopicRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, response.FailedResponse{
-			Code:    http.StatusBadRequest,
-			Message: "Invalid request body",
-			Error:   err.Error(),
-		})
+		respondBindError(c, err, "request.invalid_body")
 		return
 	}
 
-	result, err := h.service.CreateTopic(c, &req)
+	result, err := h.service.CreateTopic(c, currentActor(c), &req)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, response.FailedResponse{
 			Code:    http.StatusInternalServerError,
-			Message: "Failed to create topic",
+			Message: i18n.T(c, "topic.create_failed"),
 			Error:   err.Error(),
 		})
 		return
@@ -43,7 +47,7 @@ func (h *TopicHandler) CreateTopic(c *gin.Context) {
 
 	c.JSON(http.StatusCreated, response.SucceedResponse{
 		Code:    http.StatusCreated,
-		Message: "Topic created successfully",
+		Message: i18n.T(c, "topic.created"),
 		Data:    result,
 	})
 }
@@ -52,19 +56,25 @@ func (h *TopicHandler) CreateTopic(c *gin.Context) {
 func (h *TopicHandler) GetTopicByID(c *gin.Context) {
 	id := c.Param("id")
 
-	topic, err := h.service.GetTopicByID(c.Request.Context(), id)
+	topic, err := h.service.GetTopicByID(c.Request.Context(), currentActor(c), id)
 	if err != nil {
 		c.JSON(http.StatusNotFound, response.FailedResponse{
 			Code:    http.StatusNotFound,
-			Message: "Topic not found",
+			Message: i18n.T(c, "topic.not_found"),
 			Error:   err.Error(),
 		})
 		return
 	}
 
+	h.viewRecorder.Record(model.TopicViewEvent{
+		TopicID:        topic.ID,
+		UserID:         currentUserID(c),
+		OrganizationID: c.GetString(constants.OrganizationID),
+	})
+
 	c.JSON(http.StatusOK, response.SucceedResponse{
 		Code:    http.StatusOK,
-		Message: "Topic retrieved successfully",
+		Message: i18n.T(c, "topic.retrieved"),
 		Data:    topic,
 	})
 }
@@ -72,22 +82,26 @@ func (h *TopicHandler) GetTopicByID(c *gin.Context) {
 // PUT /topics/:id
 func (h *TopicHandler) UpdateTopic(c *gin.Context) {
 	id := c.Param("id")
-	var topic model.Topic
+	var req request.UpdateTopicRequest
 
-	if err := c.ShouldBindJSON(&topic); err != nil {
-		c.JSON(http.StatusBadRequest, response.FailedResponse{
-			Code:    http.StatusBadRequest,
-			Message: "Invalid request body",
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err, "request.invalid_body")
+		return
+	}
+
+	err := h.service.UpdateTopic(c.Request.Context(), currentActor(c), id, &model.Topic{Title: req.Title})
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		c.JSON(http.StatusNotFound, response.FailedResponse{
+			Code:    http.StatusNotFound,
+			Message: i18n.T(c, "topic.not_found"),
 			Error:   err.Error(),
 		})
 		return
 	}
-
-	err := h.service.UpdateTopic(c.Request.Context(), id, &topic)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, response.FailedResponse{
 			Code:    http.StatusInternalServerError,
-			Message: "Failed to update topic",
+			Message: i18n.T(c, "topic.update_failed"),
 			Error:   err.Error(),
 		})
 		return
@@ -95,7 +109,7 @@ func (h *TopicHandler) UpdateTopic(c *gin.Context) {
 
 	c.JSON(http.StatusOK, response.SucceedResponse{
 		Code:    http.StatusOK,
-		Message: "Topic updated successfully",
+		Message: i18n.T(c, "topic.updated"),
 		Data:    nil,
 	})
 }
@@ -104,11 +118,21 @@ func (h *TopicHandler) UpdateTopic(c *gin.Context) {
 func (h *TopicHandler) DeleteTopic(c *gin.Context) {
 	id := c.Param("id")
 
-	err := h.service.DeleteTopic(c.Request.Context(), id)
+	err := h.service.DeleteTopic(c.Request.Context(), currentActor(c), id)
 	if err != nil {
+		var inUse *service.TopicInUseError
+		if errors.As(err, &inUse) {
+			c.JSON(http.StatusConflict, response.FailedResponse{
+				Code:    http.StatusConflict,
+				Message: i18n.T(c, "topic.in_use_by_curricula"),
+				Error:   err.Error(),
+				Data:    inUse.Curricula,
+			})
+			return
+		}
 		c.JSON(http.StatusNotFound, response.FailedResponse{
 			Code:    http.StatusNotFound,
-			Message: "Topic not found",
+			Message: i18n.T(c, "topic.not_found"),
 			Error:   err.Error(),
 		})
 		return
@@ -116,18 +140,139 @@ func (h *TopicHandler) DeleteTopic(c *gin.Context) {
 
 	c.JSON(http.StatusOK, response.SucceedResponse{
 		Code:    http.StatusOK,
-		Message: "Topic deleted successfully",
+		Message: i18n.T(c, "topic.deleted"),
 		Data:    nil,
 	})
 }
 
 // GET /topics
 func (h *TopicHandler) ListTopics(c *gin.Context) {
-	topics, err := h.service.ListTopics(c.Request.Context())
+	var query request.ListTopicsQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		respondBindError(c, err, "request.invalid_query")
+		return
+	}
+
+	topics, err := h.service.ListTopics(c.Request.Context(), currentActor(c), &query)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.FailedResponse{
+			Code:    http.StatusInternalServerError,
+			Message: i18n.T(c, "topics.list_failed"),
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, response.SucceedResponse{
+		Code:    http.StatusOK,
+		Message: i18n.T(c, "topics.retrieved"),
+		Data:    topics,
+	})
+}
+
+// GET /topics/search
+func (h *TopicHandler) SearchTopics(c *gin.Context) {
+	var query request.SearchTopicsQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		respondBindError(c, err, "request.invalid_query")
+		return
+	}
+
+	topics, err := h.service.SearchTopics(c.Request.Context(), currentActor(c), &query)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.FailedResponse{
+			Code:    http.StatusInternalServerError,
+			Message: i18n.T(c, "topics.search_failed"),
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, response.SucceedResponse{
+		Code:    http.StatusOK,
+		Message: i18n.T(c, "topics.retrieved"),
+		Data:    topics,
+	})
+}
+
+// POST /topics/:id/clone
+func (h *TopicHandler) CloneTopic(c *gin.Context) {
+	id := c.Param("id")
+
+	var req request.CloneTopicRequest
+	// Body không bắt buộc
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			respondBindError(c, err, "request.invalid_body")
+			return
+		}
+	}
+
+	result, err := h.service.CloneTopic(c.Request.Context(), currentActor(c), id, &req)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			c.JSON(http.StatusNotFound, response.FailedResponse{
+				Code:    http.StatusNotFound,
+				Message: i18n.T(c, "topic.not_found"),
+				Error:   err.Error(),
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, response.FailedResponse{
+			Code:    http.StatusInternalServerError,
+			Message: i18n.T(c, "topic.clone_failed"),
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, response.SucceedResponse{
+		Code:    http.StatusCreated,
+		Message: i18n.T(c, "topic.cloned"),
+		Data:    result,
+	})
+}
+
+// PUT /topics/:id/template
+func (h *TopicHandler) SetTemplate(c *gin.Context) {
+	id := c.Param("id")
+
+	var req request.SetTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err, "request.invalid_body")
+		return
+	}
+
+	result, err := h.service.SetTemplate(c.Request.Context(), id, *req.IsTemplate)
+	if err != nil {
+		c.JSON(http.StatusNotFound, response.FailedResponse{
+			Code:    http.StatusNotFound,
+			Message: i18n.T(c, "topic.not_found"),
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, response.SucceedResponse{
+		Code:    http.StatusOK,
+		Message: i18n.T(c, "topic.template_flag_updated"),
+		Data:    result,
+	})
+}
+
+// GET /topics/templates
+func (h *TopicHandler) ListTemplates(c *gin.Context) {
+	var query request.ListTopicsQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		respondBindError(c, err, "request.invalid_query")
+		return
+	}
+
+	topics, err := h.service.ListTemplates(c.Request.Context(), &query)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, response.FailedResponse{
 			Code:    http.StatusInternalServerError,
-			Message: "Failed to list topics",
+			Message: i18n.T(c, "topic_templates.list_failed"),
 			Error:   err.Error(),
 		})
 		return
@@ -135,7 +280,7 @@ func (h *TopicHandler) ListTopics(c *gin.Context) {
 
 	c.JSON(http.StatusOK, response.SucceedResponse{
 		Code:    http.StatusOK,
-		Message: "Topics retrieved successfully",
+		Message: i18n.T(c, "topic_templates.retrieved"),
 		Data:    topics,
 	})
 }
@@ -0,0 +1,201 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"topic-service/internal/topic/dto/request"
+	"topic-service/internal/topic/dto/response"
+	"topic-service/internal/topic/service"
+	"topic-service/pkg/i18n"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type TopicItemHandler struct {
+	service service.TopicItemService
+}
+
+func NewTopicItemHandler(service service.TopicItemService) *TopicItemHandler {
+	return &TopicItemHandler{service: service}
+}
+
+// POST /topic/:id/items
+func (h *TopicItemHandler) CreateItem(c *gin.Context) {
+	topicID := c.Param("id")
+
+	var req request.CreateTopicItemRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err, "request.invalid_body")
+		return
+	}
+
+	result, err := h.service.CreateItem(c.Request.Context(), topicID, &req)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			c.JSON(http.StatusNotFound, response.FailedResponse{
+				Code:    http.StatusNotFound,
+				Message: i18n.T(c, "topic.not_found"),
+				Error:   err.Error(),
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, response.FailedResponse{
+			Code:    http.StatusInternalServerError,
+			Message: i18n.T(c, "topic_item.create_failed"),
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, response.SucceedResponse{
+		Code:    http.StatusCreated,
+		Message: i18n.T(c, "topic_item.created"),
+		Data:    result,
+	})
+}
+
+// GET /topic/:id/items
+func (h *TopicItemHandler) ListItems(c *gin.Context) {
+	topicID := c.Param("id")
+
+	items, err := h.service.ListItems(c.Request.Context(), topicID)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			c.JSON(http.StatusNotFound, response.FailedResponse{
+				Code:    http.StatusNotFound,
+				Message: i18n.T(c, "topic.not_found"),
+				Error:   err.Error(),
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, response.FailedResponse{
+			Code:    http.StatusInternalServerError,
+			Message: i18n.T(c, "topic_items.list_failed"),
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, response.SucceedResponse{
+		Code:    http.StatusOK,
+		Message: i18n.T(c, "topic_items.retrieved"),
+		Data:    items,
+	})
+}
+
+// GET /topic/:id/items/:item_id
+func (h *TopicItemHandler) GetItem(c *gin.Context) {
+	topicID := c.Param("id")
+	itemID := c.Param("item_id")
+
+	item, err := h.service.GetItem(c.Request.Context(), topicID, itemID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, response.FailedResponse{
+			Code:    http.StatusNotFound,
+			Message: i18n.T(c, "topic_item.not_found"),
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, response.SucceedResponse{
+		Code:    http.StatusOK,
+		Message: i18n.T(c, "topic_item.retrieved"),
+		Data:    item,
+	})
+}
+
+// PUT /topic/:id/items/:item_id
+func (h *TopicItemHandler) UpdateItem(c *gin.Context) {
+	topicID := c.Param("id")
+	itemID := c.Param("item_id")
+
+	var req request.UpdateTopicItemRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err, "request.invalid_body")
+		return
+	}
+
+	err := h.service.UpdateItem(c.Request.Context(), topicID, itemID, &req)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			c.JSON(http.StatusNotFound, response.FailedResponse{
+				Code:    http.StatusNotFound,
+				Message: i18n.T(c, "topic_item.not_found"),
+				Error:   err.Error(),
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, response.FailedResponse{
+			Code:    http.StatusInternalServerError,
+			Message: i18n.T(c, "topic_item.update_failed"),
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, response.SucceedResponse{
+		Code:    http.StatusOK,
+		Message: i18n.T(c, "topic_item.updated"),
+		Data:    nil,
+	})
+}
+
+// PUT /topic/:id/items/order
+func (h *TopicItemHandler) ReorderItems(c *gin.Context) {
+	topicID := c.Param("id")
+
+	var req request.ReorderTopicItemsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err, "request.invalid_body")
+		return
+	}
+
+	items, err := h.service.ReorderItems(c.Request.Context(), topicID, &req)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidItemOrder) {
+			c.JSON(http.StatusBadRequest, response.FailedResponse{
+				Code:    http.StatusBadRequest,
+				Message: i18n.T(c, "topic_item.invalid_order"),
+				Error:   err.Error(),
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, response.FailedResponse{
+			Code:    http.StatusInternalServerError,
+			Message: i18n.T(c, "topic_items.reorder_failed"),
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, response.SucceedResponse{
+		Code:    http.StatusOK,
+		Message: i18n.T(c, "topic_items.reordered"),
+		Data:    items,
+	})
+}
+
+// DELETE /topic/:id/items/:item_id
+func (h *TopicItemHandler) DeleteItem(c *gin.Context) {
+	topicID := c.Param("id")
+	itemID := c.Param("item_id")
+
+	err := h.service.DeleteItem(c.Request.Context(), topicID, itemID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, response.FailedResponse{
+			Code:    http.StatusNotFound,
+			Message: i18n.T(c, "topic_item.not_found"),
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, response.SucceedResponse{
+		Code:    http.StatusOK,
+		Message: i18n.T(c, "topic_item.deleted"),
+		Data:    nil,
+	})
+}
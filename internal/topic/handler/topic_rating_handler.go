@@ -0,0 +1,118 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"topic-service/internal/topic/dto/request"
+	"topic-service/internal/topic/dto/response"
+	"topic-service/internal/topic/service"
+	"topic-service/pkg/i18n"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type TopicRatingHandler struct {
+	service service.TopicRatingService
+}
+
+func NewTopicRatingHandler(service service.TopicRatingService) *TopicRatingHandler {
+	return &TopicRatingHandler{service: service}
+}
+
+// PUT /topic/:id/rating
+func (h *TopicRatingHandler) RateTopic(c *gin.Context) {
+	topicID := c.Param("id")
+
+	var req request.RateTopicRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err, "request.invalid_body")
+		return
+	}
+
+	result, err := h.service.RateTopic(c.Request.Context(), currentActor(c), topicID, &req)
+	if err != nil {
+		h.handleError(c, err, "rating.save_failed")
+		return
+	}
+
+	c.JSON(http.StatusOK, response.SucceedResponse{
+		Code:    http.StatusOK,
+		Message: i18n.T(c, "rating.saved"),
+		Data:    result,
+	})
+}
+
+// GET /topic/:id/rating
+func (h *TopicRatingHandler) GetMyRating(c *gin.Context) {
+	topicID := c.Param("id")
+
+	result, err := h.service.GetMyRating(c.Request.Context(), currentActor(c), topicID)
+	if err != nil {
+		h.handleError(c, err, "rating.get_failed")
+		return
+	}
+
+	c.JSON(http.StatusOK, response.SucceedResponse{
+		Code:    http.StatusOK,
+		Message: i18n.T(c, "rating.retrieved"),
+		Data:    result,
+	})
+}
+
+// DELETE /topic/:id/rating
+func (h *TopicRatingHandler) DeleteRating(c *gin.Context) {
+	topicID := c.Param("id")
+
+	if err := h.service.DeleteRating(c.Request.Context(), currentActor(c), topicID); err != nil {
+		h.handleError(c, err, "rating.delete_failed")
+		return
+	}
+
+	c.JSON(http.StatusOK, response.SucceedResponse{
+		Code:    http.StatusOK,
+		Message: i18n.T(c, "rating.deleted"),
+		Data:    nil,
+	})
+}
+
+// GET /topic/:id/ratings
+func (h *TopicRatingHandler) ListRatings(c *gin.Context) {
+	topicID := c.Param("id")
+
+	var query request.ListRatingsQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		respondBindError(c, err, "request.invalid_query")
+		return
+	}
+
+	result, err := h.service.ListRatings(c.Request.Context(), topicID, &query)
+	if err != nil {
+		h.handleError(c, err, "ratings.list_failed")
+		return
+	}
+
+	c.JSON(http.StatusOK, response.SucceedResponse{
+		Code:    http.StatusOK,
+		Message: i18n.T(c, "ratings.retrieved"),
+		Data:    result,
+	})
+}
+
+func (h *TopicRatingHandler) handleError(c *gin.Context, err error, message string) {
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		c.JSON(http.StatusNotFound, response.FailedResponse{
+			Code:    http.StatusNotFound,
+			Message: i18n.T(c, "rating.not_found"),
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusInternalServerError, response.FailedResponse{
+		Code:    http.StatusInternalServerError,
+		Message: i18n.T(c, message),
+		Error:   err.Error(),
+	})
+}
@@ -0,0 +1,136 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"topic-service/internal/topic/dto/request"
+	"topic-service/internal/topic/dto/response"
+	"topic-service/internal/topic/mapper"
+	"topic-service/internal/topic/service"
+	"topic-service/pkg/i18n"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	defaultStreamHeartbeat = 15 * time.Second
+	// Thời gian client chờ trước khi tự nối lại, gửi trong trường retry của SSE
+	streamRetryMillis = 3000
+
+	// streamResyncEvent báo client phải tải lại danh sách vì không nối tiếp được từ Last-Event-ID
+	streamResyncEvent = "resync"
+)
+
+type TopicStreamHandler struct {
+	hub       service.TopicChangeHub
+	heartbeat time.Duration
+}
+
+// NewTopicStreamHandler: heartbeat <= 0 thì dùng mặc định 15 giây
+func NewTopicStreamHandler(hub service.TopicChangeHub, heartbeat time.Duration) *TopicStreamHandler {
+	if heartbeat <= 0 {
+		heartbeat = defaultStreamHeartbeat
+	}
+	return &TopicStreamHandler{hub: hub, heartbeat: heartbeat}
+}
+
+// GET /topic/stream
+// Server-Sent Events: id là ID của outbox event để nối lại bằng header Last-Event-ID trên bất kỳ instance nào,
+// event là loại thay đổi (TopicCreated/TopicUpdated/TopicDeleted) và data là TopicChangeResponse
+func (h *TopicStreamHandler) StreamTopics(c *gin.Context) {
+	var query request.StreamTopicsQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		respondBindError(c, err, "request.invalid_query")
+		return
+	}
+
+	filter := service.TopicChangeFilter{Actor: currentActor(c), OrganizationID: query.OrganizationID}
+	for _, id := range strings.Split(query.TopicIDs, ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			filter.TopicIDs = append(filter.TopicIDs, id)
+		}
+	}
+
+	lastEventID := c.GetHeader("Last-Event-ID")
+	if lastEventID == "" {
+		// EventSource không gửi được header tuỳ ý khi mở lần đầu, cho phép truyền qua query
+		lastEventID = c.Query("last_event_id")
+	}
+
+	sub, err := h.hub.Subscribe(c.Request.Context(), filter, lastEventID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.FailedResponse{
+			Code:    http.StatusInternalServerError,
+			Message: i18n.T(c, "topic.stream_failed"),
+			Error:   err.Error(),
+		})
+		return
+	}
+	defer sub.Close()
+
+	header := c.Writer.Header()
+	header.Set("Content-Type", "text/event-stream")
+	header.Set("Cache-Control", "no-cache")
+	header.Set("Connection", "keep-alive")
+	// Tắt buffer của nginx để event tới client ngay
+	header.Set("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+
+	w := c.Writer
+	locale := i18n.Locale(c)
+	fmt.Fprintf(w, "retry: %d\n\n", streamRetryMillis)
+	if sub.ResumeGap {
+		fmt.Fprintf(w, "event: %s\ndata: {}\n\n", streamResyncEvent)
+	}
+	// Event đọc lại từ outbox có thể tới lần nữa qua hub, chỉ gửi một lần
+	resent := make(map[string]bool, len(sub.Missed))
+	for _, change := range sub.Missed {
+		if err := writeTopicChange(w, change, locale); err != nil {
+			return
+		}
+		resent[change.ID] = true
+	}
+	w.Flush()
+
+	heartbeat := time.NewTicker(h.heartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		case change, ok := <-sub.Changes:
+			if !ok {
+				// Hub dừng hoặc client đọc quá chậm, client tự nối lại với Last-Event-ID
+				return
+			}
+			if resent[change.ID] {
+				delete(resent, change.ID)
+				continue
+			}
+			if err := writeTopicChange(w, change, locale); err != nil {
+				return
+			}
+			w.Flush()
+		case <-heartbeat.C:
+			if _, err := io.WriteString(w, ": heartbeat\n\n"); err != nil {
+				return
+			}
+			w.Flush()
+		}
+	}
+}
+
+func writeTopicChange(w io.Writer, change service.TopicChange, locale string) error {
+	data, err := json.Marshal(mapper.MapTopicEventToChangeResponse(&change.Event, locale))
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "id: %s\nevent: %s\ndata: %s\n\n", change.ID, change.Event.Type, data)
+	return err
+}
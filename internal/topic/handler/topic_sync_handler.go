@@ -0,0 +1,50 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"topic-service/internal/topic/dto/request"
+	"topic-service/internal/topic/dto/response"
+	"topic-service/internal/topic/service"
+	"topic-service/pkg/i18n"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TopicSyncHandler struct {
+	service service.TopicSyncService
+}
+
+func NewTopicSyncHandler(service service.TopicSyncService) *TopicSyncHandler {
+	return &TopicSyncHandler{service: service}
+}
+
+// GET /topic/changes?since=<token>
+func (h *TopicSyncHandler) GetChanges(c *gin.Context) {
+	var query request.TopicChangesQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		respondBindError(c, err, "request.invalid_query")
+		return
+	}
+
+	result, err := h.service.GetChanges(c.Request.Context(), currentActor(c), &query)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, service.ErrInvalidSyncToken) {
+			status = http.StatusBadRequest
+		}
+		c.JSON(status, response.FailedResponse{
+			Code:    status,
+			Message: i18n.T(c, "topic_changes.get_failed"),
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, response.SucceedResponse{
+		Code:    http.StatusOK,
+		Message: i18n.T(c, "topic_changes.retrieved"),
+		Data:    result,
+	})
+}
@@ -0,0 +1,110 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"topic-service/internal/topic/dto/request"
+	"topic-service/internal/topic/dto/response"
+	"topic-service/internal/topic/service"
+	"topic-service/pkg/i18n"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type TopicTranslationHandler struct {
+	service service.TopicTranslationService
+}
+
+func NewTopicTranslationHandler(service service.TopicTranslationService) *TopicTranslationHandler {
+	return &TopicTranslationHandler{service: service}
+}
+
+// GET /topic/:id/translations
+func (h *TopicTranslationHandler) GetTranslations(c *gin.Context) {
+	topicID := c.Param("id")
+
+	result, err := h.service.GetTranslations(c.Request.Context(), topicID)
+	if err != nil {
+		h.handleError(c, err, "translation.get_failed")
+		return
+	}
+
+	c.JSON(http.StatusOK, response.SucceedResponse{
+		Code:    http.StatusOK,
+		Message: i18n.T(c, "translation.retrieved"),
+		Data:    result,
+	})
+}
+
+// PUT /topic/:id/translations/:locale
+func (h *TopicTranslationHandler) UpsertTranslation(c *gin.Context) {
+	topicID := c.Param("id")
+	locale := c.Param("locale")
+
+	var req request.UpsertTranslationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err, "request.invalid_body")
+		return
+	}
+
+	result, err := h.service.UpsertTranslation(c.Request.Context(), topicID, locale, &req)
+	if err != nil {
+		h.handleError(c, err, "translation.save_failed")
+		return
+	}
+
+	c.JSON(http.StatusOK, response.SucceedResponse{
+		Code:    http.StatusOK,
+		Message: i18n.T(c, "translation.saved"),
+		Data:    result,
+	})
+}
+
+// DELETE /topic/:id/translations/:locale
+func (h *TopicTranslationHandler) DeleteTranslation(c *gin.Context) {
+	topicID := c.Param("id")
+	locale := c.Param("locale")
+
+	result, err := h.service.DeleteTranslation(c.Request.Context(), topicID, locale)
+	if err != nil {
+		h.handleError(c, err, "translation.delete_failed")
+		return
+	}
+
+	c.JSON(http.StatusOK, response.SucceedResponse{
+		Code:    http.StatusOK,
+		Message: i18n.T(c, "translation.deleted"),
+		Data:    result,
+	})
+}
+
+func (h *TopicTranslationHandler) handleError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, mongo.ErrNoDocuments):
+		c.JSON(http.StatusNotFound, response.FailedResponse{
+			Code:    http.StatusNotFound,
+			Message: i18n.T(c, "topic.not_found"),
+			Error:   err.Error(),
+		})
+	case errors.Is(err, service.ErrUnsupportedLocale):
+		c.JSON(http.StatusBadRequest, response.FailedResponse{
+			Code:    http.StatusBadRequest,
+			Message: i18n.T(c, message),
+			Error:   err.Error(),
+		})
+	case errors.Is(err, service.ErrDeleteDefaultTranslation):
+		c.JSON(http.StatusConflict, response.FailedResponse{
+			Code:    http.StatusConflict,
+			Message: i18n.T(c, message),
+			Error:   err.Error(),
+		})
+	default:
+		c.JSON(http.StatusInternalServerError, response.FailedResponse{
+			Code:    http.StatusInternalServerError,
+			Message: i18n.T(c, message),
+			Error:   err.Error(),
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"topic-service/internal/topic/dto/request"
+	"topic-service/internal/topic/dto/response"
+	"topic-service/internal/topic/model"
+	"topic-service/internal/topic/service"
+	"topic-service/pkg/i18n"
+
+	"github.com/gin-gonic/gin"
+)
+
+type UserEventHandler struct {
+	service service.UserLifecycleService
+}
+
+func NewUserEventHandler(service service.UserLifecycleService) *UserEventHandler {
+	return &UserEventHandler{service: service}
+}
+
+// POST /webhooks/user-events
+func (h *UserEventHandler) HandleUserEvent(c *gin.Context) {
+	var req request.UserEventRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err, "request.invalid_body")
+		return
+	}
+
+	result, err := h.service.HandleUserEvent(c.Request.Context(), &model.UserLifecycleEvent{
+		EventID:        req.EventID,
+		Type:           req.Type,
+		UserID:         req.UserID,
+		OrganizationID: req.OrganizationID,
+		OccurredAt:     req.OccurredAt,
+	})
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, service.ErrInvalidUserEvent) {
+			status = http.StatusBadRequest
+		}
+		c.JSON(status, response.FailedResponse{
+			Code:    status,
+			Message: i18n.T(c, "user_event.handle_failed"),
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, response.SucceedResponse{
+		Code:    http.StatusOK,
+		Message: i18n.T(c, "user_event.handled"),
+		Data:    result,
+	})
+}
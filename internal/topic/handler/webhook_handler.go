@@ -0,0 +1,179 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"topic-service/internal/topic/dto/request"
+	"topic-service/internal/topic/dto/response"
+	"topic-service/internal/topic/service"
+	"topic-service/pkg/i18n"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type WebhookHandler struct {
+	service service.WebhookService
+}
+
+func NewWebhookHandler(service service.WebhookService) *WebhookHandler {
+	return &WebhookHandler{service: service}
+}
+
+// POST /webhooks/subscriptions
+func (h *WebhookHandler) CreateSubscription(c *gin.Context) {
+	var req request.CreateWebhookSubscriptionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err, "request.invalid_body")
+		return
+	}
+
+	result, err := h.service.CreateSubscription(c.Request.Context(), currentActor(c), &req)
+	if err != nil {
+		h.handleError(c, err, "webhook.create_failed")
+		return
+	}
+
+	c.JSON(http.StatusCreated, response.SucceedResponse{
+		Code:    http.StatusCreated,
+		Message: i18n.T(c, "webhook.created"),
+		Data:    result,
+	})
+}
+
+// GET /webhooks/subscriptions
+func (h *WebhookHandler) ListSubscriptions(c *gin.Context) {
+	result, err := h.service.ListSubscriptions(c.Request.Context(), currentActor(c))
+	if err != nil {
+		h.handleError(c, err, "webhooks.list_failed")
+		return
+	}
+
+	c.JSON(http.StatusOK, response.SucceedResponse{
+		Code:    http.StatusOK,
+		Message: i18n.T(c, "webhooks.retrieved"),
+		Data:    result,
+	})
+}
+
+// GET /webhooks/subscriptions/:id
+func (h *WebhookHandler) GetSubscription(c *gin.Context) {
+	result, err := h.service.GetSubscription(c.Request.Context(), currentActor(c), c.Param("id"))
+	if err != nil {
+		h.handleError(c, err, "webhook.get_failed")
+		return
+	}
+
+	c.JSON(http.StatusOK, response.SucceedResponse{
+		Code:    http.StatusOK,
+		Message: i18n.T(c, "webhook.retrieved"),
+		Data:    result,
+	})
+}
+
+// PUT /webhooks/subscriptions/:id
+func (h *WebhookHandler) UpdateSubscription(c *gin.Context) {
+	var req request.UpdateWebhookSubscriptionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err, "request.invalid_body")
+		return
+	}
+
+	result, err := h.service.UpdateSubscription(c.Request.Context(), currentActor(c), c.Param("id"), &req)
+	if err != nil {
+		h.handleError(c, err, "webhook.update_failed")
+		return
+	}
+
+	c.JSON(http.StatusOK, response.SucceedResponse{
+		Code:    http.StatusOK,
+		Message: i18n.T(c, "webhook.updated"),
+		Data:    result,
+	})
+}
+
+// DELETE /webhooks/subscriptions/:id
+func (h *WebhookHandler) DeleteSubscription(c *gin.Context) {
+	if err := h.service.DeleteSubscription(c.Request.Context(), currentActor(c), c.Param("id")); err != nil {
+		h.handleError(c, err, "webhook.delete_failed")
+		return
+	}
+
+	c.JSON(http.StatusOK, response.SucceedResponse{
+		Code:    http.StatusOK,
+		Message: i18n.T(c, "webhook.deleted"),
+		Data:    nil,
+	})
+}
+
+// GET /webhooks/subscriptions/:id/deliveries
+func (h *WebhookHandler) ListDeliveries(c *gin.Context) {
+	var query request.ListWebhookDeliveriesQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		respondBindError(c, err, "request.invalid_query")
+		return
+	}
+
+	result, err := h.service.ListDeliveries(c.Request.Context(), currentActor(c), c.Param("id"), &query)
+	if err != nil {
+		h.handleError(c, err, "webhook_deliveries.list_failed")
+		return
+	}
+
+	c.JSON(http.StatusOK, response.SucceedResponse{
+		Code:    http.StatusOK,
+		Message: i18n.T(c, "webhook_deliveries.retrieved"),
+		Data:    result,
+	})
+}
+
+// POST /webhooks/subscriptions/:id/deliveries/:delivery_id/replay
+func (h *WebhookHandler) ReplayDelivery(c *gin.Context) {
+	result, err := h.service.ReplayDelivery(c.Request.Context(), currentActor(c), c.Param("id"), c.Param("delivery_id"))
+	if err != nil {
+		h.handleError(c, err, "webhook_delivery.replay_failed")
+		return
+	}
+
+	c.JSON(http.StatusAccepted, response.SucceedResponse{
+		Code:    http.StatusAccepted,
+		Message: i18n.T(c, "webhook_delivery.replayed"),
+		Data:    result,
+	})
+}
+
+func (h *WebhookHandler) handleError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, mongo.ErrNoDocuments):
+		c.JSON(http.StatusNotFound, response.FailedResponse{
+			Code:    http.StatusNotFound,
+			Message: i18n.T(c, "webhook.not_found"),
+			Error:   err.Error(),
+		})
+	case errors.Is(err, service.ErrWebhookURLNotAllowed):
+		c.JSON(http.StatusBadRequest, response.FailedResponse{
+			Code:    http.StatusBadRequest,
+			Message: i18n.T(c, "webhook.url_not_allowed"),
+			Error:   err.Error(),
+		})
+	case errors.Is(err, service.ErrWebhookOrganizationRequired):
+		c.JSON(http.StatusBadRequest, response.FailedResponse{
+			Code:    http.StatusBadRequest,
+			Message: i18n.T(c, message),
+			Error:   err.Error(),
+		})
+	case errors.Is(err, service.ErrWebhookSubscriptionInactive):
+		c.JSON(http.StatusConflict, response.FailedResponse{
+			Code:    http.StatusConflict,
+			Message: i18n.T(c, message),
+			Error:   err.Error(),
+		})
+	default:
+		c.JSON(http.StatusInternalServerError, response.FailedResponse{
+			Code:    http.StatusInternalServerError,
+			Message: i18n.T(c, message),
+			Error:   err.Error(),
+		})
+	}
+}
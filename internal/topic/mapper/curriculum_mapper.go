@@ -0,0 +1,62 @@
+package mapper
+
+import (
+	"topic-service/internal/topic/dto/response"
+	"topic-service/internal/topic/model"
+)
+
+// Mapper: Curriculum model -> CurriculumResponse
+// titles (có thể nil) dùng để gắn tiêu đề topic vào từng entry
+func MapCurriculumToResponse(c *model.Curriculum, titles map[string]string) *response.CurriculumResponse {
+	if c == nil {
+		return nil
+	}
+
+	entries := make([]response.CurriculumEntryResponse, 0, len(c.Entries))
+	for _, e := range c.Entries {
+		entries = append(entries, response.CurriculumEntryResponse{
+			TopicID:    e.TopicID,
+			TopicTitle: titles[e.TopicID],
+			Order:      e.Order,
+			Week:       e.Week,
+			Term:       e.Term,
+		})
+	}
+
+	return &response.CurriculumResponse{
+		ID:                 c.ID.Hex(),
+		Title:              c.Title,
+		Description:        c.Description,
+		Entries:            entries,
+		Status:             c.Status,
+		SourceCurriculumID: c.SourceCurriculumID,
+		CreatedBy:          c.CreatedBy,
+		OrganizationID:     c.OrganizationID,
+		PublishedAt:        c.PublishedAt,
+		CreatedAt:          c.CreatedAt,
+		UpdatedAt:          c.UpdatedAt,
+	}
+}
+
+func MapCurriculaToResponses(curricula []*model.Curriculum) []response.CurriculumResponse {
+	responses := make([]response.CurriculumResponse, 0, len(curricula))
+	for _, c := range curricula {
+		res := MapCurriculumToResponse(c, nil)
+		if res != nil {
+			responses = append(responses, *res)
+		}
+	}
+	return responses
+}
+
+func MapCurriculaToRefs(curricula []*model.Curriculum) []response.CurriculumRefResponse {
+	refs := make([]response.CurriculumRefResponse, 0, len(curricula))
+	for _, c := range curricula {
+		refs = append(refs, response.CurriculumRefResponse{
+			ID:     c.ID.Hex(),
+			Title:  c.Title,
+			Status: c.Status,
+		})
+	}
+	return refs
+}
@@ -0,0 +1,63 @@
+package mapper
+
+import (
+	"topic-service/internal/topic/dto/response"
+	"topic-service/internal/topic/model"
+)
+
+// Mapper: TopicComment model -> CommentResponse (không gồm replies)
+func MapCommentToResponse(c *model.TopicComment) *response.CommentResponse {
+	if c == nil {
+		return nil
+	}
+
+	mentions := make([]response.CommentMentionResponse, 0, len(c.Mentions))
+	for _, m := range c.Mentions {
+		mentions = append(mentions, response.CommentMentionResponse{
+			UserID: m.UserID,
+			Name:   m.Name,
+		})
+	}
+
+	content := c.Content
+	if c.Deleted {
+		content = ""
+	}
+
+	return &response.CommentResponse{
+		ID:        c.ID.Hex(),
+		TopicID:   c.TopicID,
+		ParentID:  c.ParentID,
+		AuthorID:  c.AuthorID,
+		Content:   content,
+		Mentions:  mentions,
+		Hidden:    c.Hidden,
+		Deleted:   c.Deleted,
+		EditedAt:  c.EditedAt,
+		CreatedAt: c.CreatedAt,
+		UpdatedAt: c.UpdatedAt,
+	}
+}
+
+// MapCommentThreads dựng cây reply cho các bình luận gốc theo đúng thứ tự của roots
+func MapCommentThreads(roots []*model.TopicComment, replies []*model.TopicComment) []response.CommentResponse {
+	children := make(map[string][]*model.TopicComment)
+	for _, r := range replies {
+		children[r.ParentID] = append(children[r.ParentID], r)
+	}
+
+	var build func(c *model.TopicComment) response.CommentResponse
+	build = func(c *model.TopicComment) response.CommentResponse {
+		res := MapCommentToResponse(c)
+		for _, child := range children[c.ID.Hex()] {
+			res.Replies = append(res.Replies, build(child))
+		}
+		return *res
+	}
+
+	threads := make([]response.CommentResponse, 0, len(roots))
+	for _, root := range roots {
+		threads = append(threads, build(root))
+	}
+	return threads
+}
@@ -0,0 +1,42 @@
+package mapper
+
+import (
+	"topic-service/internal/topic/dto/response"
+	"topic-service/internal/topic/model"
+)
+
+// Mapper: TopicItem model -> TopicItemResponse
+func MapTopicItemToResponse(i *model.TopicItem) *response.TopicItemResponse {
+	if i == nil {
+		return nil
+	}
+
+	images := i.Images
+	if images == nil {
+		images = []string{}
+	}
+
+	return &response.TopicItemResponse{
+		ID:         i.ID.Hex(),
+		TopicID:    i.TopicID.Hex(),
+		Word:       i.Word,
+		Images:     images,
+		CoverImage: i.CoverImage,
+		AudioUrl:   i.AudioUrl,
+		VideoUrl:   i.VideoUrl,
+		Order:      i.Order,
+		CreatedAt:  i.CreatedAt,
+		UpdatedAt:  i.UpdatedAt,
+	}
+}
+
+func MapTopicItemsToResponses(items []*model.TopicItem) []response.TopicItemResponse {
+	responses := make([]response.TopicItemResponse, 0, len(items))
+	for _, i := range items {
+		res := MapTopicItemToResponse(i)
+		if res != nil {
+			responses = append(responses, *res)
+		}
+	}
+	return responses
+}
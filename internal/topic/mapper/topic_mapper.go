@@ -5,28 +5,76 @@ import (
 	"topic-service/internal/topic/model"
 )
 
-// Mapper: Topic model -> TopicResponse
-func MapTopicToResponse(t *model.Topic) *response.TopicResponse {
+// Mapper: Topic model -> TopicResponse, tiêu đề và mô tả theo locale của request
+func MapTopicToResponse(t *model.Topic, locale string) *response.TopicResponse {
 	if t == nil {
 		return nil
 	}
 
+	prerequisiteIDs := t.PrerequisiteIDs
+	if prerequisiteIDs == nil {
+		prerequisiteIDs = []string{}
+	}
+
+	title, description, resolved := t.Localize(locale)
+
 	return &response.TopicResponse{
-		ID:        t.ID.Hex(),
-		Title:     t.Title,
-		Icon:      t.Icon,
-		CreatedAt: t.CreatedAt,
-		UpdatedAt: t.UpdatedAt,
+		ID:              t.ID.Hex(),
+		Title:           title,
+		Description:     description,
+		Locale:          resolved,
+		DefaultLocale:   t.DefaultLocale,
+		Titles:          t.Titles,
+		Descriptions:    t.Descriptions,
+		Icon:            t.Icon,
+		ItemCount:       t.ItemCount,
+		CommentCount:    t.CommentCount,
+		RatingCount:     t.RatingCount,
+		RatingAverage:   t.RatingAverage,
+		PrerequisiteIDs: prerequisiteIDs,
+		CreatedBy:       t.CreatedBy,
+		OrganizationID:  t.OrganizationID,
+		IsTemplate:      t.IsTemplate,
+		SourceTopicID:   t.SourceTopicID,
+		ClonedAt:        t.ClonedAt,
+		CreatedAt:       t.CreatedAt,
+		UpdatedAt:       t.UpdatedAt,
 	}
 }
 
-func MapTopicsToResponses(topics []*model.Topic) []response.TopicResponse {
+func MapTopicsToResponses(topics []*model.Topic, locale string) []response.TopicResponse {
 	var responses []response.TopicResponse
 	for _, t := range topics {
-		res := MapTopicToResponse(t)
+		res := MapTopicToResponse(t, locale)
 		if res != nil {
 			responses = append(responses, *res)
 		}
 	}
 	return responses
 }
+
+func MapTopicAuditLogsToResponses(logs []*model.TopicAuditLog) []response.TopicAuditLogResponse {
+	responses := make([]response.TopicAuditLogResponse, 0, len(logs))
+	for _, l := range logs {
+		responses = append(responses, response.TopicAuditLogResponse{
+			ID:        l.ID.Hex(),
+			Action:    l.Action,
+			TopicID:   l.TopicID,
+			ActorID:   l.ActorID,
+			Details:   l.Details,
+			CreatedAt: l.CreatedAt,
+		})
+	}
+	return responses
+}
+
+// Mapper: TopicEvent -> TopicChangeResponse
+func MapTopicEventToChangeResponse(e *model.TopicEvent, locale string) *response.TopicChangeResponse {
+	return &response.TopicChangeResponse{
+		Type:           e.Type,
+		TopicID:        e.TopicID,
+		OrganizationID: e.OrganizationID,
+		OccurredAt:     e.OccurredAt,
+		Topic:          MapTopicToResponse(e.Topic, locale),
+	}
+}
@@ -0,0 +1,51 @@
+package mapper
+
+import (
+	"topic-service/internal/topic/dto/response"
+	topicv1 "topic-service/pkg/pb/topic/v1"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Mapper: TopicResponse -> topicv1.Topic cho API gRPC
+func MapTopicResponseToProto(t *response.TopicResponse) *topicv1.Topic {
+	if t == nil {
+		return nil
+	}
+
+	res := &topicv1.Topic{
+		Id:              t.ID,
+		Title:           t.Title,
+		Description:     t.Description,
+		Locale:          t.Locale,
+		DefaultLocale:   t.DefaultLocale,
+		Titles:          t.Titles,
+		Descriptions:    t.Descriptions,
+		Icon:            t.Icon,
+		ItemCount:       int32(t.ItemCount),
+		CommentCount:    int32(t.CommentCount),
+		RatingCount:     int32(t.RatingCount),
+		RatingAverage:   t.RatingAverage,
+		PrerequisiteIds: t.PrerequisiteIDs,
+		CreatedBy:       t.CreatedBy,
+		OrganizationId:  t.OrganizationID,
+		IsTemplate:      t.IsTemplate,
+		IsFavorite:      t.IsFavorite,
+		SourceTopicId:   t.SourceTopicID,
+		RedirectedFrom:  t.RedirectedFrom,
+		CreatedAt:       timestamppb.New(t.CreatedAt),
+		UpdatedAt:       timestamppb.New(t.UpdatedAt),
+	}
+	if t.ClonedAt != nil {
+		res.ClonedAt = timestamppb.New(*t.ClonedAt)
+	}
+	return res
+}
+
+func MapTopicResponsesToProto(topics []response.TopicResponse) []*topicv1.Topic {
+	result := make([]*topicv1.Topic, 0, len(topics))
+	for i := range topics {
+		result = append(result, MapTopicResponseToProto(&topics[i]))
+	}
+	return result
+}
@@ -0,0 +1,30 @@
+package mapper
+
+import (
+	"topic-service/internal/topic/dto/response"
+	"topic-service/internal/topic/model"
+)
+
+func MapRatingToResponse(r *model.TopicRating) *response.RatingResponse {
+	if r == nil {
+		return nil
+	}
+
+	return &response.RatingResponse{
+		ID:        r.ID.Hex(),
+		TopicID:   r.TopicID,
+		UserID:    r.UserID,
+		Score:     r.Score,
+		Feedback:  r.Feedback,
+		CreatedAt: r.CreatedAt,
+		UpdatedAt: r.UpdatedAt,
+	}
+}
+
+func MapRatingsToResponses(ratings []*model.TopicRating) []response.RatingResponse {
+	responses := make([]response.RatingResponse, 0, len(ratings))
+	for _, r := range ratings {
+		responses = append(responses, *MapRatingToResponse(r))
+	}
+	return responses
+}
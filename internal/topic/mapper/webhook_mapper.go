@@ -0,0 +1,74 @@
+package mapper
+
+import (
+	"topic-service/internal/topic/dto/response"
+	"topic-service/internal/topic/model"
+)
+
+// Mapper: WebhookSubscription model -> WebhookSubscriptionResponse, không kèm secret
+func MapWebhookSubscriptionToResponse(s *model.WebhookSubscription) *response.WebhookSubscriptionResponse {
+	if s == nil {
+		return nil
+	}
+
+	events := s.Events
+	if events == nil {
+		events = []string{}
+	}
+
+	return &response.WebhookSubscriptionResponse{
+		ID:                  s.ID.Hex(),
+		OrganizationID:      s.OrganizationID,
+		URL:                 s.URL,
+		Events:              events,
+		Active:              s.Active,
+		ConsecutiveFailures: s.ConsecutiveFailures,
+		DisabledAt:          s.DisabledAt,
+		DisabledReason:      s.DisabledReason,
+		CreatedBy:           s.CreatedBy,
+		CreatedAt:           s.CreatedAt,
+		UpdatedAt:           s.UpdatedAt,
+	}
+}
+
+func MapWebhookSubscriptionsToResponse(subscriptions []*model.WebhookSubscription) []response.WebhookSubscriptionResponse {
+	result := make([]response.WebhookSubscriptionResponse, 0, len(subscriptions))
+	for _, s := range subscriptions {
+		result = append(result, *MapWebhookSubscriptionToResponse(s))
+	}
+	return result
+}
+
+// Mapper: WebhookDelivery model -> WebhookDeliveryResponse, next_attempt_at chỉ có khi delivery còn chờ gửi
+func MapWebhookDeliveryToResponse(d *model.WebhookDelivery) *response.WebhookDeliveryResponse {
+	if d == nil {
+		return nil
+	}
+
+	res := &response.WebhookDeliveryResponse{
+		ID:             d.ID.Hex(),
+		SubscriptionID: d.SubscriptionID,
+		EventID:        d.EventID,
+		EventType:      d.EventType,
+		ReplayOf:       d.ReplayOf,
+		Status:         d.Status,
+		Attempts:       d.Attempts,
+		LastStatusCode: d.LastStatusCode,
+		LastError:      d.LastError,
+		CreatedAt:      d.CreatedAt,
+		DeliveredAt:    d.DeliveredAt,
+	}
+	if d.Status == model.WebhookDeliveryPending {
+		next := d.NextAttemptAt
+		res.NextAttemptAt = &next
+	}
+	return res
+}
+
+func MapWebhookDeliveriesToResponse(deliveries []*model.WebhookDelivery) []response.WebhookDeliveryResponse {
+	result := make([]response.WebhookDeliveryResponse, 0, len(deliveries))
+	for _, d := range deliveries {
+		result = append(result, *MapWebhookDeliveryToResponse(d))
+	}
+	return result
+}
@@ -0,0 +1,97 @@
+package middleware
+
+import (
+	"context"
+	"log"
+	"runtime/debug"
+	"strings"
+	"time"
+	"topic-service/pkg/i18n"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// claimsContextKey giữ Claims của request gRPC trong context
+type claimsContextKey struct{}
+
+// ClaimsFromContext trả về claims đã được UnaryAuthInterceptor gắn vào context
+func ClaimsFromContext(ctx context.Context) (Claims, bool) {
+	claims, ok := ctx.Value(claimsContextKey{}).(Claims)
+	return claims, ok
+}
+
+// UnaryAuthInterceptor kiểm tra chữ ký của token trong metadata "authorization" bằng verifier rồi gắn claims
+// và locale vào context. verifier nil thì mọi request cần token bị từ chối với Unavailable.
+// Method có tiền tố trong publicPrefixes (ví dụ health check) không cần token.
+func UnaryAuthInterceptor(verifier *TokenVerifier, publicPrefixes ...string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+		ctx = i18n.WithLocale(ctx, i18n.Negotiate(firstMetadata(md, "accept-language")))
+
+		for _, prefix := range publicPrefixes {
+			if strings.HasPrefix(info.FullMethod, prefix) {
+				return handler(ctx, req)
+			}
+		}
+
+		if verifier == nil {
+			return nil, status.Error(codes.Unavailable, i18n.T(ctx, "auth.token_verification_not_configured"))
+		}
+		tokenString, err := BearerToken(firstMetadata(md, "authorization"))
+		if err != nil {
+			return nil, status.Error(codes.Unauthenticated, i18n.T(ctx, "auth.token_required"))
+		}
+		claims, err := verifier.ParseClaims(tokenString)
+		if err != nil {
+			return nil, status.Error(codes.Unauthenticated, i18n.T(ctx, "auth.invalid_token"))
+		}
+
+		// Ngôn ngữ user đã chọn được ưu tiên hơn accept-language
+		if claims.Locale != "" {
+			ctx = i18n.WithLocale(ctx, claims.Locale)
+		}
+		return handler(context.WithValue(ctx, claimsContextKey{}, claims), req)
+	}
+}
+
+// GrpcAccessLogger là phần của zap.Logger dùng để ghi access log gRPC
+type GrpcAccessLogger interface {
+	GrpcMiddlewareAccessLogger(method string, time time.Duration, metaData map[string][]string, err error)
+}
+
+// UnaryAccessLogInterceptor ghi method, thời gian xử lý, metadata (trừ token) và lỗi của mỗi request
+func UnaryAccessLogInterceptor(logger GrpcAccessLogger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+
+		md, _ := metadata.FromIncomingContext(ctx)
+		md = md.Copy()
+		md.Delete("authorization")
+		logger.GrpcMiddlewareAccessLogger(info.FullMethod, time.Since(start), md, err)
+		return resp, err
+	}
+}
+
+// UnaryRecoveryInterceptor chuyển panic trong handler thành lỗi Internal để server không bị dừng
+func UnaryRecoveryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				log.Printf("Recovered from panic in %s: %v\n%s", info.FullMethod, r, debug.Stack())
+				resp, err = nil, status.Error(codes.Internal, i18n.T(ctx, "request.internal_error"))
+			}
+		}()
+		return handler(ctx, req)
+	}
+}
+
+func firstMetadata(md metadata.MD, key string) string {
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
@@ -5,45 +5,103 @@ import (
 	// "strings"
 	// "term-info-service/pkg/constants"
 
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
 	"net/http"
+	"strconv"
 	"strings"
+	"time"
 	"topic-service/pkg/constants"
+	"topic-service/pkg/i18n"
 
 	"github.com/gin-gonic/gin"
 	//"github.com/golang-jwt/jwt"
 	"github.com/golang-jwt/jwt/v5"
 )
 
+// Claims là thông tin user đọc từ JWT, dùng chung cho API HTTP và gRPC
+type Claims struct {
+	UserID         string
+	UserName       string
+	UserRoles      string
+	OrganizationID string
+	Locale         string
+}
+
+var (
+	errMissingToken   = errors.New("authorization token is missing")
+	errMalformedToken = errors.New("authorization header must use the Bearer scheme")
+)
+
+// BearerToken tách token từ giá trị header Authorization dạng "Bearer <token>"
+func BearerToken(authorization string) (string, error) {
+	if len(authorization) == 0 {
+		return "", errMissingToken
+	}
+	if !strings.HasPrefix(authorization, "Bearer ") {
+		return "", errMalformedToken
+	}
+	return strings.Split(authorization, " ")[1], nil
+}
+
+// ParseClaims đọc claims từ token mà không kiểm tra chữ ký, lỗi khi token sai định dạng.
+// API HTTP chỉ nhận request đã qua gateway, API gRPC dùng TokenVerifier.
+func ParseClaims(tokenString string) (Claims, error) {
+	token, _, err := new(jwt.Parser).ParseUnverified(tokenString, jwt.MapClaims{})
+	if err != nil {
+		return Claims{}, err
+	}
+	return claimsOf(token), nil
+}
+
+func claimsOf(token *jwt.Token) Claims {
+	var result Claims
+	if claims, ok := token.Claims.(jwt.MapClaims); ok {
+		result.UserID, _ = claims[constants.UserID].(string)
+		result.UserName, _ = claims[constants.UserName].(string)
+		result.UserRoles, _ = claims[constants.UserRoles].(string)
+		result.OrganizationID, _ = claims[constants.OrganizationID].(string)
+		result.Locale, _ = claims[constants.Locale].(string)
+	}
+	return result
+}
+
 func Secured() gin.HandlerFunc {
 	return func(context *gin.Context) {
-		authorizationHeader := context.GetHeader("Authorization")
-
-		if len(authorizationHeader) == 0 {
+		tokenString, err := BearerToken(context.GetHeader("Authorization"))
+		if errors.Is(err, errMissingToken) {
 			context.AbortWithStatus(http.StatusForbidden)
 			return
 		}
-
-		if !strings.HasPrefix(authorizationHeader, "Bearer ") {
+		if err != nil {
 			context.AbortWithStatus(http.StatusUnauthorized)
 			return
 		}
 
-		tokenString := strings.Split(authorizationHeader, " ")[1]
-
-		token, _, _ := new(jwt.Parser).ParseUnverified(tokenString, jwt.MapClaims{})
-
-		if claims, ok := token.Claims.(jwt.MapClaims); ok {
-			if userId, ok := claims[constants.UserID].(string); ok {
-				context.Set(constants.UserID, userId)
-			}
-
-			if userName, ok := claims[constants.UserName].(string); ok {
-				context.Set(constants.UserName, userName)
-			}
-
-			if userRoles, ok := claims[constants.UserRoles].(string); ok {
-				context.Set(constants.UserRoles, userRoles)
-			}
+		claims, err := ParseClaims(tokenString)
+		if err != nil {
+			context.AbortWithStatus(http.StatusUnauthorized)
+			return
+		}
+		if claims.UserID != "" {
+			context.Set(constants.UserID, claims.UserID)
+		}
+		if claims.UserName != "" {
+			context.Set(constants.UserName, claims.UserName)
+		}
+		if claims.UserRoles != "" {
+			context.Set(constants.UserRoles, claims.UserRoles)
+		}
+		if claims.OrganizationID != "" {
+			context.Set(constants.OrganizationID, claims.OrganizationID)
+		}
+		// Ngôn ngữ user đã chọn được ưu tiên hơn Accept-Language
+		if claims.Locale != "" {
+			i18n.SetPreferredLocale(context, claims.Locale)
 		}
 
 		context.Set(constants.Token, tokenString)
@@ -55,31 +113,91 @@ func RequireAdmin() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		rolesAny, exists := c.Get(constants.UserRoles)
 		if !exists {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Roles not found"})
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": i18n.T(c, "auth.roles_not_found")})
 			return
 		}
 
-		rolesStr, ok := rolesAny.(string)
-		if !ok {
-			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "Invalid roles format"})
+		if _, ok := rolesAny.(string); !ok {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": i18n.T(c, "auth.invalid_roles_format")})
 			return
 		}
 
-		// Chuyển chuỗi "Admin, Teacher" thành slice
-		roles := strings.Split(rolesStr, ",")
-		isAdmin := false
-		for _, role := range roles {
-			if strings.TrimSpace(role) == "Admin" {
-				isAdmin = true
-				break
-			}
+		if !IsAdmin(c) {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": i18n.T(c, "auth.admin_required")})
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// IsAdmin kiểm tra user hiện tại có role Admin hay không
+func IsAdmin(c *gin.Context) bool {
+	return HasAdminRole(c.GetString(constants.UserRoles))
+}
+
+// HasAdminRole kiểm tra chuỗi role trong token có chứa Admin hay không
+func HasAdminRole(rolesStr string) bool {
+	// Chuyển chuỗi "Admin, Teacher" thành slice
+	roles := strings.Split(rolesStr, ",")
+	for _, role := range roles {
+		if strings.TrimSpace(role) == "Admin" {
+			return true
 		}
+	}
+	return false
+}
+
+// Header của webhook nhận từ go-main-service. Bên gửi ký HMAC-SHA256 của "<timestamp>.<body>"
+// với timestamp là unix giây, gửi kèm để request bị bắt được không thể gửi lại sau SignatureTolerance.
+const (
+	SignatureHeader          = "X-Signature"
+	SignatureTimestampHeader = "X-Signature-Timestamp"
+)
+
+// SignatureTolerance là độ lệch tối đa giữa timestamp đã ký và giờ của server
+const SignatureTolerance = 5 * time.Minute
 
-		if !isAdmin {
-			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "Admin access required"})
+// SignPayload trả về giá trị header X-Signature dạng "sha256=<hex>"
+func SignPayload(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifySignature kiểm tra body và timestamp của webhook được ký bằng secret dùng chung với bên gửi.
+// secret rỗng thì mọi request bị từ chối, router không đăng ký route trong trường hợp này.
+func VerifySignature(secret string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if secret == "" {
+			c.AbortWithStatusJSON(http.StatusServiceUnavailable, gin.H{"error": i18n.T(c, "auth.signature_not_configured")})
 			return
 		}
 
+		timestamp, err := strconv.ParseInt(c.GetHeader(SignatureTimestampHeader), 10, 64)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": i18n.T(c, "auth.invalid_signature")})
+			return
+		}
+		if age := time.Since(time.Unix(timestamp, 0)); age > SignatureTolerance || age < -SignatureTolerance {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": i18n.T(c, "auth.stale_signature")})
+			return
+		}
+
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			c.AbortWithStatus(http.StatusBadRequest)
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		expected := SignPayload(secret, timestamp, body)
+		if !hmac.Equal([]byte(c.GetHeader(SignatureHeader)), []byte(expected)) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": i18n.T(c, "auth.invalid_signature")})
+			return
+		}
 		c.Next()
 	}
 }
@@ -0,0 +1,65 @@
+package middleware
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"errors"
+	"fmt"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// TokenVerifier kiểm tra chữ ký và thời hạn của JWT trước khi đọc claims
+type TokenVerifier struct {
+	hmacSecret []byte
+	publicKey  crypto.PublicKey
+}
+
+// NewTokenVerifier: hmacSecret kiểm tra token HS256/HS384/HS512, publicKeyPEM (RSA hoặc ECDSA) kiểm tra
+// token RS*, PS* hoặc ES*. Không có khoá nào thì trả về nil.
+func NewTokenVerifier(hmacSecret string, publicKeyPEM []byte) (*TokenVerifier, error) {
+	if hmacSecret == "" && len(publicKeyPEM) == 0 {
+		return nil, nil
+	}
+
+	verifier := &TokenVerifier{hmacSecret: []byte(hmacSecret)}
+	if len(publicKeyPEM) > 0 {
+		if key, err := jwt.ParseRSAPublicKeyFromPEM(publicKeyPEM); err == nil {
+			verifier.publicKey = key
+		} else if key, err := jwt.ParseECPublicKeyFromPEM(publicKeyPEM); err == nil {
+			verifier.publicKey = key
+		} else {
+			return nil, errors.New("JWT public key must be an RSA or ECDSA key in PEM format")
+		}
+	}
+	return verifier, nil
+}
+
+// ParseClaims đọc claims của token có chữ ký hợp lệ và chưa hết hạn
+func (v *TokenVerifier) ParseClaims(tokenString string) (Claims, error) {
+	token, err := jwt.Parse(tokenString, v.key)
+	if err != nil {
+		return Claims{}, err
+	}
+	return claimsOf(token), nil
+}
+
+// key chọn khoá theo thuật toán trong header, thuật toán không có khoá tương ứng bị từ chối
+func (v *TokenVerifier) key(token *jwt.Token) (any, error) {
+	switch token.Method.(type) {
+	case *jwt.SigningMethodHMAC:
+		if len(v.hmacSecret) > 0 {
+			return v.hmacSecret, nil
+		}
+	case *jwt.SigningMethodRSA, *jwt.SigningMethodRSAPSS:
+		if key, ok := v.publicKey.(*rsa.PublicKey); ok {
+			return key, nil
+		}
+	case *jwt.SigningMethodECDSA:
+		if key, ok := v.publicKey.(*ecdsa.PublicKey); ok {
+			return key, nil
+		}
+	}
+	return nil, fmt.Errorf("unexpected signing method %s", token.Method.Alg())
+}
@@ -0,0 +1,204 @@
+package migration
+
+import (
+	"context"
+	"errors"
+	"time"
+	"topic-service/internal/topic/model"
+	"topic-service/pkg/migrate"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// MongoMigrations là lịch sử schema của MongoDB. Chỉ thêm migration mới vào cuối, không sửa migration đã phát hành.
+var MongoMigrations = []migrate.Migration[*mongo.Database]{
+	indexMigration(1, "create_topic_indexes", "topics",
+		index("search_terms_1", bson.D{{Key: "search_terms", Value: 1}}, false),
+		index("organization_id_1", bson.D{{Key: "organization_id", Value: 1}}, false),
+		index("is_template_1", bson.D{{Key: "is_template", Value: 1}}, false),
+		index("prerequisite_ids_1", bson.D{{Key: "prerequisite_ids", Value: 1}}, false),
+		index("rating_average_-1__id_1", bson.D{{Key: "rating_average", Value: -1}, {Key: "_id", Value: 1}}, false),
+		index("created_at_-1", bson.D{{Key: "created_at", Value: -1}}, false),
+	),
+	indexMigration(2, "create_topic_item_indexes", "topic_items",
+		index("topic_id_1_order_1", bson.D{{Key: "topic_id", Value: 1}, {Key: "order", Value: 1}}, false),
+	),
+	indexMigration(3, "create_curriculum_indexes", "curricula",
+		index("entries.topic_id_1", bson.D{{Key: "entries.topic_id", Value: 1}}, false),
+		index("created_at_-1", bson.D{{Key: "created_at", Value: -1}}, false),
+	),
+	indexMigration(4, "create_topic_redirect_indexes", "topic_redirects",
+		index("target_id_1", bson.D{{Key: "target_id", Value: 1}}, false),
+	),
+	indexMigration(5, "create_topic_audit_indexes", "topic_audit_logs",
+		index("topic_id_1_created_at_-1", bson.D{{Key: "topic_id", Value: 1}, {Key: "created_at", Value: -1}}, false),
+		index("details.merged_id_1", bson.D{{Key: "details.merged_id", Value: 1}}, false),
+	),
+	indexMigration(6, "create_topic_view_stat_indexes", "topic_view_stats",
+		index("topic_id_1_organization_id_1_date_1", bson.D{
+			{Key: "topic_id", Value: 1}, {Key: "organization_id", Value: 1}, {Key: "date", Value: 1},
+		}, true),
+		index("date_1_organization_id_1", bson.D{{Key: "date", Value: 1}, {Key: "organization_id", Value: 1}}, false),
+	),
+	indexMigration(7, "create_topic_favorite_indexes", "topic_favorites",
+		index("user_id_1_topic_id_1", bson.D{{Key: "user_id", Value: 1}, {Key: "topic_id", Value: 1}}, true),
+		index("user_id_1_created_at_-1", bson.D{{Key: "user_id", Value: 1}, {Key: "created_at", Value: -1}}, false),
+		index("topic_id_1", bson.D{{Key: "topic_id", Value: 1}}, false),
+	),
+	indexMigration(8, "create_topic_comment_indexes", "topic_comments",
+		index("topic_id_1_parent_id_1_created_at_-1", bson.D{
+			{Key: "topic_id", Value: 1}, {Key: "parent_id", Value: 1}, {Key: "created_at", Value: -1},
+		}, false),
+		index("topic_id_1_root_id_1_created_at_1", bson.D{
+			{Key: "topic_id", Value: 1}, {Key: "root_id", Value: 1}, {Key: "created_at", Value: 1},
+		}, false),
+	),
+	indexMigration(9, "create_topic_rating_indexes", "topic_ratings",
+		index("topic_id_1_user_id_1", bson.D{{Key: "topic_id", Value: 1}, {Key: "user_id", Value: 1}}, true),
+		index("topic_id_1_updated_at_-1", bson.D{{Key: "topic_id", Value: 1}, {Key: "updated_at", Value: -1}}, false),
+	),
+	indexMigration(10, "create_topic_co_view_indexes", "topic_co_views",
+		index("topic_a_1_topic_b_1", bson.D{{Key: "topic_a", Value: 1}, {Key: "topic_b", Value: 1}}, true),
+	),
+	// Tạo sẵn collection outbox vì MongoDB trước 4.4 không cho tạo collection trong transaction.
+	// Event đã gửi tự xoá sau 7 ngày, event pending và dead không có published_at nên được giữ lại.
+	indexMigration(11, "create_topic_outbox_indexes", "topic_outbox",
+		index("status_1_created_at_1__id_1", bson.D{
+			{Key: "status", Value: 1}, {Key: "created_at", Value: 1}, {Key: "_id", Value: 1},
+		}, false),
+		ttlIndex("published_at_1", bson.D{{Key: "published_at", Value: 1}}, 7*24*time.Hour),
+	),
+	// Tìm topic theo người sở hữu khi chuyển quyền sở hữu
+	indexMigration(12, "create_topic_created_by_index", "topics",
+		index("created_by_1", bson.D{{Key: "created_by", Value: 1}}, false),
+	),
+	// Event đã xử lý chỉ cần giữ lâu hơn thời gian bên gửi có thể gửi lại
+	indexMigration(13, "create_processed_event_indexes", "processed_events",
+		ttlIndex("processed_at_1", bson.D{{Key: "processed_at", Value: 1}}, 30*24*time.Hour),
+	),
+	indexMigration(14, "create_webhook_subscription_indexes", "webhook_subscriptions",
+		index("organization_id_1_active_1", bson.D{{Key: "organization_id", Value: 1}, {Key: "active", Value: 1}}, false),
+	),
+	// Nhật ký gửi webhook giữ 30 ngày, delivery replay không có dedup_key nên không bị ràng buộc unique
+	indexMigration(15, "create_webhook_delivery_indexes", "webhook_deliveries",
+		partialUniqueIndex("dedup_key_1", bson.D{{Key: "dedup_key", Value: 1}}, bson.M{"dedup_key": bson.M{"$exists": true}}),
+		index("status_1_next_attempt_at_1", bson.D{{Key: "status", Value: 1}, {Key: "next_attempt_at", Value: 1}}, false),
+		index("subscription_id_1_created_at_-1", bson.D{{Key: "subscription_id", Value: 1}, {Key: "created_at", Value: -1}}, false),
+		ttlIndex("created_at_1", bson.D{{Key: "created_at", Value: 1}}, 30*24*time.Hour),
+	),
+	// Đọc outbox theo thứ tự ghi cho GET /topic/changes
+	indexMigration(16, "create_topic_outbox_created_at_index", "topic_outbox",
+		index("created_at_1__id_1", bson.D{{Key: "created_at", Value: 1}, {Key: "_id", Value: 1}}, false),
+	),
+	// Topic tạo trước khi có tìm kiếm chưa có search_terms nên không bao giờ khớp GET /topic/search
+	{
+		Version: 17,
+		Name:    "backfill_topic_search_terms",
+		Up:      backfillSearchTerms,
+	},
+	// Relay tìm topic đang chờ gửi lại để bỏ qua khi lấy batch
+	indexMigration(18, "create_topic_outbox_next_attempt_index", "topic_outbox",
+		index("status_1_next_attempt_at_1", bson.D{{Key: "status", Value: 1}, {Key: "next_attempt_at", Value: 1}}, false),
+	),
+}
+
+// backfillBatchSize là số topic được cập nhật trong một lần BulkWrite
+const backfillBatchSize = 500
+
+// backfillSearchTerms tính search_terms cho topic chưa có field này. Không có Down vì search_terms
+// chỉ là dữ liệu suy ra từ tiêu đề, giữ lại khi rollback cũng không ảnh hưởng gì.
+func backfillSearchTerms(ctx context.Context, db *mongo.Database) error {
+	topics := db.Collection("topics")
+	cursor, err := topics.Find(ctx, bson.M{"search_terms": bson.M{"$exists": false}},
+		options.Find().SetProjection(bson.M{"title": 1, "titles": 1}))
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	var writes []mongo.WriteModel
+	flush := func() error {
+		if len(writes) == 0 {
+			return nil
+		}
+		_, err := topics.BulkWrite(ctx, writes, options.BulkWrite().SetOrdered(false))
+		writes = writes[:0]
+		return err
+	}
+
+	for cursor.Next(ctx) {
+		var topic model.Topic
+		if err := cursor.Decode(&topic); err != nil {
+			return err
+		}
+		topic.RefreshSearchTerms()
+		writes = append(writes, mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"_id": topic.ID, "search_terms": bson.M{"$exists": false}}).
+			SetUpdate(bson.M{"$set": bson.M{"search_terms": topic.SearchTerms}}))
+		if len(writes) == backfillBatchSize {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+	}
+	if err := cursor.Err(); err != nil {
+		return err
+	}
+	return flush()
+}
+
+func ttlIndex(name string, keys bson.D, ttl time.Duration) mongo.IndexModel {
+	model := index(name, keys, false)
+	model.Options.SetExpireAfterSeconds(int32(ttl / time.Second))
+	return model
+}
+
+func partialUniqueIndex(name string, keys bson.D, filter bson.M) mongo.IndexModel {
+	model := index(name, keys, true)
+	model.Options.SetPartialFilterExpression(filter)
+	return model
+}
+
+func index(name string, keys bson.D, unique bool) mongo.IndexModel {
+	opts := options.Index().SetName(name)
+	if unique {
+		opts.SetUnique(true)
+	}
+	return mongo.IndexModel{Keys: keys, Options: opts}
+}
+
+// indexMigration tạo các index trên một collection, Down xoá đúng các index đó theo tên
+func indexMigration(version int64, name, collection string, indexes ...mongo.IndexModel) migrate.Migration[*mongo.Database] {
+	return migrate.Migration[*mongo.Database]{
+		Version: version,
+		Name:    name,
+		Up: func(ctx context.Context, db *mongo.Database) error {
+			_, err := db.Collection(collection).Indexes().CreateMany(ctx, indexes)
+			return err
+		},
+		Down: func(ctx context.Context, db *mongo.Database) error {
+			for _, idx := range indexes {
+				_, err := db.Collection(collection).Indexes().DropOne(ctx, *idx.Options.Name)
+				if err != nil && !isIndexNotFound(err) {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+}
+
+func isIndexNotFound(err error) bool {
+	var cmdErr mongo.CommandError
+	if errors.As(err, &cmdErr) {
+		// 27: IndexNotFound, 26: NamespaceNotFound
+		return cmdErr.Code == 27 || cmdErr.Code == 26
+	}
+	return false
+}
+
+func NewMongoRunner(db *mongo.Database) (*migrate.Runner[*mongo.Database], error) {
+	return migrate.NewRunner(db, migrate.NewMongoStore(db), MongoMigrations)
+}
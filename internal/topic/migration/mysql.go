@@ -0,0 +1,49 @@
+package migration
+
+import (
+	"context"
+	"errors"
+	"topic-service/pkg/migrate"
+
+	"github.com/go-sql-driver/mysql"
+	"gorm.io/gorm"
+)
+
+// MySQLMigrations là lịch sử schema của MySQL. Chỉ thêm migration mới vào cuối, không sửa migration đã phát hành.
+// Topic được lưu trong MongoDB và chưa có code nào đọc MySQL nên danh sách còn trống; bảng đầu tiên được thêm
+// cùng với repository dùng nó. Migration baseline nhận bảng có sẵn bằng IF NOT EXISTS thì để down rỗng,
+// vì rollback nó sẽ xoá bảng không do migration tạo ra.
+var MySQLMigrations = []migrate.Migration[*gorm.DB]{}
+
+// sqlMigration chạy một câu lệnh cho mỗi chiều. MySQL tự commit DDL nên mỗi migration chỉ nên có một câu lệnh.
+// MySQL không có CREATE INDEX IF NOT EXISTS, index đã có sẵn trên database cũ được coi như đã tạo.
+// down rỗng nghĩa là migration không rollback được (migrate.ErrIrreversible).
+func sqlMigration(version int64, name, up, down string) migrate.Migration[*gorm.DB] {
+	m := migrate.Migration[*gorm.DB]{
+		Version: version,
+		Name:    name,
+		Up: func(ctx context.Context, db *gorm.DB) error {
+			err := db.WithContext(ctx).Exec(up).Error
+			if isDuplicateIndex(err) {
+				return nil
+			}
+			return err
+		},
+	}
+	if down != "" {
+		m.Down = func(ctx context.Context, db *gorm.DB) error {
+			return db.WithContext(ctx).Exec(down).Error
+		}
+	}
+	return m
+}
+
+func isDuplicateIndex(err error) bool {
+	var mysqlErr *mysql.MySQLError
+	// 1061: ER_DUP_KEYNAME
+	return errors.As(err, &mysqlErr) && mysqlErr.Number == 1061
+}
+
+func NewMySQLRunner(db *gorm.DB) (*migrate.Runner[*gorm.DB], error) {
+	return migrate.NewRunner(db, migrate.NewMySQLStore(db), MySQLMigrations)
+}
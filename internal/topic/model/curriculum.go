@@ -0,0 +1,34 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	CurriculumStatusDraft     = "draft"
+	CurriculumStatusPublished = "published"
+)
+
+// Curriculum là chuỗi topic có thứ tự do trường phát hành, ví dụ "Kindergarten Year 1"
+type Curriculum struct {
+	ID                 primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	Title              string             `bson:"title" json:"title"`
+	Description        string             `bson:"description" json:"description"`
+	Entries            []CurriculumEntry  `bson:"entries" json:"entries"`
+	Status             string             `bson:"status" json:"status"`
+	SourceCurriculumID string             `bson:"source_curriculum_id,omitempty" json:"source_curriculum_id,omitempty"`
+	CreatedBy          string             `bson:"created_by" json:"created_by"`
+	OrganizationID     string             `bson:"organization_id,omitempty" json:"organization_id,omitempty"`
+	PublishedAt        *time.Time         `bson:"published_at,omitempty" json:"published_at,omitempty"`
+	CreatedAt          time.Time          `bson:"created_at" json:"created_at"`
+	UpdatedAt          time.Time          `bson:"updated_at" json:"updated_at"`
+}
+
+type CurriculumEntry struct {
+	TopicID string `bson:"topic_id" json:"topic_id"`
+	Order   int    `bson:"order" json:"order"`
+	Week    *int   `bson:"week,omitempty" json:"week,omitempty"`
+	Term    string `bson:"term,omitempty" json:"term,omitempty"`
+}
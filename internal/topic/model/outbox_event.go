@@ -0,0 +1,82 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	TopicCreated = "TopicCreated"
+	TopicUpdated = "TopicUpdated"
+	TopicDeleted = "TopicDeleted"
+)
+
+const (
+	OutboxStatusPending   = "pending"
+	OutboxStatusPublished = "published"
+	// OutboxStatusDead là event lỗi quá số lần thử, cần xử lý thủ công
+	OutboxStatusDead = "dead"
+)
+
+// OutboxEvent là domain event được ghi cùng transaction với thay đổi dữ liệu, relay gửi đi sau.
+// Payload là JSON của TopicEvent.
+type OutboxEvent struct {
+	ID            primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	AggregateID   string             `bson:"aggregate_id" json:"aggregate_id"`
+	Type          string             `bson:"type" json:"type"`
+	Payload       string             `bson:"payload" json:"payload"`
+	Status        string             `bson:"status" json:"status"`
+	Attempts      int                `bson:"attempts" json:"attempts"`
+	LastError     string             `bson:"last_error,omitempty" json:"last_error,omitempty"`
+	NextAttemptAt time.Time          `bson:"next_attempt_at" json:"next_attempt_at"`
+	CreatedAt     time.Time          `bson:"created_at" json:"created_at"`
+	PublishedAt   *time.Time         `bson:"published_at,omitempty" json:"published_at,omitempty"`
+}
+
+// TopicEvent là nội dung event gửi cho service khác. Topic là trạng thái sau thay đổi, rỗng với TopicDeleted.
+type TopicEvent struct {
+	EventID        string    `json:"event_id"`
+	Type           string    `json:"type"`
+	TopicID        string    `json:"topic_id"`
+	OrganizationID string    `json:"organization_id"`
+	OccurredAt     time.Time `json:"occurred_at"`
+	Topic          *Topic    `json:"topic,omitempty"`
+}
+
+// NewTopicOutboxEvent tạo event chờ gửi cho topic. Với TopicDeleted, topic chỉ dùng để lấy ID và tổ chức.
+func NewTopicOutboxEvent(eventType string, topic *Topic) (*OutboxEvent, error) {
+	return NewTopicOutboxEventWithID(primitive.NewObjectID(), eventType, topic)
+}
+
+// NewTopicOutboxEventWithID dùng ID cho trước để cùng một thay đổi được ghi nhiều lần cũng chỉ tạo một bản ghi
+func NewTopicOutboxEventWithID(id primitive.ObjectID, eventType string, topic *Topic) (*OutboxEvent, error) {
+	now := time.Now()
+
+	event := TopicEvent{
+		EventID:        id.Hex(),
+		Type:           eventType,
+		TopicID:        topic.ID.Hex(),
+		OrganizationID: topic.OrganizationID,
+		OccurredAt:     now,
+	}
+	if eventType != TopicDeleted {
+		event.Topic = topic
+	}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return nil, err
+	}
+
+	return &OutboxEvent{
+		ID:            id,
+		AggregateID:   event.TopicID,
+		Type:          eventType,
+		Payload:       string(payload),
+		Status:        OutboxStatusPending,
+		NextAttemptAt: now,
+		CreatedAt:     now,
+	}, nil
+}
@@ -0,0 +1,11 @@
+package model
+
+import "time"
+
+// ProjectionCheckpoint là vị trí trong $all mà projection đã xử lý xong, subscription chạy lại từ vị trí này
+type ProjectionCheckpoint struct {
+	Name            string    `bson:"_id" json:"name"`
+	CommitPosition  uint64    `bson:"commit_position" json:"commit_position"`
+	PreparePosition uint64    `bson:"prepare_position" json:"prepare_position"`
+	UpdatedAt       time.Time `bson:"updated_at" json:"updated_at"`
+}
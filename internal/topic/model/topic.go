@@ -7,9 +7,29 @@ import (
 )
 
 type Topic struct {
-	ID        primitive.ObjectID `bson:"_id,omitempty" json:"id"`
-	Title     string             `bson:"title" json:"title"`
-	Icon      string             `bson:"icon" json:"icon"`
-	CreatedAt time.Time          `bson:"created_at" json:"created_at"`
-	UpdatedAt time.Time          `bson:"updated_at" json:"updated_at"`
+	ID primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	// Title luôn là tiêu đề ở DefaultLocale, Titles/Descriptions chứa bản dịch theo locale
+	Title         string            `bson:"title" json:"title"`
+	Titles        map[string]string `bson:"titles,omitempty" json:"titles,omitempty"`
+	Descriptions  map[string]string `bson:"descriptions,omitempty" json:"descriptions,omitempty"`
+	DefaultLocale string            `bson:"default_locale,omitempty" json:"default_locale,omitempty"`
+	// SearchTerms là các từ đã bỏ dấu của tiêu đề ở mọi locale, dùng cho tìm kiếm và typeahead
+	SearchTerms  []string `bson:"search_terms,omitempty" json:"-"`
+	Icon         string   `bson:"icon" json:"icon"`
+	ItemCount    int      `bson:"item_count" json:"item_count"`
+	CommentCount int      `bson:"comment_count" json:"comment_count"`
+	// Aggregate đánh giá được cập nhật tăng dần mỗi lần user đánh giá
+	RatingSum     int     `bson:"rating_sum" json:"-"`
+	RatingCount   int     `bson:"rating_count" json:"rating_count"`
+	RatingAverage float64 `bson:"rating_average" json:"rating_average"`
+	// PrerequisiteIDs là các topic cần học trước topic này
+	PrerequisiteIDs []string `bson:"prerequisite_ids" json:"prerequisite_ids"`
+	CreatedBy       string   `bson:"created_by" json:"created_by"`
+	OrganizationID  string   `bson:"organization_id" json:"organization_id"`
+	IsTemplate      bool     `bson:"is_template" json:"is_template"`
+	// Nguồn gốc khi topic được clone từ topic khác
+	SourceTopicID string     `bson:"source_topic_id,omitempty" json:"source_topic_id,omitempty"`
+	ClonedAt      *time.Time `bson:"cloned_at,omitempty" json:"cloned_at,omitempty"`
+	CreatedAt     time.Time  `bson:"created_at" json:"created_at"`
+	UpdatedAt     time.Time  `bson:"updated_at" json:"updated_at"`
 }
@@ -0,0 +1,224 @@
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// TopicStreamPrefix là tiền tố stream của topic trên EventStoreDB, mỗi topic một stream "topic-<id>"
+const TopicStreamPrefix = "topic-"
+
+// Loại event trong stream của topic. Tên khác các hằng outbox vì đây là event nội bộ của write model,
+// không phải event gửi cho service khác.
+const (
+	EventTopicCreated             = "TopicCreated"
+	EventTopicDetailsChanged      = "TopicDetailsChanged"
+	EventTopicItemCountChanged    = "TopicItemCountChanged"
+	EventTopicCommentCountChanged = "TopicCommentCountChanged"
+	EventTopicRatingChanged       = "TopicRatingChanged"
+	EventTopicPrerequisiteAdded   = "TopicPrerequisiteAdded"
+	EventTopicPrerequisiteRemoved = "TopicPrerequisiteRemoved"
+	EventTopicTemplateChanged     = "TopicTemplateChanged"
+	EventTopicMerged              = "TopicMerged"
+	EventTopicOwnerChanged        = "TopicOwnerChanged"
+	EventTopicDeleted             = "TopicDeleted"
+)
+
+func TopicStreamID(id string) string {
+	return TopicStreamPrefix + id
+}
+
+// TopicCreatedData chứa toàn bộ topic lúc tạo. SearchTerms và RatingSum không có trong JSON của Topic nên được
+// ghi riêng. Topic có sẵn trong MongoDB trước khi bật event sourcing cũng được nhập vào stream bằng event này.
+type TopicCreatedData struct {
+	Topic       Topic    `json:"topic"`
+	SearchTerms []string `json:"search_terms,omitempty"`
+	RatingSum   int      `json:"rating_sum"`
+}
+
+type TopicDetailsChangedData struct {
+	Title         string            `json:"title"`
+	Titles        map[string]string `json:"titles,omitempty"`
+	Descriptions  map[string]string `json:"descriptions,omitempty"`
+	DefaultLocale string            `json:"default_locale,omitempty"`
+	SearchTerms   []string          `json:"search_terms,omitempty"`
+	UpdatedAt     time.Time         `json:"updated_at"`
+}
+
+type TopicCountChangedData struct {
+	Delta int `json:"delta"`
+}
+
+type TopicRatingChangedData struct {
+	SumDelta   int `json:"sum_delta"`
+	CountDelta int `json:"count_delta"`
+}
+
+// TopicPrerequisiteChangedData: UpdatedAt rỗng khi cạnh bị gỡ do topic kia bị xoá hoặc gộp
+type TopicPrerequisiteChangedData struct {
+	PrerequisiteID string     `json:"prerequisite_id"`
+	UpdatedAt      *time.Time `json:"updated_at,omitempty"`
+}
+
+type TopicTemplateChangedData struct {
+	IsTemplate bool      `json:"is_template"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+type TopicOwnerChangedData struct {
+	OwnerID   string    `json:"owner_id"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// TopicMergedData là các field của topic còn lại sau khi gộp, giống những gì ApplyMerge ghi
+type TopicMergedData struct {
+	TopicDetailsChangedData
+	Icon            string   `json:"icon"`
+	IsTemplate      bool     `json:"is_template"`
+	PrerequisiteIDs []string `json:"prerequisite_ids"`
+	ItemCount       int      `json:"item_count"`
+	CommentCount    int      `json:"comment_count"`
+	RatingSum       int      `json:"rating_sum"`
+	RatingCount     int      `json:"rating_count"`
+	RatingAverage   float64  `json:"rating_average"`
+}
+
+// TopicStreamEvent là event chuẩn bị ghi vào stream, Data được lưu dạng JSON
+type TopicStreamEvent struct {
+	Type string
+	Data any
+}
+
+// TopicAggregate là trạng thái topic dựng lại từ stream. Version là số thứ tự của event cuối đã áp dụng,
+// -1 khi stream chưa tồn tại.
+type TopicAggregate struct {
+	Topic   Topic
+	Version int64
+	Deleted bool
+}
+
+func NewTopicAggregate() *TopicAggregate {
+	return &TopicAggregate{Version: -1}
+}
+
+// Exists cho biết topic đã được tạo và chưa bị xoá
+func (a *TopicAggregate) Exists() bool {
+	return a.Version >= 0 && !a.Deleted
+}
+
+// Apply áp dụng event kế tiếp của stream lên trạng thái hiện tại
+func (a *TopicAggregate) Apply(eventType string, data []byte) error {
+	t := &a.Topic
+	switch eventType {
+	case EventTopicCreated:
+		var d TopicCreatedData
+		if err := json.Unmarshal(data, &d); err != nil {
+			return err
+		}
+		*t = d.Topic
+		t.SearchTerms = d.SearchTerms
+		t.RatingSum = d.RatingSum
+		a.Deleted = false
+	case EventTopicDetailsChanged:
+		var d TopicDetailsChangedData
+		if err := json.Unmarshal(data, &d); err != nil {
+			return err
+		}
+		d.applyTo(t)
+	case EventTopicItemCountChanged:
+		var d TopicCountChangedData
+		if err := json.Unmarshal(data, &d); err != nil {
+			return err
+		}
+		t.ItemCount += d.Delta
+	case EventTopicCommentCountChanged:
+		var d TopicCountChangedData
+		if err := json.Unmarshal(data, &d); err != nil {
+			return err
+		}
+		t.CommentCount += d.Delta
+	case EventTopicRatingChanged:
+		var d TopicRatingChangedData
+		if err := json.Unmarshal(data, &d); err != nil {
+			return err
+		}
+		t.RatingSum += d.SumDelta
+		t.RatingCount += d.CountDelta
+		t.RatingAverage = 0
+		if t.RatingCount > 0 {
+			t.RatingAverage = float64(t.RatingSum) / float64(t.RatingCount)
+		}
+	case EventTopicPrerequisiteAdded:
+		var d TopicPrerequisiteChangedData
+		if err := json.Unmarshal(data, &d); err != nil {
+			return err
+		}
+		if !slices.Contains(t.PrerequisiteIDs, d.PrerequisiteID) {
+			t.PrerequisiteIDs = append(t.PrerequisiteIDs, d.PrerequisiteID)
+		}
+		if d.UpdatedAt != nil {
+			t.UpdatedAt = *d.UpdatedAt
+		}
+	case EventTopicPrerequisiteRemoved:
+		var d TopicPrerequisiteChangedData
+		if err := json.Unmarshal(data, &d); err != nil {
+			return err
+		}
+		ids := make([]string, 0, len(t.PrerequisiteIDs))
+		for _, id := range t.PrerequisiteIDs {
+			if id != d.PrerequisiteID {
+				ids = append(ids, id)
+			}
+		}
+		t.PrerequisiteIDs = ids
+		if d.UpdatedAt != nil {
+			t.UpdatedAt = *d.UpdatedAt
+		}
+	case EventTopicTemplateChanged:
+		var d TopicTemplateChangedData
+		if err := json.Unmarshal(data, &d); err != nil {
+			return err
+		}
+		t.IsTemplate = d.IsTemplate
+		t.UpdatedAt = d.UpdatedAt
+	case EventTopicMerged:
+		var d TopicMergedData
+		if err := json.Unmarshal(data, &d); err != nil {
+			return err
+		}
+		d.applyTo(t)
+		t.Icon = d.Icon
+		t.IsTemplate = d.IsTemplate
+		t.PrerequisiteIDs = d.PrerequisiteIDs
+		t.ItemCount = d.ItemCount
+		t.CommentCount = d.CommentCount
+		t.RatingSum = d.RatingSum
+		t.RatingCount = d.RatingCount
+		t.RatingAverage = d.RatingAverage
+	case EventTopicOwnerChanged:
+		var d TopicOwnerChangedData
+		if err := json.Unmarshal(data, &d); err != nil {
+			return err
+		}
+		t.CreatedBy = d.OwnerID
+		t.UpdatedAt = d.UpdatedAt
+	case EventTopicDeleted:
+		a.Deleted = true
+	default:
+		return fmt.Errorf("unknown topic event type %q", eventType)
+	}
+
+	a.Version++
+	return nil
+}
+
+func (d TopicDetailsChangedData) applyTo(t *Topic) {
+	t.Title = d.Title
+	t.Titles = d.Titles
+	t.Descriptions = d.Descriptions
+	t.DefaultLocale = d.DefaultLocale
+	t.SearchTerms = d.SearchTerms
+	t.UpdatedAt = d.UpdatedAt
+}
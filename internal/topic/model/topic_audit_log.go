@@ -0,0 +1,23 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	AuditActionMerge = "merge"
+	// AuditActionOwnershipTransfer: topic được chuyển cho người khác khi user sở hữu bị xoá hoặc bị khoá
+	AuditActionOwnershipTransfer = "ownership_transfer"
+)
+
+// TopicAuditLog ghi lại các thao tác quản trị trên topic
+type TopicAuditLog struct {
+	ID        primitive.ObjectID     `bson:"_id,omitempty" json:"id"`
+	Action    string                 `bson:"action" json:"action"`
+	TopicID   string                 `bson:"topic_id" json:"topic_id"`
+	ActorID   string                 `bson:"actor_id" json:"actor_id"`
+	Details   map[string]interface{} `bson:"details" json:"details"`
+	CreatedAt time.Time              `bson:"created_at" json:"created_at"`
+}
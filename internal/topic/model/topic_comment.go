@@ -0,0 +1,30 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// TopicComment là một bình luận trong thread của topic.
+// RootID là bình luận gốc của thread (bằng ID với bình luận gốc), dùng để phân trang theo thread.
+type TopicComment struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	TopicID   string             `bson:"topic_id" json:"topic_id"`
+	ParentID  string             `bson:"parent_id,omitempty" json:"parent_id,omitempty"`
+	RootID    string             `bson:"root_id" json:"root_id"`
+	AuthorID  string             `bson:"author_id" json:"author_id"`
+	Content   string             `bson:"content" json:"content"`
+	Mentions  []CommentMention   `bson:"mentions" json:"mentions"`
+	Hidden    bool               `bson:"hidden" json:"hidden"`
+	HiddenBy  string             `bson:"hidden_by,omitempty" json:"hidden_by,omitempty"`
+	Deleted   bool               `bson:"deleted" json:"deleted"`
+	EditedAt  *time.Time         `bson:"edited_at,omitempty" json:"edited_at,omitempty"`
+	CreatedAt time.Time          `bson:"created_at" json:"created_at"`
+	UpdatedAt time.Time          `bson:"updated_at" json:"updated_at"`
+}
+
+type CommentMention struct {
+	UserID string `bson:"user_id" json:"user_id"`
+	Name   string `bson:"name" json:"name"`
+}
@@ -0,0 +1,21 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type TopicFavorite struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	UserID    string             `bson:"user_id" json:"user_id"`
+	TopicID   string             `bson:"topic_id" json:"topic_id"`
+	CreatedAt time.Time          `bson:"created_at" json:"created_at"`
+}
+
+// RecentTopics là danh sách topic xem gần đây của một user, mới nhất đứng đầu
+type RecentTopics struct {
+	UserID    string    `bson:"_id" json:"user_id"`
+	TopicIDs  []string  `bson:"topic_ids" json:"topic_ids"`
+	UpdatedAt time.Time `bson:"updated_at" json:"updated_at"`
+}
@@ -0,0 +1,21 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// TopicItem là một phần nội dung trong topic: từ vựng, hình ảnh, phát âm và video
+type TopicItem struct {
+	ID         primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	TopicID    primitive.ObjectID `bson:"topic_id" json:"topic_id"`
+	Word       string             `bson:"word" json:"word"`
+	Images     []string           `bson:"images" json:"images"`
+	CoverImage string             `bson:"cover_image" json:"cover_image"`
+	AudioUrl   string             `bson:"audio_url" json:"audio_url"`
+	VideoUrl   string             `bson:"video_url" json:"video_url"`
+	Order      int                `bson:"order" json:"order"`
+	CreatedAt  time.Time          `bson:"created_at" json:"created_at"`
+	UpdatedAt  time.Time          `bson:"updated_at" json:"updated_at"`
+}
@@ -0,0 +1,29 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	MinRatingScore = 1
+	MaxRatingScore = 5
+)
+
+// TopicRating là đánh giá của một user cho một topic, mỗi cặp (topic_id, user_id) chỉ có một bản ghi
+type TopicRating struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	TopicID   string             `bson:"topic_id" json:"topic_id"`
+	UserID    string             `bson:"user_id" json:"user_id"`
+	Score     int                `bson:"score" json:"score"`
+	Feedback  string             `bson:"feedback" json:"feedback"`
+	CreatedAt time.Time          `bson:"created_at" json:"created_at"`
+	UpdatedAt time.Time          `bson:"updated_at" json:"updated_at"`
+}
+
+// RatingTotals là tổng điểm và số lượt đánh giá, dùng để cập nhật aggregate trên topic
+type RatingTotals struct {
+	Sum   int `bson:"sum"`
+	Count int `bson:"count"`
+}
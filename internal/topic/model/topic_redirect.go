@@ -0,0 +1,11 @@
+package model
+
+import "time"
+
+// TopicRedirect là tombstone của topic đã bị gộp, trỏ tới topic còn lại
+type TopicRedirect struct {
+	ID        string    `bson:"_id" json:"id"`
+	TargetID  string    `bson:"target_id" json:"target_id"`
+	CreatedBy string    `bson:"created_by" json:"created_by"`
+	CreatedAt time.Time `bson:"created_at" json:"created_at"`
+}
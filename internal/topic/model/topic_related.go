@@ -0,0 +1,25 @@
+package model
+
+import "time"
+
+// TopicCoView đếm số lần hai topic được cùng một user xem trong một phiên.
+// TopicA luôn nhỏ hơn TopicB để mỗi cặp chỉ có một bản ghi.
+type TopicCoView struct {
+	TopicA string `bson:"topic_a" json:"topic_a"`
+	TopicB string `bson:"topic_b" json:"topic_b"`
+	Count  int64  `bson:"count" json:"count"`
+}
+
+// TopicRelated là danh sách gợi ý đã tính sẵn cho một topic, sắp xếp theo điểm giảm dần
+type TopicRelated struct {
+	TopicID    string              `bson:"_id" json:"topic_id"`
+	Related    []RelatedTopicScore `bson:"related" json:"related"`
+	ComputedAt time.Time           `bson:"computed_at" json:"computed_at"`
+}
+
+type RelatedTopicScore struct {
+	TopicID     string  `bson:"topic_id" json:"topic_id"`
+	Score       float64 `bson:"score" json:"score"`
+	TitleScore  float64 `bson:"title_score" json:"title_score"`
+	CoViewScore float64 `bson:"co_view_score" json:"co_view_score"`
+}
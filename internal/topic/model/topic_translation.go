@@ -0,0 +1,89 @@
+package model
+
+import (
+	"sort"
+	"topic-service/pkg/helper"
+)
+
+// Localize chọn tiêu đề và mô tả phù hợp nhất với locale: đúng locale yêu cầu,
+// sau đó là DefaultLocale, cuối cùng là locale bất kỳ có bản dịch (theo thứ tự chữ cái).
+// Topic cũ chưa có bản dịch trả về Title.
+func (t *Topic) Localize(locale string) (title, description, resolved string) {
+	for _, candidate := range t.localeFallbacks(locale) {
+		if value := t.Titles[candidate]; value != "" {
+			title, resolved = value, candidate
+			break
+		}
+	}
+	if title == "" {
+		title, resolved = t.Title, t.DefaultLocale
+	}
+
+	description = t.Descriptions[resolved]
+	if description == "" {
+		description = t.Descriptions[t.DefaultLocale]
+	}
+	return title, description, resolved
+}
+
+func (t *Topic) localeFallbacks(locale string) []string {
+	candidates := []string{locale, t.DefaultLocale}
+
+	others := make([]string, 0, len(t.Titles))
+	for l := range t.Titles {
+		others = append(others, l)
+	}
+	sort.Strings(others)
+	return append(candidates, others...)
+}
+
+// SetTranslation ghi bản dịch của một locale. Bản dịch ở DefaultLocale cũng cập nhật Title.
+func (t *Topic) SetTranslation(locale, title, description string) {
+	if t.Titles == nil {
+		t.Titles = map[string]string{}
+	}
+	if t.Descriptions == nil {
+		t.Descriptions = map[string]string{}
+	}
+
+	t.Titles[locale] = title
+	if description != "" {
+		t.Descriptions[locale] = description
+	} else {
+		delete(t.Descriptions, locale)
+	}
+
+	if locale == t.DefaultLocale {
+		t.Title = title
+	}
+	t.RefreshSearchTerms()
+}
+
+// RemoveTranslation xoá bản dịch của một locale khác DefaultLocale
+func (t *Topic) RemoveTranslation(locale string) {
+	delete(t.Titles, locale)
+	delete(t.Descriptions, locale)
+	t.RefreshSearchTerms()
+}
+
+// RefreshSearchTerms tính lại các từ khoá tìm kiếm từ tiêu đề ở mọi locale
+func (t *Topic) RefreshSearchTerms() {
+	seen := map[string]bool{}
+	terms := []string{}
+
+	titles := []string{t.Title}
+	for _, title := range t.Titles {
+		titles = append(titles, title)
+	}
+	for _, title := range titles {
+		for _, token := range helper.SearchTokens(title) {
+			if !seen[token] {
+				seen[token] = true
+				terms = append(terms, token)
+			}
+		}
+	}
+
+	sort.Strings(terms)
+	t.SearchTerms = terms
+}
@@ -0,0 +1,25 @@
+package model
+
+import "time"
+
+// TopicViewEvent là một lượt xem topic, được ghi nhận bất đồng bộ
+type TopicViewEvent struct {
+	TopicID        string
+	UserID         string
+	OrganizationID string
+	ViewedAt       time.Time
+}
+
+// TopicViewStat là bộ đếm lượt xem theo ngày của một topic trong một tổ chức
+type TopicViewStat struct {
+	TopicID        string `bson:"topic_id" json:"topic_id"`
+	OrganizationID string `bson:"organization_id" json:"organization_id"`
+	Date           string `bson:"date" json:"date"`
+	Views          int64  `bson:"views" json:"views"`
+}
+
+// TopicViewTotal là tổng lượt xem của một topic trong một khoảng thời gian
+type TopicViewTotal struct {
+	TopicID string `bson:"_id" json:"topic_id"`
+	Views   int64  `bson:"views" json:"views"`
+}
@@ -0,0 +1,26 @@
+package model
+
+import "time"
+
+// Loại event vòng đời user do go-main-service gửi
+const (
+	UserEventDeleted     = "user.deleted"
+	UserEventDeactivated = "user.deactivated"
+)
+
+// UserLifecycleEvent là event user bị xoá hoặc bị khoá, nhận qua webhook hoặc message bus
+type UserLifecycleEvent struct {
+	EventID        string    `json:"event_id"`
+	Type           string    `json:"type"`
+	UserID         string    `json:"user_id"`
+	OrganizationID string    `json:"organization_id,omitempty"`
+	OccurredAt     time.Time `json:"occurred_at"`
+}
+
+// ProcessedEvent đánh dấu event đã xử lý xong để bỏ qua khi được gửi lại
+type ProcessedEvent struct {
+	ID          string    `bson:"_id" json:"id"`
+	Consumer    string    `bson:"consumer" json:"consumer"`
+	EventID     string    `bson:"event_id" json:"event_id"`
+	ProcessedAt time.Time `bson:"processed_at" json:"processed_at"`
+}
@@ -0,0 +1,68 @@
+package model
+
+import (
+	"slices"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	WebhookDeliveryPending   = "pending"
+	WebhookDeliverySucceeded = "succeeded"
+	// WebhookDeliveryFailed là delivery đã hết số lần thử, chỉ gửi lại khi được replay
+	WebhookDeliveryFailed = "failed"
+)
+
+// WebhookSubscription là đăng ký nhận callback khi topic của tổ chức thay đổi.
+// Events rỗng nghĩa là nhận mọi loại event.
+type WebhookSubscription struct {
+	ID             primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	OrganizationID string             `bson:"organization_id" json:"organization_id"`
+	URL            string             `bson:"url" json:"url"`
+	// Secret dùng để ký payload, chỉ trả về cho client lúc tạo
+	Secret string   `bson:"secret" json:"-"`
+	Events []string `bson:"events" json:"events"`
+	Active bool     `bson:"active" json:"active"`
+	// ConsecutiveFailures đếm số lần gửi lỗi liên tiếp, quá ngưỡng thì subscription bị tắt
+	ConsecutiveFailures int        `bson:"consecutive_failures" json:"consecutive_failures"`
+	DisabledAt          *time.Time `bson:"disabled_at,omitempty" json:"disabled_at,omitempty"`
+	DisabledReason      string     `bson:"disabled_reason,omitempty" json:"disabled_reason,omitempty"`
+	CreatedBy           string     `bson:"created_by" json:"created_by"`
+	CreatedAt           time.Time  `bson:"created_at" json:"created_at"`
+	UpdatedAt           time.Time  `bson:"updated_at" json:"updated_at"`
+}
+
+// Matches cho biết subscription có nhận event này không
+func (s *WebhookSubscription) Matches(eventType, organizationID string) bool {
+	if !s.Active || s.OrganizationID != organizationID {
+		return false
+	}
+	return len(s.Events) == 0 || slices.Contains(s.Events, eventType)
+}
+
+// WebhookDeliveryDedupKey là DedupKey của delivery đầu tiên của event cho subscription
+func WebhookDeliveryDedupKey(subscriptionID, eventID string) string {
+	return subscriptionID + ":" + eventID
+}
+
+// WebhookDelivery là một lần gửi event cho một subscription, giữ lại làm nhật ký và để replay
+type WebhookDelivery struct {
+	ID             primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	SubscriptionID string             `bson:"subscription_id" json:"subscription_id"`
+	EventID        string             `bson:"event_id" json:"event_id"`
+	EventType      string             `bson:"event_type" json:"event_type"`
+	Payload        string             `bson:"payload" json:"payload"`
+	// ReplayOf là delivery gốc khi delivery này được tạo bằng replay
+	ReplayOf string `bson:"replay_of,omitempty" json:"replay_of,omitempty"`
+	// DedupKey là "<subscription_id>:<event_id>" để một event chỉ tạo một delivery cho mỗi subscription,
+	// delivery replay không có key này
+	DedupKey       string     `bson:"dedup_key,omitempty" json:"-"`
+	Status         string     `bson:"status" json:"status"`
+	Attempts       int        `bson:"attempts" json:"attempts"`
+	LastStatusCode int        `bson:"last_status_code,omitempty" json:"last_status_code,omitempty"`
+	LastError      string     `bson:"last_error,omitempty" json:"last_error,omitempty"`
+	NextAttemptAt  time.Time  `bson:"next_attempt_at" json:"next_attempt_at"`
+	CreatedAt      time.Time  `bson:"created_at" json:"created_at"`
+	DeliveredAt    *time.Time `bson:"delivered_at,omitempty" json:"delivered_at,omitempty"`
+}
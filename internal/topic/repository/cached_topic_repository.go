@@ -0,0 +1,299 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sync/atomic"
+	"time"
+	"topic-service/internal/topic/model"
+	"topic-service/pkg/cache"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"golang.org/x/sync/singleflight"
+)
+
+const (
+	topicCacheKeyPrefix = "topic-service:topic:"
+	// topicInvalidateChannel là kênh Redis pub/sub báo các instance khác xoá topic khỏi cache trong process
+	topicInvalidateChannel = "topic-service:topic:invalidate"
+	// purgeAllMessage yêu cầu xoá toàn bộ cache, dùng cho các thao tác sửa nhiều topic cùng lúc
+	purgeAllMessage = "*"
+
+	DefaultTopicCacheSize = 10000
+	DefaultTopicCacheTTL  = 5 * time.Minute
+)
+
+// TopicCacheOptions cấu hình cache topic. Redis nil thì chỉ dùng LRU trong process và không broadcast.
+type TopicCacheOptions struct {
+	Size  int
+	TTL   time.Duration
+	Redis *cache.Redis
+}
+
+// cachedTopicRepository đọc topic theo ID qua LRU trong process, sau đó Redis (nếu có), cuối cùng mới tới repository gốc.
+// Mọi thao tác ghi xoá topic khỏi cache và broadcast cho các instance khác.
+type cachedTopicRepository struct {
+	TopicRepository
+	local *cache.LRU
+	redis *cache.Redis
+	ttl   time.Duration
+	group singleflight.Group
+	// generation tăng sau mỗi lần invalidate, kết quả đọc từ DB bắt đầu trước đó không được ghi vào cache
+	generation atomic.Uint64
+}
+
+// NewCachedTopicRepository bọc repo bằng cache. ctx quản lý vòng đời của subscription Redis.
+func NewCachedTopicRepository(ctx context.Context, repo TopicRepository, opts TopicCacheOptions) (TopicRepository, error) {
+	if opts.Size <= 0 {
+		opts.Size = DefaultTopicCacheSize
+	}
+	if opts.TTL <= 0 {
+		opts.TTL = DefaultTopicCacheTTL
+	}
+
+	local, err := cache.NewLRU(opts.Size, opts.TTL)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &cachedTopicRepository{
+		TopicRepository: repo,
+		local:           local,
+		redis:           opts.Redis,
+		ttl:             opts.TTL,
+	}
+	if r.redis != nil {
+		go r.redis.Subscribe(ctx, topicInvalidateChannel, r.onInvalidate, r.purgeLocal)
+	}
+	return r, nil
+}
+
+func (r *cachedTopicRepository) GetByID(ctx context.Context, id string) (*model.Topic, error) {
+	// Trong transaction phải đọc từ DB để thấy thay đổi chưa commit, và không được cache dữ liệu đó
+	if InTransaction(ctx) {
+		return r.TopicRepository.GetByID(ctx, id)
+	}
+	if topic, ok := r.lookup(ctx, id); ok {
+		return topic, nil
+	}
+
+	generation := r.generation.Load()
+	// singleflight gộp các lần miss đồng thời trên cùng ID thành một lần gọi DB
+	value, err, _ := r.group.Do(id, func() (interface{}, error) {
+		// Không dùng ctx huỷ được của request đầu tiên vì kết quả được chia cho các request đang chờ khác
+		topic, err := r.TopicRepository.GetByID(context.WithoutCancel(ctx), id)
+		if err != nil {
+			return nil, err
+		}
+		data, err := bson.Marshal(topic)
+		if err != nil {
+			return nil, err
+		}
+		r.store(ctx, id, data, generation)
+		return data, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return decodeTopic(value.([]byte))
+}
+
+func (r *cachedTopicRepository) GetByIDs(ctx context.Context, ids []string) ([]*model.Topic, error) {
+	if InTransaction(ctx) {
+		return r.TopicRepository.GetByIDs(ctx, ids)
+	}
+
+	topics := make([]*model.Topic, 0, len(ids))
+	var missing []string
+	for _, id := range ids {
+		if topic, ok := r.lookup(ctx, id); ok {
+			topics = append(topics, topic)
+		} else {
+			missing = append(missing, id)
+		}
+	}
+	if len(missing) == 0 {
+		return topics, nil
+	}
+
+	generation := r.generation.Load()
+	loaded, err := r.TopicRepository.GetByIDs(ctx, missing)
+	if err != nil {
+		return nil, err
+	}
+	for _, topic := range loaded {
+		if data, err := bson.Marshal(topic); err == nil {
+			r.store(ctx, topic.ID.Hex(), data, generation)
+		}
+	}
+	return append(topics, loaded...), nil
+}
+
+func (r *cachedTopicRepository) EmitsOutboxEvents() bool {
+	return EmitsOutboxEvents(r.TopicRepository)
+}
+
+func (r *cachedTopicRepository) Update(ctx context.Context, id string, topic *model.Topic) error {
+	defer r.invalidate(ctx, id)
+	return r.TopicRepository.Update(ctx, id, topic)
+}
+
+func (r *cachedTopicRepository) Delete(ctx context.Context, id string) error {
+	defer r.invalidate(ctx, id)
+	return r.TopicRepository.Delete(ctx, id)
+}
+
+func (r *cachedTopicRepository) IncrementItemCount(ctx context.Context, id string, delta int) error {
+	defer r.invalidate(ctx, id)
+	return r.TopicRepository.IncrementItemCount(ctx, id, delta)
+}
+
+func (r *cachedTopicRepository) IncrementCommentCount(ctx context.Context, id string, delta int) error {
+	defer r.invalidate(ctx, id)
+	return r.TopicRepository.IncrementCommentCount(ctx, id, delta)
+}
+
+func (r *cachedTopicRepository) ApplyRatingDelta(ctx context.Context, id string, sumDelta, countDelta int) error {
+	defer r.invalidate(ctx, id)
+	return r.TopicRepository.ApplyRatingDelta(ctx, id, sumDelta, countDelta)
+}
+
+func (r *cachedTopicRepository) AddPrerequisite(ctx context.Context, id string, prerequisiteID string) error {
+	defer r.invalidate(ctx, id)
+	return r.TopicRepository.AddPrerequisite(ctx, id, prerequisiteID)
+}
+
+func (r *cachedTopicRepository) RemovePrerequisite(ctx context.Context, id string, prerequisiteID string) error {
+	defer r.invalidate(ctx, id)
+	return r.TopicRepository.RemovePrerequisite(ctx, id, prerequisiteID)
+}
+
+func (r *cachedTopicRepository) RemovePrerequisiteFromAll(ctx context.Context, prerequisiteID string) error {
+	defer r.invalidateAll(ctx)
+	return r.TopicRepository.RemovePrerequisiteFromAll(ctx, prerequisiteID)
+}
+
+func (r *cachedTopicRepository) SetTemplate(ctx context.Context, id string, isTemplate bool) error {
+	defer r.invalidate(ctx, id)
+	return r.TopicRepository.SetTemplate(ctx, id, isTemplate)
+}
+
+func (r *cachedTopicRepository) ReplacePrerequisite(ctx context.Context, oldID, newID string) error {
+	defer r.invalidateAll(ctx)
+	return r.TopicRepository.ReplacePrerequisite(ctx, oldID, newID)
+}
+
+func (r *cachedTopicRepository) ApplyMerge(ctx context.Context, id string, merged *model.Topic) error {
+	defer r.invalidate(ctx, id)
+	return r.TopicRepository.ApplyMerge(ctx, id, merged)
+}
+
+func (r *cachedTopicRepository) SetOwner(ctx context.Context, id string, ownerID string) error {
+	defer r.invalidate(ctx, id)
+	return r.TopicRepository.SetOwner(ctx, id, ownerID)
+}
+
+// lookup đọc LRU trước rồi tới Redis, lỗi Redis chỉ được log và coi như miss
+func (r *cachedTopicRepository) lookup(ctx context.Context, id string) (*model.Topic, bool) {
+	if data, ok := r.local.Get(id); ok {
+		if topic, err := decodeTopic(data); err == nil {
+			return topic, true
+		}
+	}
+	if r.redis == nil {
+		return nil, false
+	}
+
+	data, err := r.redis.Get(ctx, topicCacheKeyPrefix+id)
+	if err != nil {
+		if !errors.Is(err, cache.ErrNil) {
+			log.Printf("topic cache: redis get %s: %v", id, err)
+		}
+		return nil, false
+	}
+	topic, err := decodeTopic(data)
+	if err != nil {
+		return nil, false
+	}
+	r.local.Set(id, data)
+	return topic, true
+}
+
+func (r *cachedTopicRepository) store(ctx context.Context, id string, data []byte, generation uint64) {
+	if r.generation.Load() != generation {
+		return
+	}
+	r.local.Set(id, data)
+	if r.redis != nil {
+		if err := r.redis.Set(ctx, topicCacheKeyPrefix+id, data, r.ttl); err != nil {
+			log.Printf("topic cache: redis set %s: %v", id, err)
+		}
+	}
+}
+
+func (r *cachedTopicRepository) invalidate(ctx context.Context, id string) {
+	// Request khác có thể đọc lại bản cũ trước khi transaction commit, nên xoá thêm lần nữa sau commit
+	if InTransaction(ctx) {
+		AfterCommit(ctx, func() { r.invalidate(WithoutTransaction(ctx), id) })
+	}
+	r.generation.Add(1)
+	r.group.Forget(id)
+	r.local.Delete(id)
+	if r.redis == nil {
+		return
+	}
+
+	// Context riêng để vẫn invalidate được khi request đã bị huỷ sau khi ghi xong
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 3*time.Second)
+	defer cancel()
+	if err := r.redis.Del(ctx, topicCacheKeyPrefix+id); err != nil {
+		log.Printf("topic cache: redis del %s: %v", id, err)
+	}
+	if err := r.redis.Publish(ctx, topicInvalidateChannel, id); err != nil {
+		log.Printf("topic cache: publish invalidation %s: %v", id, err)
+	}
+}
+
+func (r *cachedTopicRepository) invalidateAll(ctx context.Context) {
+	if InTransaction(ctx) {
+		AfterCommit(ctx, func() { r.invalidateAll(WithoutTransaction(ctx)) })
+	}
+	r.purgeLocal()
+	if r.redis == nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 10*time.Second)
+	defer cancel()
+	if err := r.redis.DelPattern(ctx, topicCacheKeyPrefix+"[0-9a-f]*"); err != nil {
+		log.Printf("topic cache: redis purge: %v", err)
+	}
+	if err := r.redis.Publish(ctx, topicInvalidateChannel, purgeAllMessage); err != nil {
+		log.Printf("topic cache: publish purge: %v", err)
+	}
+}
+
+// onInvalidate xử lý invalidation từ instance khác, chỉ cần xoá bản trong process vì Redis đã được xoá
+func (r *cachedTopicRepository) onInvalidate(message string) {
+	if message == purgeAllMessage {
+		r.purgeLocal()
+		return
+	}
+	r.generation.Add(1)
+	r.group.Forget(message)
+	r.local.Delete(message)
+}
+
+func (r *cachedTopicRepository) purgeLocal() {
+	r.generation.Add(1)
+	r.local.Purge()
+}
+
+func decodeTopic(data []byte) (*model.Topic, error) {
+	var topic model.Topic
+	if err := bson.Unmarshal(data, &topic); err != nil {
+		return nil, err
+	}
+	return &topic, nil
+}
@@ -0,0 +1,162 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+	"topic-service/internal/topic/model"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type CurriculumRepository interface {
+	Create(ctx context.Context, curriculum *model.Curriculum) (*model.Curriculum, error)
+	GetByID(ctx context.Context, id string) (*model.Curriculum, error)
+	GetAll(ctx context.Context) ([]*model.Curriculum, error)
+	Update(ctx context.Context, id string, curriculum *model.Curriculum) error
+	UpdateEntries(ctx context.Context, id string, entries []model.CurriculumEntry) error
+	Publish(ctx context.Context, id string, publishedAt time.Time) error
+	Delete(ctx context.Context, id string) error
+	FindByTopicID(ctx context.Context, topicID string) ([]*model.Curriculum, error)
+}
+
+type curriculumRepository struct {
+	collection *mongo.Collection
+}
+
+func NewCurriculumRepository(collection *mongo.Collection) CurriculumRepository {
+	return &curriculumRepository{collection}
+}
+
+func (r *curriculumRepository) Create(ctx context.Context, curriculum *model.Curriculum) (*model.Curriculum, error) {
+	_, err := r.collection.InsertOne(ctx, curriculum)
+	if err != nil {
+		return nil, err
+	}
+	return curriculum, nil
+}
+
+func (r *curriculumRepository) GetByID(ctx context.Context, id string) (*model.Curriculum, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, errors.New("invalid ID format")
+	}
+
+	var curriculum model.Curriculum
+	err = r.collection.FindOne(ctx, bson.M{"_id": objectID}).Decode(&curriculum)
+	if err != nil {
+		return nil, err
+	}
+	return &curriculum, nil
+}
+
+func (r *curriculumRepository) GetAll(ctx context.Context) ([]*model.Curriculum, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}})
+	return r.find(ctx, bson.M{}, opts)
+}
+
+func (r *curriculumRepository) Update(ctx context.Context, id string, updated *model.Curriculum) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return errors.New("invalid ID format")
+	}
+
+	updated.UpdatedAt = time.Now()
+
+	update := bson.M{
+		"$set": bson.M{
+			"title":       updated.Title,
+			"description": updated.Description,
+			"entries":     updated.Entries,
+			"updated_at":  updated.UpdatedAt,
+		},
+	}
+
+	return r.updateByID(ctx, objectID, update)
+}
+
+func (r *curriculumRepository) UpdateEntries(ctx context.Context, id string, entries []model.CurriculumEntry) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return errors.New("invalid ID format")
+	}
+
+	update := bson.M{
+		"$set": bson.M{
+			"entries":    entries,
+			"updated_at": time.Now(),
+		},
+	}
+
+	return r.updateByID(ctx, objectID, update)
+}
+
+func (r *curriculumRepository) Publish(ctx context.Context, id string, publishedAt time.Time) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return errors.New("invalid ID format")
+	}
+
+	update := bson.M{
+		"$set": bson.M{
+			"status":       model.CurriculumStatusPublished,
+			"published_at": publishedAt,
+			"updated_at":   publishedAt,
+		},
+	}
+
+	return r.updateByID(ctx, objectID, update)
+}
+
+func (r *curriculumRepository) Delete(ctx context.Context, id string) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return errors.New("invalid ID format")
+	}
+
+	result, err := r.collection.DeleteOne(ctx, bson.M{"_id": objectID})
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+// FindByTopicID trả về các curriculum có chứa topic
+func (r *curriculumRepository) FindByTopicID(ctx context.Context, topicID string) ([]*model.Curriculum, error) {
+	return r.find(ctx, bson.M{"entries.topic_id": topicID})
+}
+
+func (r *curriculumRepository) updateByID(ctx context.Context, objectID primitive.ObjectID, update bson.M) error {
+	result, err := r.collection.UpdateByID(ctx, objectID, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+func (r *curriculumRepository) find(ctx context.Context, filter bson.M, opts ...*options.FindOptions) ([]*model.Curriculum, error) {
+	cursor, err := r.collection.Find(ctx, filter, opts...)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var curricula []*model.Curriculum
+	for cursor.Next(ctx) {
+		var curriculum model.Curriculum
+		if err := cursor.Decode(&curriculum); err != nil {
+			return nil, err
+		}
+		curricula = append(curricula, &curriculum)
+	}
+	return curricula, nil
+}
@@ -0,0 +1,362 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"log"
+	"slices"
+	"time"
+	"topic-service/internal/topic/model"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const (
+	DefaultProjectionWait = 5 * time.Second
+
+	projectionPollInterval = 20 * time.Millisecond
+	// maxAppendAttempts là số lần đọc lại aggregate khi stream bị ghi đồng thời
+	maxAppendAttempts = 3
+)
+
+// EventSourcedTopicOptions: ProjectionWait là thời gian tối đa chờ projection ghi xong thay đổi vào MongoDB
+// trước khi lệnh ghi trả về, để request đọc ngay sau đó thấy được thay đổi
+type EventSourcedTopicOptions struct {
+	ProjectionWait time.Duration
+}
+
+// eventSourcedTopicRepository ghi thay đổi topic thành event trên stream của topic thay vì sửa MongoDB trực tiếp.
+// Các thao tác đọc vẫn đi qua repository gốc, dữ liệu ở đó do projection dựng lại từ event.
+type eventSourcedTopicRepository struct {
+	TopicRepository
+	store      TopicEventStore
+	projection TopicProjectionRepository
+	opts       EventSourcedTopicOptions
+}
+
+func NewEventSourcedTopicRepository(repo TopicRepository, store TopicEventStore, projection TopicProjectionRepository, opts EventSourcedTopicOptions) TopicRepository {
+	if opts.ProjectionWait <= 0 {
+		opts.ProjectionWait = DefaultProjectionWait
+	}
+	return &eventSourcedTopicRepository{TopicRepository: repo, store: store, projection: projection, opts: opts}
+}
+
+func (r *eventSourcedTopicRepository) Create(ctx context.Context, topic *model.Topic) (*model.Topic, error) {
+	if topic.ID.IsZero() {
+		topic.ID = primitive.NewObjectID()
+	}
+
+	// Lần chạy lại của transaction dùng lại topic đã tạo ở lần trước thay vì tạo thêm một stream
+	cmd, replayed, err := appendOnce(ctx, "", func() (appendedCommand, error) {
+		id := topic.ID.Hex()
+		version, err := r.store.Append(ctx, id, -1, model.TopicStreamEvent{
+			Type: model.EventTopicCreated,
+			Data: model.TopicCreatedData{Topic: *topic, SearchTerms: topic.SearchTerms, RatingSum: topic.RatingSum},
+		})
+		return appendedCommand{StreamID: id, Version: version}, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if replayed {
+		topic.ID, _ = primitive.ObjectIDFromHex(cmd.StreamID)
+		return topic, nil
+	}
+	r.waitProjected(ctx, cmd.StreamID, cmd.Version, false)
+	return topic, nil
+}
+
+func (r *eventSourcedTopicRepository) Update(ctx context.Context, id string, updated *model.Topic) error {
+	updated.UpdatedAt = time.Now()
+	return r.execute(ctx, id, func(*model.Topic) []model.TopicStreamEvent {
+		return []model.TopicStreamEvent{{Type: model.EventTopicDetailsChanged, Data: detailsOf(updated)}}
+	})
+}
+
+func (r *eventSourcedTopicRepository) Delete(ctx context.Context, id string) error {
+	return r.execute(ctx, id, func(*model.Topic) []model.TopicStreamEvent {
+		return []model.TopicStreamEvent{{Type: model.EventTopicDeleted, Data: struct{}{}}}
+	})
+}
+
+func (r *eventSourcedTopicRepository) IncrementItemCount(ctx context.Context, id string, delta int) error {
+	return r.execute(ctx, id, func(*model.Topic) []model.TopicStreamEvent {
+		return []model.TopicStreamEvent{{Type: model.EventTopicItemCountChanged, Data: model.TopicCountChangedData{Delta: delta}}}
+	})
+}
+
+func (r *eventSourcedTopicRepository) IncrementCommentCount(ctx context.Context, id string, delta int) error {
+	return r.execute(ctx, id, func(*model.Topic) []model.TopicStreamEvent {
+		return []model.TopicStreamEvent{{Type: model.EventTopicCommentCountChanged, Data: model.TopicCountChangedData{Delta: delta}}}
+	})
+}
+
+func (r *eventSourcedTopicRepository) ApplyRatingDelta(ctx context.Context, id string, sumDelta, countDelta int) error {
+	return r.execute(ctx, id, func(*model.Topic) []model.TopicStreamEvent {
+		return []model.TopicStreamEvent{{
+			Type: model.EventTopicRatingChanged,
+			Data: model.TopicRatingChangedData{SumDelta: sumDelta, CountDelta: countDelta},
+		}}
+	})
+}
+
+func (r *eventSourcedTopicRepository) AddPrerequisite(ctx context.Context, id string, prerequisiteID string) error {
+	now := time.Now()
+	return r.execute(ctx, id, func(*model.Topic) []model.TopicStreamEvent {
+		return []model.TopicStreamEvent{{
+			Type: model.EventTopicPrerequisiteAdded,
+			Data: model.TopicPrerequisiteChangedData{PrerequisiteID: prerequisiteID, UpdatedAt: &now},
+		}}
+	})
+}
+
+func (r *eventSourcedTopicRepository) RemovePrerequisite(ctx context.Context, id string, prerequisiteID string) error {
+	now := time.Now()
+	return r.execute(ctx, id, func(*model.Topic) []model.TopicStreamEvent {
+		return []model.TopicStreamEvent{{
+			Type: model.EventTopicPrerequisiteRemoved,
+			Data: model.TopicPrerequisiteChangedData{PrerequisiteID: prerequisiteID, UpdatedAt: &now},
+		}}
+	})
+}
+
+// RemovePrerequisiteFromAll ghi event gỡ cạnh lên stream của từng topic đang trỏ tới prerequisiteID
+func (r *eventSourcedTopicRepository) RemovePrerequisiteFromAll(ctx context.Context, prerequisiteID string) error {
+	return r.forEachDependent(ctx, prerequisiteID, func(topic *model.Topic) []model.TopicStreamEvent {
+		return []model.TopicStreamEvent{{
+			Type: model.EventTopicPrerequisiteRemoved,
+			Data: model.TopicPrerequisiteChangedData{PrerequisiteID: prerequisiteID},
+		}}
+	})
+}
+
+func (r *eventSourcedTopicRepository) SetTemplate(ctx context.Context, id string, isTemplate bool) error {
+	return r.execute(ctx, id, func(*model.Topic) []model.TopicStreamEvent {
+		return []model.TopicStreamEvent{{
+			Type: model.EventTopicTemplateChanged,
+			Data: model.TopicTemplateChangedData{IsTemplate: isTemplate, UpdatedAt: time.Now()},
+		}}
+	})
+}
+
+// ReplacePrerequisite thay mọi cạnh trỏ tới oldID bằng newID, topic newID không trở thành prerequisite của chính nó
+func (r *eventSourcedTopicRepository) ReplacePrerequisite(ctx context.Context, oldID, newID string) error {
+	return r.forEachDependent(ctx, oldID, func(topic *model.Topic) []model.TopicStreamEvent {
+		var events []model.TopicStreamEvent
+		if topic.ID.Hex() != newID && !slices.Contains(topic.PrerequisiteIDs, newID) {
+			events = append(events, model.TopicStreamEvent{
+				Type: model.EventTopicPrerequisiteAdded,
+				Data: model.TopicPrerequisiteChangedData{PrerequisiteID: newID},
+			})
+		}
+		return append(events, model.TopicStreamEvent{
+			Type: model.EventTopicPrerequisiteRemoved,
+			Data: model.TopicPrerequisiteChangedData{PrerequisiteID: oldID},
+		})
+	})
+}
+
+func (r *eventSourcedTopicRepository) ApplyMerge(ctx context.Context, id string, merged *model.Topic) error {
+	merged.UpdatedAt = time.Now()
+	return r.execute(ctx, id, func(*model.Topic) []model.TopicStreamEvent {
+		return []model.TopicStreamEvent{{
+			Type: model.EventTopicMerged,
+			Data: model.TopicMergedData{
+				TopicDetailsChangedData: detailsOf(merged),
+				Icon:                    merged.Icon,
+				IsTemplate:              merged.IsTemplate,
+				PrerequisiteIDs:         merged.PrerequisiteIDs,
+				ItemCount:               merged.ItemCount,
+				CommentCount:            merged.CommentCount,
+				RatingSum:               merged.RatingSum,
+				RatingCount:             merged.RatingCount,
+				RatingAverage:           merged.RatingAverage,
+			},
+		}}
+	})
+}
+
+func (r *eventSourcedTopicRepository) SetOwner(ctx context.Context, id string, ownerID string) error {
+	return r.execute(ctx, id, func(*model.Topic) []model.TopicStreamEvent {
+		return []model.TopicStreamEvent{{
+			Type: model.EventTopicOwnerChanged,
+			Data: model.TopicOwnerChangedData{OwnerID: ownerID, UpdatedAt: time.Now()},
+		}}
+	})
+}
+
+// execute dựng aggregate từ stream, tạo event từ trạng thái hiện tại rồi ghi kèm kiểm tra version.
+// Stream bị ghi đồng thời thì đọc lại và tạo lại event.
+func (r *eventSourcedTopicRepository) execute(ctx context.Context, id string, decide func(topic *model.Topic) []model.TopicStreamEvent) error {
+	if _, err := primitive.ObjectIDFromHex(id); err != nil {
+		return errors.New("invalid ID format")
+	}
+
+	deleted := false
+	cmd, replayed, err := appendOnce(ctx, id, func() (appendedCommand, error) {
+		for attempt := 1; ; attempt++ {
+			aggregate, err := r.load(ctx, id)
+			if err != nil {
+				return appendedCommand{}, err
+			}
+			if !aggregate.Exists() {
+				return appendedCommand{}, mongo.ErrNoDocuments
+			}
+
+			events := decide(&aggregate.Topic)
+			if len(events) == 0 {
+				return appendedCommand{StreamID: id, Version: -1}, nil
+			}
+
+			version, err := r.store.Append(ctx, id, aggregate.Version, events...)
+			if errors.Is(err, ErrStreamConflict) && attempt < maxAppendAttempts {
+				continue
+			}
+			if err != nil {
+				return appendedCommand{}, err
+			}
+			deleted = events[len(events)-1].Type == model.EventTopicDeleted
+			return appendedCommand{StreamID: id, Version: version}, nil
+		}
+	})
+	if err != nil || replayed || cmd.Version < 0 {
+		return err
+	}
+	r.waitProjected(ctx, id, cmd.Version, deleted)
+	return nil
+}
+
+// appendedCommand là kết quả một lệnh append, Version -1 khi lệnh không tạo event nào
+type appendedCommand struct {
+	StreamID string
+	Version  int64
+}
+
+// appendOnce chạy fn đúng một lần cho mỗi lệnh ghi trong transaction. EventStoreDB không nằm trong transaction
+// MongoDB, nên khi WithTransaction chạy lại fn, lệnh thứ n của lần chạy lại nhận kết quả đã append ở lần trước
+// thay vì append thêm (bộ đếm bị cộng hai lần). Lệnh thứ n ghi vào stream khác với lần trước thì vẫn được chạy,
+// streamID rỗng khớp với mọi stream. Ngoài transaction fn luôn được chạy.
+func appendOnce(ctx context.Context, streamID string, fn func() (appendedCommand, error)) (appendedCommand, bool, error) {
+	state := transactionState(ctx)
+	if state == nil {
+		cmd, err := fn()
+		return cmd, false, err
+	}
+
+	state.mu.Lock()
+	seq := state.commands
+	state.commands++
+	cmd, done := state.appended[seq]
+	state.mu.Unlock()
+	if done && (streamID == "" || cmd.StreamID == streamID) {
+		return cmd, true, nil
+	}
+
+	cmd, err := fn()
+	if err != nil {
+		return cmd, false, err
+	}
+	state.mu.Lock()
+	if state.appended == nil {
+		state.appended = make(map[int]appendedCommand)
+	}
+	state.appended[seq] = cmd
+	state.mu.Unlock()
+	return cmd, false, nil
+}
+
+// EmitsOutboxEvents: append lên EventStoreDB không rollback được cùng transaction MongoDB, nên outbox event
+// của topic do projection tạo từ stream thay vì do service ghi trong transaction
+func (r *eventSourcedTopicRepository) EmitsOutboxEvents() bool {
+	return true
+}
+
+// load đọc aggregate từ stream. Topic có sẵn trong MongoDB nhưng chưa có stream (tạo trước khi bật
+// event sourcing) được nhập vào stream bằng TopicCreated trước khi ghi thay đổi đầu tiên.
+func (r *eventSourcedTopicRepository) load(ctx context.Context, id string) (*model.TopicAggregate, error) {
+	aggregate, err := r.store.Load(ctx, id)
+	if err != nil || aggregate.Version >= 0 {
+		return aggregate, err
+	}
+
+	topic, err := r.TopicRepository.GetByID(ctx, id)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return aggregate, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = r.store.Append(ctx, id, -1, model.TopicStreamEvent{
+		Type: model.EventTopicCreated,
+		Data: model.TopicCreatedData{Topic: *topic, SearchTerms: topic.SearchTerms, RatingSum: topic.RatingSum},
+	})
+	if err != nil && !errors.Is(err, ErrStreamConflict) {
+		return nil, err
+	}
+	log.Printf("event sourcing: imported topic %s into its stream", id)
+	return r.store.Load(ctx, id)
+}
+
+// forEachDependent ghi event lên mọi topic có prerequisiteID trong danh sách prerequisite
+func (r *eventSourcedTopicRepository) forEachDependent(ctx context.Context, prerequisiteID string, decide func(topic *model.Topic) []model.TopicStreamEvent) error {
+	topics, err := r.TopicRepository.GetAll(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, topic := range topics {
+		if !slices.Contains(topic.PrerequisiteIDs, prerequisiteID) {
+			continue
+		}
+		err := r.execute(ctx, topic.ID.Hex(), func(current *model.Topic) []model.TopicStreamEvent {
+			// Danh sách đọc từ projection có thể đã cũ, quyết định lại theo trạng thái trong stream
+			if !slices.Contains(current.PrerequisiteIDs, prerequisiteID) {
+				return nil
+			}
+			return decide(current)
+		})
+		// Topic bị xoá trong lúc đang xử lý thì không còn cạnh nào để sửa
+		if err != nil && !errors.Is(err, mongo.ErrNoDocuments) {
+			return err
+		}
+	}
+	return nil
+}
+
+// waitProjected chờ projection ghi version vào MongoDB. Hết thời gian chờ thì vẫn coi là thành công
+// vì event đã được lưu, projection sẽ bắt kịp sau.
+func (r *eventSourcedTopicRepository) waitProjected(ctx context.Context, id string, version int64, deleted bool) {
+	deadline := time.Now().Add(r.opts.ProjectionWait)
+	for {
+		// Không đọc bằng ctx đang trong transaction: snapshot của transaction không thấy document projection vừa ghi
+		pollCtx, cancel := context.WithDeadline(context.Background(), deadline)
+		current, found, err := r.projection.Version(pollCtx, id)
+		cancel()
+		if err == nil && (deleted && !found || !deleted && found && current >= version) {
+			return
+		}
+
+		if time.Now().After(deadline) {
+			log.Printf("event sourcing: projection of topic %s has not reached version %d after %s", id, version, r.opts.ProjectionWait)
+			return
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(projectionPollInterval):
+		}
+	}
+}
+
+func detailsOf(topic *model.Topic) model.TopicDetailsChangedData {
+	return model.TopicDetailsChangedData{
+		Title:         topic.Title,
+		Titles:        topic.Titles,
+		Descriptions:  topic.Descriptions,
+		DefaultLocale: topic.DefaultLocale,
+		SearchTerms:   topic.SearchTerms,
+		UpdatedAt:     topic.UpdatedAt,
+	}
+}
@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// LeaseRepository cấp quyền chạy độc quyền có thời hạn theo tên, dùng chung giữa các instance.
+// Lease hết hạn tự được nhường lại nên instance chết giữa chừng không giữ lease mãi.
+type LeaseRepository interface {
+	// Acquire giữ lease name cho owner trong ttl, gọi lại với cùng owner để gia hạn
+	Acquire(ctx context.Context, name, owner string, ttl time.Duration) (bool, error)
+	// Release trả lease nếu owner còn đang giữ
+	Release(ctx context.Context, name, owner string) error
+}
+
+type leaseRepository struct {
+	collection *mongo.Collection
+}
+
+func NewLeaseRepository(collection *mongo.Collection) LeaseRepository {
+	return &leaseRepository{collection: collection}
+}
+
+func (r *leaseRepository) Acquire(ctx context.Context, name, owner string, ttl time.Duration) (bool, error) {
+	now := time.Now()
+	filter := bson.M{
+		"_id": name,
+		"$or": bson.A{
+			bson.M{"expires_at": bson.M{"$lt": now}},
+			bson.M{"owner": owner},
+		},
+	}
+	update := bson.M{"$set": bson.M{"owner": owner, "expires_at": now.Add(ttl)}}
+
+	_, err := r.collection.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true))
+	if mongo.IsDuplicateKeyError(err) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func (r *leaseRepository) Release(ctx context.Context, name, owner string) error {
+	_, err := r.collection.DeleteOne(ctx, bson.M{"_id": name, "owner": owner})
+	return err
+}
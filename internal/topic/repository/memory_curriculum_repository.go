@@ -0,0 +1,139 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+	"topic-service/internal/topic/model"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type memoryCurriculumRepository struct {
+	mu        sync.RWMutex
+	curricula map[string]*model.Curriculum
+}
+
+func NewMemoryCurriculumRepository() CurriculumRepository {
+	return &memoryCurriculumRepository{curricula: make(map[string]*model.Curriculum)}
+}
+
+func (r *memoryCurriculumRepository) Create(ctx context.Context, curriculum *model.Curriculum) (*model.Curriculum, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if curriculum.ID.IsZero() {
+		curriculum.ID = primitive.NewObjectID()
+	}
+	r.curricula[curriculum.ID.Hex()] = copyDocument(curriculum)
+	return curriculum, nil
+}
+
+func (r *memoryCurriculumRepository) GetByID(ctx context.Context, id string) (*model.Curriculum, error) {
+	if err := validObjectID(id); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	curriculum, ok := r.curricula[id]
+	if !ok {
+		return nil, mongo.ErrNoDocuments
+	}
+	return copyDocument(curriculum), nil
+}
+
+func (r *memoryCurriculumRepository) GetAll(ctx context.Context) ([]*model.Curriculum, error) {
+	curricula := r.find(func(*model.Curriculum) bool { return true })
+	sort.SliceStable(curricula, func(i, j int) bool {
+		return curricula[i].CreatedAt.After(curricula[j].CreatedAt)
+	})
+	return curricula, nil
+}
+
+func (r *memoryCurriculumRepository) Update(ctx context.Context, id string, updated *model.Curriculum) error {
+	updated.UpdatedAt = time.Now()
+	return r.modify(id, func(c *model.Curriculum) {
+		c.Title = updated.Title
+		c.Description = updated.Description
+		c.Entries = updated.Entries
+		c.UpdatedAt = updated.UpdatedAt
+	})
+}
+
+func (r *memoryCurriculumRepository) UpdateEntries(ctx context.Context, id string, entries []model.CurriculumEntry) error {
+	return r.modify(id, func(c *model.Curriculum) {
+		c.Entries = entries
+		c.UpdatedAt = time.Now()
+	})
+}
+
+func (r *memoryCurriculumRepository) Publish(ctx context.Context, id string, publishedAt time.Time) error {
+	return r.modify(id, func(c *model.Curriculum) {
+		c.Status = model.CurriculumStatusPublished
+		c.PublishedAt = &publishedAt
+		c.UpdatedAt = publishedAt
+	})
+}
+
+func (r *memoryCurriculumRepository) Delete(ctx context.Context, id string) error {
+	if err := validObjectID(id); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.curricula[id]; !ok {
+		return mongo.ErrNoDocuments
+	}
+	delete(r.curricula, id)
+	return nil
+}
+
+func (r *memoryCurriculumRepository) FindByTopicID(ctx context.Context, topicID string) ([]*model.Curriculum, error) {
+	return r.find(func(c *model.Curriculum) bool {
+		for _, entry := range c.Entries {
+			if entry.TopicID == topicID {
+				return true
+			}
+		}
+		return false
+	}), nil
+}
+
+func (r *memoryCurriculumRepository) modify(id string, fn func(c *model.Curriculum)) error {
+	if err := validObjectID(id); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	curriculum, ok := r.curricula[id]
+	if !ok {
+		return mongo.ErrNoDocuments
+	}
+	fn(curriculum)
+	r.curricula[id] = copyDocument(curriculum)
+	return nil
+}
+
+func (r *memoryCurriculumRepository) find(match func(c *model.Curriculum) bool) []*model.Curriculum {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var curricula []*model.Curriculum
+	for _, curriculum := range r.curricula {
+		if match(curriculum) {
+			curricula = append(curricula, copyDocument(curriculum))
+		}
+	}
+	sort.Slice(curricula, func(i, j int) bool {
+		return curricula[i].ID.Hex() < curricula[j].ID.Hex()
+	})
+	return curricula
+}
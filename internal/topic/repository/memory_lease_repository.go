@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+type memoryLease struct {
+	owner     string
+	expiresAt time.Time
+}
+
+type memoryLeaseRepository struct {
+	mu     sync.Mutex
+	leases map[string]memoryLease
+}
+
+func NewMemoryLeaseRepository() LeaseRepository {
+	return &memoryLeaseRepository{leases: make(map[string]memoryLease)}
+}
+
+func (r *memoryLeaseRepository) Acquire(ctx context.Context, name, owner string, ttl time.Duration) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	if lease, ok := r.leases[name]; ok && lease.owner != owner && !lease.expiresAt.Before(now) {
+		return false, nil
+	}
+	r.leases[name] = memoryLease{owner: owner, expiresAt: now.Add(ttl)}
+	return true, nil
+}
+
+func (r *memoryLeaseRepository) Release(ctx context.Context, name, owner string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if lease, ok := r.leases[name]; ok && lease.owner == owner {
+		delete(r.leases, name)
+	}
+	return nil
+}
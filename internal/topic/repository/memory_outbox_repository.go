@@ -0,0 +1,142 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+	"topic-service/internal/topic/model"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type memoryOutboxRepository struct {
+	mu     sync.RWMutex
+	events map[primitive.ObjectID]*model.OutboxEvent
+}
+
+func NewMemoryOutboxRepository() OutboxRepository {
+	return &memoryOutboxRepository{events: make(map[primitive.ObjectID]*model.OutboxEvent)}
+}
+
+func (r *memoryOutboxRepository) Create(ctx context.Context, event *model.OutboxEvent) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if event.ID.IsZero() {
+		event.ID = primitive.NewObjectID()
+	}
+	copied := *event
+	// MongoDB chỉ lưu thời gian tới millisecond, giữ giống vậy để ListSince so sánh vị trí như bản MongoDB
+	copied.CreatedAt = copied.CreatedAt.Truncate(time.Millisecond)
+	r.events[event.ID] = &copied
+	return nil
+}
+
+func (r *memoryOutboxRepository) GetByID(ctx context.Context, id primitive.ObjectID) (*model.OutboxEvent, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	event, ok := r.events[id]
+	if !ok {
+		return nil, mongo.ErrNoDocuments
+	}
+	copied := *event
+	return &copied, nil
+}
+
+func (r *memoryOutboxRepository) ListPending(ctx context.Context, now time.Time, limit int) ([]*model.OutboxEvent, error) {
+	r.mu.RLock()
+	waiting := make(map[string]bool)
+	for _, event := range r.events {
+		if event.Status == model.OutboxStatusPending && event.NextAttemptAt.After(now) {
+			waiting[event.AggregateID] = true
+		}
+	}
+	var events []*model.OutboxEvent
+	for _, event := range r.events {
+		if event.Status == model.OutboxStatusPending && !waiting[event.AggregateID] {
+			copied := *event
+			events = append(events, &copied)
+		}
+	}
+	r.mu.RUnlock()
+
+	sort.Slice(events, func(i, j int) bool {
+		if !events[i].CreatedAt.Equal(events[j].CreatedAt) {
+			return events[i].CreatedAt.Before(events[j].CreatedAt)
+		}
+		return events[i].ID.Hex() < events[j].ID.Hex()
+	})
+	if limit > 0 && len(events) > limit {
+		events = events[:limit]
+	}
+	return events, nil
+}
+
+func (r *memoryOutboxRepository) ListSince(ctx context.Context, afterCreatedAt time.Time, afterID primitive.ObjectID, until time.Time, limit int) ([]*model.OutboxEvent, error) {
+	r.mu.RLock()
+	events := []*model.OutboxEvent{}
+	for _, event := range r.events {
+		after := event.CreatedAt.After(afterCreatedAt) && !event.CreatedAt.After(until)
+		sameTime := event.CreatedAt.Equal(afterCreatedAt) && event.ID.Hex() > afterID.Hex()
+		if after || sameTime {
+			copied := *event
+			events = append(events, &copied)
+		}
+	}
+	r.mu.RUnlock()
+
+	sort.Slice(events, func(i, j int) bool {
+		if !events[i].CreatedAt.Equal(events[j].CreatedAt) {
+			return events[i].CreatedAt.Before(events[j].CreatedAt)
+		}
+		return events[i].ID.Hex() < events[j].ID.Hex()
+	})
+	if limit > 0 && len(events) > limit {
+		events = events[:limit]
+	}
+	return events, nil
+}
+
+func (r *memoryOutboxRepository) MarkPublished(ctx context.Context, id primitive.ObjectID, publishedAt time.Time) error {
+	return r.modify(id, func(e *model.OutboxEvent) {
+		e.Status = model.OutboxStatusPublished
+		e.PublishedAt = &publishedAt
+		e.Attempts = 0
+	})
+}
+
+func (r *memoryOutboxRepository) MarkRetry(ctx context.Context, id primitive.ObjectID, attempts int, lastError string, nextAttemptAt time.Time) error {
+	return r.modify(id, func(e *model.OutboxEvent) {
+		e.Attempts = attempts
+		e.LastError = lastError
+		e.NextAttemptAt = nextAttemptAt
+	})
+}
+
+func (r *memoryOutboxRepository) MarkDead(ctx context.Context, id primitive.ObjectID, attempts int, lastError string) error {
+	return r.modify(id, func(e *model.OutboxEvent) {
+		e.Status = model.OutboxStatusDead
+		e.Attempts = attempts
+		e.LastError = lastError
+	})
+}
+
+// AcquireLease luôn thành công vì dữ liệu trong bộ nhớ chỉ có một instance
+func (r *memoryOutboxRepository) AcquireLease(ctx context.Context, owner string, ttl time.Duration) (bool, error) {
+	return true, nil
+}
+
+func (r *memoryOutboxRepository) modify(id primitive.ObjectID, fn func(e *model.OutboxEvent)) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	event, ok := r.events[id]
+	if !ok {
+		return mongo.ErrNoDocuments
+	}
+	fn(event)
+	return nil
+}
@@ -0,0 +1,29 @@
+package repository
+
+import (
+	"context"
+	"sync"
+)
+
+type memoryProcessedEventRepository struct {
+	mu        sync.RWMutex
+	processed map[string]struct{}
+}
+
+func NewMemoryProcessedEventRepository() ProcessedEventRepository {
+	return &memoryProcessedEventRepository{processed: make(map[string]struct{})}
+}
+
+func (r *memoryProcessedEventRepository) IsProcessed(ctx context.Context, consumer, eventID string) (bool, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	_, ok := r.processed[processedEventKey(consumer, eventID)]
+	return ok, nil
+}
+
+func (r *memoryProcessedEventRepository) MarkProcessed(ctx context.Context, consumer, eventID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.processed[processedEventKey(consumer, eventID)] = struct{}{}
+	return nil
+}
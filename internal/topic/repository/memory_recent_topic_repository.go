@@ -0,0 +1,48 @@
+package repository
+
+import (
+	"context"
+	"sync"
+)
+
+type memoryRecentTopicRepository struct {
+	mu     sync.RWMutex
+	recent map[string][]string
+}
+
+func NewMemoryRecentTopicRepository() RecentTopicRepository {
+	return &memoryRecentTopicRepository{recent: make(map[string][]string)}
+}
+
+func (r *memoryRecentTopicRepository) Push(ctx context.Context, userID string, topicIDs []string, limit int) error {
+	if len(topicIDs) == 0 {
+		return nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	pushed := make(map[string]bool, len(topicIDs))
+	for _, id := range topicIDs {
+		pushed[id] = true
+	}
+
+	merged := append([]string(nil), topicIDs...)
+	for _, id := range r.recent[userID] {
+		if !pushed[id] {
+			merged = append(merged, id)
+		}
+	}
+	if limit > 0 && len(merged) > limit {
+		merged = merged[:limit]
+	}
+	r.recent[userID] = merged
+	return nil
+}
+
+func (r *memoryRecentTopicRepository) Get(ctx context.Context, userID string) ([]string, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return append([]string(nil), r.recent[userID]...), nil
+}
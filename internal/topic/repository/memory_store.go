@@ -0,0 +1,29 @@
+package repository
+
+import (
+	"errors"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// copyDocument sao chép sâu một document qua bson để bản lưu trong bộ nhớ
+// không bị thay đổi khi caller sửa struct trả về (và ngược lại)
+func copyDocument[T any](src *T) *T {
+	data, err := bson.Marshal(src)
+	if err != nil {
+		panic(err)
+	}
+	var dst T
+	if err := bson.Unmarshal(data, &dst); err != nil {
+		panic(err)
+	}
+	return &dst
+}
+
+func validObjectID(id string) error {
+	if _, err := primitive.ObjectIDFromHex(id); err != nil {
+		return errors.New("invalid ID format")
+	}
+	return nil
+}
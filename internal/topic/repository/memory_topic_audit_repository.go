@@ -0,0 +1,46 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"topic-service/internal/topic/model"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type memoryTopicAuditRepository struct {
+	mu   sync.RWMutex
+	logs []*model.TopicAuditLog
+}
+
+func NewMemoryTopicAuditRepository() TopicAuditRepository {
+	return &memoryTopicAuditRepository{}
+}
+
+func (r *memoryTopicAuditRepository) Create(ctx context.Context, log *model.TopicAuditLog) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if log.ID.IsZero() {
+		log.ID = primitive.NewObjectID()
+	}
+	r.logs = append(r.logs, copyDocument(log))
+	return nil
+}
+
+func (r *memoryTopicAuditRepository) ListByTopic(ctx context.Context, topicID string) ([]*model.TopicAuditLog, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var logs []*model.TopicAuditLog
+	for _, log := range r.logs {
+		if log.TopicID == topicID || log.Details["merged_id"] == topicID {
+			logs = append(logs, copyDocument(log))
+		}
+	}
+	sort.SliceStable(logs, func(i, j int) bool {
+		return logs[i].CreatedAt.After(logs[j].CreatedAt)
+	})
+	return logs, nil
+}
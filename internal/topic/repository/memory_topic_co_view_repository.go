@@ -0,0 +1,42 @@
+package repository
+
+import (
+	"context"
+	"sync"
+	"topic-service/internal/topic/model"
+)
+
+type coViewKey struct {
+	topicA string
+	topicB string
+}
+
+type memoryTopicCoViewRepository struct {
+	mu     sync.RWMutex
+	counts map[coViewKey]int64
+}
+
+func NewMemoryTopicCoViewRepository() TopicCoViewRepository {
+	return &memoryTopicCoViewRepository{counts: make(map[coViewKey]int64)}
+}
+
+func (r *memoryTopicCoViewRepository) IncrementPairs(ctx context.Context, pairs []model.TopicCoView) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, pair := range pairs {
+		r.counts[coViewKey{pair.TopicA, pair.TopicB}] += pair.Count
+	}
+	return nil
+}
+
+func (r *memoryTopicCoViewRepository) GetAll(ctx context.Context) ([]model.TopicCoView, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	pairs := make([]model.TopicCoView, 0, len(r.counts))
+	for key, count := range r.counts {
+		pairs = append(pairs, model.TopicCoView{TopicA: key.topicA, TopicB: key.topicB, Count: count})
+	}
+	return pairs, nil
+}
@@ -0,0 +1,183 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+	"topic-service/internal/topic/model"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type memoryTopicCommentRepository struct {
+	mu       sync.RWMutex
+	comments map[primitive.ObjectID]*model.TopicComment
+}
+
+func NewMemoryTopicCommentRepository() TopicCommentRepository {
+	return &memoryTopicCommentRepository{comments: make(map[primitive.ObjectID]*model.TopicComment)}
+}
+
+func (r *memoryTopicCommentRepository) Create(ctx context.Context, comment *model.TopicComment) (*model.TopicComment, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if comment.ID.IsZero() {
+		comment.ID = primitive.NewObjectID()
+	}
+	r.comments[comment.ID] = copyDocument(comment)
+	return comment, nil
+}
+
+func (r *memoryTopicCommentRepository) GetByID(ctx context.Context, topicID, commentID string) (*model.TopicComment, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	comment, err := r.get(topicID, commentID)
+	if err != nil {
+		return nil, err
+	}
+	return copyDocument(comment), nil
+}
+
+func (r *memoryTopicCommentRepository) ListRoots(ctx context.Context, topicID string, skip, limit int64) ([]*model.TopicComment, error) {
+	roots := r.find(func(c *model.TopicComment) bool {
+		return c.TopicID == topicID && c.ParentID == ""
+	})
+	sort.Slice(roots, func(i, j int) bool {
+		if !roots[i].CreatedAt.Equal(roots[j].CreatedAt) {
+			return roots[i].CreatedAt.After(roots[j].CreatedAt)
+		}
+		return roots[i].ID.Hex() > roots[j].ID.Hex()
+	})
+	return paginate(roots, skip, limit), nil
+}
+
+func (r *memoryTopicCommentRepository) CountRoots(ctx context.Context, topicID string) (int64, error) {
+	roots := r.find(func(c *model.TopicComment) bool {
+		return c.TopicID == topicID && c.ParentID == ""
+	})
+	return int64(len(roots)), nil
+}
+
+func (r *memoryTopicCommentRepository) ListReplies(ctx context.Context, topicID string, rootIDs []string) ([]*model.TopicComment, error) {
+	if len(rootIDs) == 0 {
+		return nil, nil
+	}
+
+	replies := r.find(func(c *model.TopicComment) bool {
+		return c.TopicID == topicID && c.ParentID != "" && containsString(rootIDs, c.RootID)
+	})
+	sort.Slice(replies, func(i, j int) bool {
+		if !replies[i].CreatedAt.Equal(replies[j].CreatedAt) {
+			return replies[i].CreatedAt.Before(replies[j].CreatedAt)
+		}
+		return replies[i].ID.Hex() < replies[j].ID.Hex()
+	})
+	return replies, nil
+}
+
+func (r *memoryTopicCommentRepository) UpdateContent(ctx context.Context, topicID, commentID, content string, mentions []model.CommentMention) error {
+	return r.modify(topicID, commentID, func(c *model.TopicComment) {
+		now := time.Now()
+		c.Content = content
+		c.Mentions = mentions
+		c.EditedAt = &now
+		c.UpdatedAt = now
+	})
+}
+
+func (r *memoryTopicCommentRepository) SoftDelete(ctx context.Context, topicID, commentID string) error {
+	return r.modify(topicID, commentID, func(c *model.TopicComment) {
+		c.Deleted = true
+		c.Content = ""
+		c.Mentions = []model.CommentMention{}
+		c.UpdatedAt = time.Now()
+	})
+}
+
+func (r *memoryTopicCommentRepository) SetHidden(ctx context.Context, topicID, commentID string, hidden bool, moderatorID string) error {
+	return r.modify(topicID, commentID, func(c *model.TopicComment) {
+		c.Hidden = hidden
+		c.HiddenBy = moderatorID
+		c.UpdatedAt = time.Now()
+	})
+}
+
+func (r *memoryTopicCommentRepository) DeleteByTopic(ctx context.Context, topicID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for id, comment := range r.comments {
+		if comment.TopicID == topicID {
+			delete(r.comments, id)
+		}
+	}
+	return nil
+}
+
+func (r *memoryTopicCommentRepository) MoveToTopic(ctx context.Context, fromTopicID, toTopicID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, comment := range r.comments {
+		if comment.TopicID == fromTopicID {
+			comment.TopicID = toTopicID
+		}
+	}
+	return nil
+}
+
+// get tìm bình luận thuộc topic, caller phải giữ lock
+func (r *memoryTopicCommentRepository) get(topicID, commentID string) (*model.TopicComment, error) {
+	objectID, err := primitive.ObjectIDFromHex(commentID)
+	if err != nil {
+		return nil, validObjectID(commentID)
+	}
+
+	comment, ok := r.comments[objectID]
+	if !ok || comment.TopicID != topicID {
+		return nil, mongo.ErrNoDocuments
+	}
+	return comment, nil
+}
+
+func (r *memoryTopicCommentRepository) modify(topicID, commentID string, fn func(c *model.TopicComment)) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	comment, err := r.get(topicID, commentID)
+	if err != nil {
+		return err
+	}
+	fn(comment)
+	r.comments[comment.ID] = copyDocument(comment)
+	return nil
+}
+
+func (r *memoryTopicCommentRepository) find(match func(c *model.TopicComment) bool) []*model.TopicComment {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var comments []*model.TopicComment
+	for _, comment := range r.comments {
+		if match(comment) {
+			comments = append(comments, copyDocument(comment))
+		}
+	}
+	return comments
+}
+
+// paginate áp dụng skip/limit như Mongo, limit <= 0 nghĩa là không giới hạn
+func paginate[T any](values []T, skip, limit int64) []T {
+	if skip >= int64(len(values)) {
+		return nil
+	}
+	values = values[skip:]
+	if limit > 0 && limit < int64(len(values)) {
+		values = values[:limit]
+	}
+	return values
+}
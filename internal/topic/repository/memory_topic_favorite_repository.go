@@ -0,0 +1,122 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+	"topic-service/internal/topic/model"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type favoriteKey struct {
+	userID  string
+	topicID string
+}
+
+type memoryTopicFavoriteRepository struct {
+	mu        sync.RWMutex
+	favorites map[favoriteKey]*model.TopicFavorite
+}
+
+func NewMemoryTopicFavoriteRepository() TopicFavoriteRepository {
+	return &memoryTopicFavoriteRepository{favorites: make(map[favoriteKey]*model.TopicFavorite)}
+}
+
+func (r *memoryTopicFavoriteRepository) Add(ctx context.Context, userID, topicID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.add(userID, topicID)
+	return nil
+}
+
+func (r *memoryTopicFavoriteRepository) Remove(ctx context.Context, userID, topicID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := favoriteKey{userID, topicID}
+	if _, ok := r.favorites[key]; !ok {
+		return mongo.ErrNoDocuments
+	}
+	delete(r.favorites, key)
+	return nil
+}
+
+func (r *memoryTopicFavoriteRepository) ListByUser(ctx context.Context, userID string) ([]*model.TopicFavorite, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var favorites []*model.TopicFavorite
+	for key, favorite := range r.favorites {
+		if key.userID == userID {
+			copied := *favorite
+			favorites = append(favorites, &copied)
+		}
+	}
+	sort.Slice(favorites, func(i, j int) bool {
+		if !favorites[i].CreatedAt.Equal(favorites[j].CreatedAt) {
+			return favorites[i].CreatedAt.After(favorites[j].CreatedAt)
+		}
+		return favorites[i].ID.Hex() > favorites[j].ID.Hex()
+	})
+	return favorites, nil
+}
+
+func (r *memoryTopicFavoriteRepository) FavoriteTopicIDs(ctx context.Context, userID string, topicIDs []string) (map[string]bool, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make(map[string]bool)
+	for _, topicID := range topicIDs {
+		if _, ok := r.favorites[favoriteKey{userID, topicID}]; ok {
+			result[topicID] = true
+		}
+	}
+	return result, nil
+}
+
+func (r *memoryTopicFavoriteRepository) DeleteByTopic(ctx context.Context, topicID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.deleteByTopic(topicID)
+	return nil
+}
+
+func (r *memoryTopicFavoriteRepository) ReplaceTopic(ctx context.Context, oldTopicID, newTopicID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for key := range r.favorites {
+		if key.topicID == oldTopicID {
+			r.add(key.userID, newTopicID)
+		}
+	}
+	r.deleteByTopic(oldTopicID)
+	return nil
+}
+
+// add giữ Add idempotent như bản Mongo, caller phải giữ lock
+func (r *memoryTopicFavoriteRepository) add(userID, topicID string) {
+	key := favoriteKey{userID, topicID}
+	if _, ok := r.favorites[key]; ok {
+		return
+	}
+	r.favorites[key] = &model.TopicFavorite{
+		ID:        primitive.NewObjectID(),
+		UserID:    userID,
+		TopicID:   topicID,
+		CreatedAt: time.Now(),
+	}
+}
+
+func (r *memoryTopicFavoriteRepository) deleteByTopic(topicID string) {
+	for key := range r.favorites {
+		if key.topicID == topicID {
+			delete(r.favorites, key)
+		}
+	}
+}
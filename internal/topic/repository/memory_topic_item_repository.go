@@ -0,0 +1,202 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+	"topic-service/internal/topic/model"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type memoryTopicItemRepository struct {
+	mu    sync.RWMutex
+	items map[primitive.ObjectID]*model.TopicItem
+}
+
+func NewMemoryTopicItemRepository() TopicItemRepository {
+	return &memoryTopicItemRepository{items: make(map[primitive.ObjectID]*model.TopicItem)}
+}
+
+func (r *memoryTopicItemRepository) Create(ctx context.Context, item *model.TopicItem) (*model.TopicItem, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if item.ID.IsZero() {
+		item.ID = primitive.NewObjectID()
+	}
+	r.items[item.ID] = copyDocument(item)
+	return item, nil
+}
+
+func (r *memoryTopicItemRepository) CreateMany(ctx context.Context, items []*model.TopicItem) error {
+	for _, item := range items {
+		if _, err := r.Create(ctx, item); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *memoryTopicItemRepository) GetByID(ctx context.Context, topicID, itemID string) (*model.TopicItem, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	item, err := r.find(topicID, itemID)
+	if err != nil {
+		return nil, err
+	}
+	return copyDocument(item), nil
+}
+
+func (r *memoryTopicItemRepository) ListByTopic(ctx context.Context, topicID string) ([]*model.TopicItem, error) {
+	topicObjectID, err := primitive.ObjectIDFromHex(topicID)
+	if err != nil {
+		return nil, validObjectID(topicID)
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var items []*model.TopicItem
+	for _, item := range r.items {
+		if item.TopicID == topicObjectID {
+			items = append(items, copyDocument(item))
+		}
+	}
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].Order != items[j].Order {
+			return items[i].Order < items[j].Order
+		}
+		return items[i].ID.Hex() < items[j].ID.Hex()
+	})
+	return items, nil
+}
+
+func (r *memoryTopicItemRepository) MaxOrder(ctx context.Context, topicID string) (int, error) {
+	items, err := r.ListByTopic(ctx, topicID)
+	if err != nil {
+		return 0, err
+	}
+	maxOrder := -1
+	for _, item := range items {
+		if item.Order > maxOrder {
+			maxOrder = item.Order
+		}
+	}
+	return maxOrder, nil
+}
+
+func (r *memoryTopicItemRepository) Update(ctx context.Context, topicID, itemID string, updated *model.TopicItem) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	item, err := r.find(topicID, itemID)
+	if err != nil {
+		return err
+	}
+
+	updated.UpdatedAt = time.Now()
+	item.Word = updated.Word
+	item.Images = append([]string(nil), updated.Images...)
+	item.CoverImage = updated.CoverImage
+	item.AudioUrl = updated.AudioUrl
+	item.VideoUrl = updated.VideoUrl
+	item.UpdatedAt = updated.UpdatedAt
+	return nil
+}
+
+func (r *memoryTopicItemRepository) UpdateOrder(ctx context.Context, topicID string, itemIDs []string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	for order, itemID := range itemIDs {
+		item, err := r.find(topicID, itemID)
+		if err == mongo.ErrNoDocuments {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		item.Order = order
+		item.UpdatedAt = now
+	}
+	return nil
+}
+
+func (r *memoryTopicItemRepository) Delete(ctx context.Context, topicID, itemID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	item, err := r.find(topicID, itemID)
+	if err != nil {
+		return err
+	}
+	delete(r.items, item.ID)
+	return nil
+}
+
+func (r *memoryTopicItemRepository) DeleteByTopic(ctx context.Context, topicID string) error {
+	topicObjectID, err := primitive.ObjectIDFromHex(topicID)
+	if err != nil {
+		return validObjectID(topicID)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for id, item := range r.items {
+		if item.TopicID == topicObjectID {
+			delete(r.items, id)
+		}
+	}
+	return nil
+}
+
+func (r *memoryTopicItemRepository) MoveToTopic(ctx context.Context, fromTopicID, toTopicID string, orderOffset int) (int64, error) {
+	fromObjectID, err := primitive.ObjectIDFromHex(fromTopicID)
+	if err != nil {
+		return 0, validObjectID(fromTopicID)
+	}
+	toObjectID, err := primitive.ObjectIDFromHex(toTopicID)
+	if err != nil {
+		return 0, validObjectID(toTopicID)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	var moved int64
+	for _, item := range r.items {
+		if item.TopicID != fromObjectID {
+			continue
+		}
+		item.TopicID = toObjectID
+		item.Order += orderOffset
+		item.UpdatedAt = now
+		moved++
+	}
+	return moved, nil
+}
+
+// find tìm item thuộc topic, caller phải giữ lock
+func (r *memoryTopicItemRepository) find(topicID, itemID string) (*model.TopicItem, error) {
+	topicObjectID, err := primitive.ObjectIDFromHex(topicID)
+	if err != nil {
+		return nil, validObjectID(topicID)
+	}
+	itemObjectID, err := primitive.ObjectIDFromHex(itemID)
+	if err != nil {
+		return nil, validObjectID(itemID)
+	}
+
+	item, ok := r.items[itemObjectID]
+	if !ok || item.TopicID != topicObjectID {
+		return nil, mongo.ErrNoDocuments
+	}
+	return item, nil
+}
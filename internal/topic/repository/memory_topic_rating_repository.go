@@ -0,0 +1,142 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+	"topic-service/internal/topic/model"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type ratingKey struct {
+	topicID string
+	userID  string
+}
+
+type memoryTopicRatingRepository struct {
+	mu      sync.RWMutex
+	ratings map[ratingKey]*model.TopicRating
+}
+
+func NewMemoryTopicRatingRepository() TopicRatingRepository {
+	return &memoryTopicRatingRepository{ratings: make(map[ratingKey]*model.TopicRating)}
+}
+
+func (r *memoryTopicRatingRepository) Upsert(ctx context.Context, rating *model.TopicRating) (*model.TopicRating, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := ratingKey{rating.TopicID, rating.UserID}
+	existing, ok := r.ratings[key]
+	if !ok {
+		stored := *rating
+		stored.ID = primitive.NewObjectID()
+		r.ratings[key] = &stored
+		return nil, nil
+	}
+
+	previous := *existing
+	existing.Score = rating.Score
+	existing.Feedback = rating.Feedback
+	existing.UpdatedAt = rating.UpdatedAt
+	return &previous, nil
+}
+
+func (r *memoryTopicRatingRepository) GetByUser(ctx context.Context, topicID, userID string) (*model.TopicRating, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	rating, ok := r.ratings[ratingKey{topicID, userID}]
+	if !ok {
+		return nil, mongo.ErrNoDocuments
+	}
+	copied := *rating
+	return &copied, nil
+}
+
+func (r *memoryTopicRatingRepository) Delete(ctx context.Context, topicID, userID string) (*model.TopicRating, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := ratingKey{topicID, userID}
+	rating, ok := r.ratings[key]
+	if !ok {
+		return nil, mongo.ErrNoDocuments
+	}
+	delete(r.ratings, key)
+	return rating, nil
+}
+
+func (r *memoryTopicRatingRepository) ListByTopic(ctx context.Context, topicID string, skip, limit int64) ([]*model.TopicRating, error) {
+	r.mu.RLock()
+	var ratings []*model.TopicRating
+	for key, rating := range r.ratings {
+		if key.topicID == topicID {
+			copied := *rating
+			ratings = append(ratings, &copied)
+		}
+	}
+	r.mu.RUnlock()
+
+	sort.Slice(ratings, func(i, j int) bool {
+		if !ratings[i].UpdatedAt.Equal(ratings[j].UpdatedAt) {
+			return ratings[i].UpdatedAt.After(ratings[j].UpdatedAt)
+		}
+		return ratings[i].ID.Hex() > ratings[j].ID.Hex()
+	})
+	return paginate(ratings, skip, limit), nil
+}
+
+func (r *memoryTopicRatingRepository) CountByTopic(ctx context.Context, topicID string) (int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var count int64
+	for key := range r.ratings {
+		if key.topicID == topicID {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (r *memoryTopicRatingRepository) DeleteByTopic(ctx context.Context, topicID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for key := range r.ratings {
+		if key.topicID == topicID {
+			delete(r.ratings, key)
+		}
+	}
+	return nil
+}
+
+func (r *memoryTopicRatingRepository) MoveToTopic(ctx context.Context, fromTopicID, toTopicID string) (model.RatingTotals, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var totals model.RatingTotals
+	now := time.Now()
+	for key, rating := range r.ratings {
+		if key.topicID != fromTopicID {
+			continue
+		}
+		delete(r.ratings, key)
+
+		// User đã đánh giá topic đích thì giữ đánh giá ở đích
+		target := ratingKey{toTopicID, key.userID}
+		if _, exists := r.ratings[target]; exists {
+			continue
+		}
+		rating.TopicID = toTopicID
+		rating.UpdatedAt = now
+		r.ratings[target] = rating
+		totals.Sum += rating.Score
+		totals.Count++
+	}
+	return totals, nil
+}
@@ -0,0 +1,49 @@
+package repository
+
+import (
+	"context"
+	"sync"
+	"topic-service/internal/topic/model"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type memoryTopicRedirectRepository struct {
+	mu        sync.RWMutex
+	redirects map[string]*model.TopicRedirect
+}
+
+func NewMemoryTopicRedirectRepository() TopicRedirectRepository {
+	return &memoryTopicRedirectRepository{redirects: make(map[string]*model.TopicRedirect)}
+}
+
+func (r *memoryTopicRedirectRepository) Save(ctx context.Context, redirect *model.TopicRedirect) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.redirects[redirect.ID] = copyDocument(redirect)
+	return nil
+}
+
+func (r *memoryTopicRedirectRepository) GetByID(ctx context.Context, id string) (*model.TopicRedirect, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	redirect, ok := r.redirects[id]
+	if !ok {
+		return nil, mongo.ErrNoDocuments
+	}
+	return copyDocument(redirect), nil
+}
+
+func (r *memoryTopicRedirectRepository) RepointTargets(ctx context.Context, oldTargetID, newTargetID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, redirect := range r.redirects {
+		if redirect.TargetID == oldTargetID {
+			redirect.TargetID = newTargetID
+		}
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package repository
+
+import (
+	"context"
+	"sync"
+	"topic-service/internal/topic/model"
+)
+
+type memoryTopicRelatedRepository struct {
+	mu      sync.RWMutex
+	related map[string]*model.TopicRelated
+}
+
+func NewMemoryTopicRelatedRepository() TopicRelatedRepository {
+	return &memoryTopicRelatedRepository{related: make(map[string]*model.TopicRelated)}
+}
+
+func (r *memoryTopicRelatedRepository) SaveAll(ctx context.Context, related []*model.TopicRelated) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, rel := range related {
+		r.related[rel.TopicID] = copyDocument(rel)
+	}
+	return nil
+}
+
+func (r *memoryTopicRelatedRepository) Get(ctx context.Context, topicID string) (*model.TopicRelated, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	related, ok := r.related[topicID]
+	if !ok {
+		return nil, nil
+	}
+	return copyDocument(related), nil
+}
+
+func (r *memoryTopicRelatedRepository) DeleteStale(ctx context.Context, keepTopicIDs []string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for topicID := range r.related {
+		if !containsString(keepTopicIDs, topicID) {
+			delete(r.related, topicID)
+		}
+	}
+	return nil
+}
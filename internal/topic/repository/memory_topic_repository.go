@@ -0,0 +1,357 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"topic-service/internal/topic/model"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// memoryTopicRepository lưu topic trong bộ nhớ, dùng cho chế độ --dev
+type memoryTopicRepository struct {
+	mu     sync.RWMutex
+	topics map[string]*model.Topic
+	// order giữ thứ tự tạo để GetAll trả về ổn định như thứ tự tự nhiên của Mongo
+	order []string
+}
+
+func NewMemoryTopicRepository() TopicRepository {
+	return &memoryTopicRepository{topics: make(map[string]*model.Topic)}
+}
+
+func (r *memoryTopicRepository) Create(ctx context.Context, topic *model.Topic) (*model.Topic, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	id := topic.ID.Hex()
+	if _, exists := r.topics[id]; exists {
+		return nil, mongo.WriteException{WriteErrors: mongo.WriteErrors{{Code: 11000, Message: "duplicate key"}}}
+	}
+	r.topics[id] = copyDocument(topic)
+	r.order = append(r.order, id)
+	return topic, nil
+}
+
+func (r *memoryTopicRepository) GetByID(ctx context.Context, id string) (*model.Topic, error) {
+	if err := validObjectID(id); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	topic, ok := r.topics[id]
+	if !ok {
+		return nil, mongo.ErrNoDocuments
+	}
+	return copyDocument(topic), nil
+}
+
+func (r *memoryTopicRepository) Update(ctx context.Context, id string, updated *model.Topic) error {
+	updated.UpdatedAt = time.Now()
+	return r.modify(id, func(t *model.Topic) {
+		t.Title = updated.Title
+		t.Titles = updated.Titles
+		t.Descriptions = updated.Descriptions
+		t.DefaultLocale = updated.DefaultLocale
+		t.SearchTerms = updated.SearchTerms
+		t.UpdatedAt = updated.UpdatedAt
+	})
+}
+
+func (r *memoryTopicRepository) Delete(ctx context.Context, id string) error {
+	if err := validObjectID(id); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.topics[id]; !ok {
+		return mongo.ErrNoDocuments
+	}
+	delete(r.topics, id)
+	for i, existing := range r.order {
+		if existing == id {
+			r.order = append(r.order[:i], r.order[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+func (r *memoryTopicRepository) GetAll(ctx context.Context) ([]*model.Topic, error) {
+	return r.filter(func(*model.Topic) bool { return true }), nil
+}
+
+func (r *memoryTopicRepository) List(ctx context.Context, filter TopicFilter) ([]*model.Topic, error) {
+	topics := r.filter(func(t *model.Topic) bool {
+		if filter.OrganizationID != nil && !visibleInMemory(t, *filter.OrganizationID) {
+			return false
+		}
+		if filter.IsTemplate != nil && t.IsTemplate != *filter.IsTemplate {
+			return false
+		}
+		if filter.MinRating != nil && t.RatingAverage < *filter.MinRating {
+			return false
+		}
+		if filter.MaxRating != nil && t.RatingAverage > *filter.MaxRating {
+			return false
+		}
+		return t.RatingCount >= filter.MinRatingCount
+	})
+
+	key := strings.TrimPrefix(filter.Sort, "-")
+	if _, ok := topicSortFields[key]; ok {
+		desc := strings.HasPrefix(filter.Sort, "-")
+		sort.SliceStable(topics, func(i, j int) bool {
+			a, b := topicSortValue(topics[i], key), topicSortValue(topics[j], key)
+			if a == b {
+				return topics[i].ID.Hex() < topics[j].ID.Hex()
+			}
+			if desc {
+				return a > b
+			}
+			return a < b
+		})
+	}
+	return topics, nil
+}
+
+// visibleInMemory giống visibleToOrganization của bản MongoDB
+func visibleInMemory(t *model.Topic, organizationID string) bool {
+	return t.OrganizationID == organizationID || t.OrganizationID == "" || t.IsTemplate
+}
+
+func topicSortValue(t *model.Topic, key string) float64 {
+	switch key {
+	case "rating":
+		return t.RatingAverage
+	case "rating_count":
+		return float64(t.RatingCount)
+	default:
+		return float64(t.CreatedAt.UnixNano())
+	}
+}
+
+func (r *memoryTopicRepository) GetByIDs(ctx context.Context, ids []string) ([]*model.Topic, error) {
+	wanted := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		if err := validObjectID(id); err != nil {
+			return nil, err
+		}
+		wanted[id] = true
+	}
+	if len(wanted) == 0 {
+		return nil, nil
+	}
+	return r.filter(func(t *model.Topic) bool { return wanted[t.ID.Hex()] }), nil
+}
+
+func (r *memoryTopicRepository) IncrementItemCount(ctx context.Context, id string, delta int) error {
+	return r.modify(id, func(t *model.Topic) { t.ItemCount += delta })
+}
+
+func (r *memoryTopicRepository) IncrementCommentCount(ctx context.Context, id string, delta int) error {
+	return r.modify(id, func(t *model.Topic) { t.CommentCount += delta })
+}
+
+func (r *memoryTopicRepository) ApplyRatingDelta(ctx context.Context, id string, sumDelta, countDelta int) error {
+	return r.modify(id, func(t *model.Topic) {
+		t.RatingSum += sumDelta
+		t.RatingCount += countDelta
+		t.RatingAverage = 0
+		if t.RatingCount > 0 {
+			t.RatingAverage = float64(t.RatingSum) / float64(t.RatingCount)
+		}
+	})
+}
+
+func (r *memoryTopicRepository) AddPrerequisite(ctx context.Context, id string, prerequisiteID string) error {
+	return r.modify(id, func(t *model.Topic) {
+		if !containsString(t.PrerequisiteIDs, prerequisiteID) {
+			t.PrerequisiteIDs = append(t.PrerequisiteIDs, prerequisiteID)
+		}
+		t.UpdatedAt = time.Now()
+	})
+}
+
+func (r *memoryTopicRepository) RemovePrerequisite(ctx context.Context, id string, prerequisiteID string) error {
+	return r.modify(id, func(t *model.Topic) {
+		t.PrerequisiteIDs = removeString(t.PrerequisiteIDs, prerequisiteID)
+		t.UpdatedAt = time.Now()
+	})
+}
+
+func (r *memoryTopicRepository) RemovePrerequisiteFromAll(ctx context.Context, prerequisiteID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, t := range r.topics {
+		t.PrerequisiteIDs = removeString(t.PrerequisiteIDs, prerequisiteID)
+	}
+	return nil
+}
+
+func (r *memoryTopicRepository) SetTemplate(ctx context.Context, id string, isTemplate bool) error {
+	return r.modify(id, func(t *model.Topic) {
+		t.IsTemplate = isTemplate
+		t.UpdatedAt = time.Now()
+	})
+}
+
+func (r *memoryTopicRepository) GetTemplates(ctx context.Context) ([]*model.Topic, error) {
+	return r.filter(func(t *model.Topic) bool { return t.IsTemplate }), nil
+}
+
+func (r *memoryTopicRepository) ReplacePrerequisite(ctx context.Context, oldID, newID string) error {
+	if err := validObjectID(newID); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, t := range r.topics {
+		if !containsString(t.PrerequisiteIDs, oldID) {
+			continue
+		}
+		t.PrerequisiteIDs = removeString(t.PrerequisiteIDs, oldID)
+		if !containsString(t.PrerequisiteIDs, newID) {
+			t.PrerequisiteIDs = append(t.PrerequisiteIDs, newID)
+		}
+	}
+	// Topic mới không được là prerequisite của chính nó
+	if t, ok := r.topics[newID]; ok {
+		t.PrerequisiteIDs = removeString(t.PrerequisiteIDs, newID)
+	}
+	return nil
+}
+
+func (r *memoryTopicRepository) ApplyMerge(ctx context.Context, id string, merged *model.Topic) error {
+	merged.UpdatedAt = time.Now()
+	return r.modify(id, func(t *model.Topic) {
+		t.Title = merged.Title
+		t.Titles = merged.Titles
+		t.Descriptions = merged.Descriptions
+		t.DefaultLocale = merged.DefaultLocale
+		t.SearchTerms = merged.SearchTerms
+		t.Icon = merged.Icon
+		t.IsTemplate = merged.IsTemplate
+		t.PrerequisiteIDs = merged.PrerequisiteIDs
+		t.ItemCount = merged.ItemCount
+		t.CommentCount = merged.CommentCount
+		t.RatingSum = merged.RatingSum
+		t.RatingCount = merged.RatingCount
+		t.RatingAverage = merged.RatingAverage
+		t.UpdatedAt = merged.UpdatedAt
+	})
+}
+
+func (r *memoryTopicRepository) Search(ctx context.Context, filter TopicSearchFilter) ([]*model.Topic, error) {
+	if len(filter.Terms) == 0 && filter.Prefix == "" {
+		return nil, nil
+	}
+
+	topics := r.filter(func(t *model.Topic) bool {
+		for _, term := range filter.Terms {
+			if !containsString(t.SearchTerms, term) {
+				return false
+			}
+		}
+		if filter.Prefix != "" {
+			matched := false
+			for _, term := range t.SearchTerms {
+				if strings.HasPrefix(term, filter.Prefix) {
+					matched = true
+					break
+				}
+			}
+			if !matched {
+				return false
+			}
+		}
+		if filter.OrganizationID != nil && !visibleInMemory(t, *filter.OrganizationID) {
+			return false
+		}
+		return true
+	})
+
+	sort.SliceStable(topics, func(i, j int) bool {
+		if topics[i].RatingAverage != topics[j].RatingAverage {
+			return topics[i].RatingAverage > topics[j].RatingAverage
+		}
+		return topics[i].ID.Hex() < topics[j].ID.Hex()
+	})
+	if filter.Limit > 0 && len(topics) > filter.Limit {
+		topics = topics[:filter.Limit]
+	}
+	return topics, nil
+}
+
+func (r *memoryTopicRepository) ListByCreator(ctx context.Context, userID string) ([]*model.Topic, error) {
+	return r.filter(func(t *model.Topic) bool { return t.CreatedBy == userID }), nil
+}
+
+func (r *memoryTopicRepository) SetOwner(ctx context.Context, id string, ownerID string) error {
+	return r.modify(id, func(t *model.Topic) {
+		t.CreatedBy = ownerID
+		t.UpdatedAt = time.Now()
+	})
+}
+
+// modify áp dụng fn lên topic đang lưu dưới write lock
+func (r *memoryTopicRepository) modify(id string, fn func(t *model.Topic)) error {
+	if err := validObjectID(id); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	topic, ok := r.topics[id]
+	if !ok {
+		return mongo.ErrNoDocuments
+	}
+	fn(topic)
+	// Sao chép lại để không giữ tham chiếu tới slice/map của caller
+	r.topics[id] = copyDocument(topic)
+	return nil
+}
+
+// filter trả về bản sao các topic thoả điều kiện theo thứ tự tạo
+func (r *memoryTopicRepository) filter(match func(t *model.Topic) bool) []*model.Topic {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var topics []*model.Topic
+	for _, id := range r.order {
+		if t := r.topics[id]; match(t) {
+			topics = append(topics, copyDocument(t))
+		}
+	}
+	return topics
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func removeString(values []string, value string) []string {
+	result := values[:0:0]
+	for _, v := range values {
+		if v != value {
+			result = append(result, v)
+		}
+	}
+	return result
+}
@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"topic-service/internal/topic/model"
+)
+
+type viewStatKey struct {
+	topicID        string
+	organizationID string
+	date           string
+}
+
+type memoryTopicViewStatRepository struct {
+	mu    sync.Mutex
+	views map[viewStatKey]int64
+}
+
+func NewMemoryTopicViewStatRepository() TopicViewStatRepository {
+	return &memoryTopicViewStatRepository{views: make(map[viewStatKey]int64)}
+}
+
+func (r *memoryTopicViewStatRepository) IncrementViews(ctx context.Context, stats []model.TopicViewStat) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, stat := range stats {
+		r.views[viewStatKey{stat.TopicID, stat.OrganizationID, stat.Date}] += stat.Views
+	}
+	return nil
+}
+
+func (r *memoryTopicViewStatRepository) SumViews(ctx context.Context, filter ViewStatFilter, limit int) ([]model.TopicViewTotal, error) {
+	r.mu.Lock()
+	sums := make(map[string]int64)
+	for key, views := range r.views {
+		if key.date < filter.From || key.date > filter.To {
+			continue
+		}
+		if filter.OrganizationID != "" && key.organizationID != filter.OrganizationID {
+			continue
+		}
+		sums[key.topicID] += views
+	}
+	r.mu.Unlock()
+
+	totals := make([]model.TopicViewTotal, 0, len(sums))
+	for topicID, views := range sums {
+		totals = append(totals, model.TopicViewTotal{TopicID: topicID, Views: views})
+	}
+	sort.Slice(totals, func(i, j int) bool {
+		if totals[i].Views != totals[j].Views {
+			return totals[i].Views > totals[j].Views
+		}
+		return totals[i].TopicID < totals[j].TopicID
+	})
+	if limit > 0 && len(totals) > limit {
+		totals = totals[:limit]
+	}
+	return totals, nil
+}
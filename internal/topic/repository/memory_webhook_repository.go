@@ -0,0 +1,302 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+	"topic-service/internal/topic/model"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type memoryWebhookSubscriptionRepository struct {
+	mu            sync.RWMutex
+	subscriptions map[string]*model.WebhookSubscription
+}
+
+func NewMemoryWebhookSubscriptionRepository() WebhookSubscriptionRepository {
+	return &memoryWebhookSubscriptionRepository{subscriptions: make(map[string]*model.WebhookSubscription)}
+}
+
+func (r *memoryWebhookSubscriptionRepository) Create(ctx context.Context, subscription *model.WebhookSubscription) (*model.WebhookSubscription, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if subscription.ID.IsZero() {
+		subscription.ID = primitive.NewObjectID()
+	}
+	r.subscriptions[subscription.ID.Hex()] = copyDocument(subscription)
+	return subscription, nil
+}
+
+func (r *memoryWebhookSubscriptionRepository) GetByID(ctx context.Context, id string) (*model.WebhookSubscription, error) {
+	if err := validObjectID(id); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	subscription, ok := r.subscriptions[id]
+	if !ok {
+		return nil, mongo.ErrNoDocuments
+	}
+	return copyDocument(subscription), nil
+}
+
+func (r *memoryWebhookSubscriptionRepository) ListByOrganization(ctx context.Context, organizationID string) ([]*model.WebhookSubscription, error) {
+	return r.find(func(s *model.WebhookSubscription) bool {
+		return s.OrganizationID == organizationID
+	}), nil
+}
+
+func (r *memoryWebhookSubscriptionRepository) ListActive(ctx context.Context, organizationID string) ([]*model.WebhookSubscription, error) {
+	return r.find(func(s *model.WebhookSubscription) bool {
+		return s.OrganizationID == organizationID && s.Active
+	}), nil
+}
+
+func (r *memoryWebhookSubscriptionRepository) Update(ctx context.Context, subscription *model.WebhookSubscription) error {
+	return r.modify(subscription.ID.Hex(), func(s *model.WebhookSubscription) {
+		s.URL = subscription.URL
+		s.Secret = subscription.Secret
+		s.Events = append([]string(nil), subscription.Events...)
+		s.Active = subscription.Active
+		s.UpdatedAt = subscription.UpdatedAt
+		if s.Active {
+			s.ConsecutiveFailures = 0
+			s.DisabledAt = nil
+			s.DisabledReason = ""
+		}
+	})
+}
+
+func (r *memoryWebhookSubscriptionRepository) Delete(ctx context.Context, id string) error {
+	if err := validObjectID(id); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.subscriptions[id]; !ok {
+		return mongo.ErrNoDocuments
+	}
+	delete(r.subscriptions, id)
+	return nil
+}
+
+func (r *memoryWebhookSubscriptionRepository) RecordSuccess(ctx context.Context, id string) error {
+	err := r.modify(id, func(s *model.WebhookSubscription) {
+		s.ConsecutiveFailures = 0
+	})
+	if err == mongo.ErrNoDocuments {
+		return nil
+	}
+	return err
+}
+
+func (r *memoryWebhookSubscriptionRepository) RecordFailure(ctx context.Context, id string, disableAfter int, reason string) (bool, error) {
+	disabled := false
+	err := r.modify(id, func(s *model.WebhookSubscription) {
+		s.ConsecutiveFailures++
+		if disableAfter > 0 && s.Active && s.ConsecutiveFailures >= disableAfter {
+			now := time.Now()
+			s.Active = false
+			s.DisabledAt = &now
+			s.DisabledReason = reason
+			disabled = true
+		}
+	})
+	return disabled, err
+}
+
+func (r *memoryWebhookSubscriptionRepository) find(match func(s *model.WebhookSubscription) bool) []*model.WebhookSubscription {
+	r.mu.RLock()
+	subscriptions := []*model.WebhookSubscription{}
+	for _, s := range r.subscriptions {
+		if match(s) {
+			subscriptions = append(subscriptions, copyDocument(s))
+		}
+	}
+	r.mu.RUnlock()
+
+	sort.Slice(subscriptions, func(i, j int) bool {
+		if !subscriptions[i].CreatedAt.Equal(subscriptions[j].CreatedAt) {
+			return subscriptions[i].CreatedAt.Before(subscriptions[j].CreatedAt)
+		}
+		return subscriptions[i].ID.Hex() < subscriptions[j].ID.Hex()
+	})
+	return subscriptions
+}
+
+func (r *memoryWebhookSubscriptionRepository) modify(id string, fn func(s *model.WebhookSubscription)) error {
+	if err := validObjectID(id); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	subscription, ok := r.subscriptions[id]
+	if !ok {
+		return mongo.ErrNoDocuments
+	}
+	fn(subscription)
+	return nil
+}
+
+type memoryWebhookDeliveryRepository struct {
+	mu         sync.RWMutex
+	deliveries map[primitive.ObjectID]*model.WebhookDelivery
+}
+
+func NewMemoryWebhookDeliveryRepository() WebhookDeliveryRepository {
+	return &memoryWebhookDeliveryRepository{deliveries: make(map[primitive.ObjectID]*model.WebhookDelivery)}
+}
+
+func (r *memoryWebhookDeliveryRepository) Create(ctx context.Context, delivery *model.WebhookDelivery) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if delivery.DedupKey != "" {
+		for _, d := range r.deliveries {
+			if d.DedupKey == delivery.DedupKey {
+				return nil
+			}
+		}
+	}
+	if delivery.ID.IsZero() {
+		delivery.ID = primitive.NewObjectID()
+	}
+	copied := *delivery
+	r.deliveries[delivery.ID] = &copied
+	return nil
+}
+
+func (r *memoryWebhookDeliveryRepository) GetByID(ctx context.Context, subscriptionID, id string) (*model.WebhookDelivery, error) {
+	if err := validObjectID(id); err != nil {
+		return nil, err
+	}
+	objectID, _ := primitive.ObjectIDFromHex(id)
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	delivery, ok := r.deliveries[objectID]
+	if !ok || delivery.SubscriptionID != subscriptionID {
+		return nil, mongo.ErrNoDocuments
+	}
+	copied := *delivery
+	return &copied, nil
+}
+
+func (r *memoryWebhookDeliveryRepository) ListBySubscription(ctx context.Context, subscriptionID string, skip, limit int64) ([]*model.WebhookDelivery, error) {
+	r.mu.RLock()
+	deliveries := []*model.WebhookDelivery{}
+	for _, d := range r.deliveries {
+		if d.SubscriptionID == subscriptionID {
+			copied := *d
+			deliveries = append(deliveries, &copied)
+		}
+	}
+	r.mu.RUnlock()
+
+	sort.Slice(deliveries, func(i, j int) bool {
+		if !deliveries[i].CreatedAt.Equal(deliveries[j].CreatedAt) {
+			return deliveries[i].CreatedAt.After(deliveries[j].CreatedAt)
+		}
+		return deliveries[i].ID.Hex() > deliveries[j].ID.Hex()
+	})
+	return paginate(deliveries, skip, limit), nil
+}
+
+func (r *memoryWebhookDeliveryRepository) CountBySubscription(ctx context.Context, subscriptionID string) (int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var count int64
+	for _, d := range r.deliveries {
+		if d.SubscriptionID == subscriptionID {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (r *memoryWebhookDeliveryRepository) ClaimDue(ctx context.Context, now time.Time, lease time.Duration) (*model.WebhookDelivery, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var due *model.WebhookDelivery
+	for _, d := range r.deliveries {
+		if d.Status != model.WebhookDeliveryPending || d.NextAttemptAt.After(now) {
+			continue
+		}
+		if due == nil || d.NextAttemptAt.Before(due.NextAttemptAt) ||
+			(d.NextAttemptAt.Equal(due.NextAttemptAt) && d.ID.Hex() < due.ID.Hex()) {
+			due = d
+		}
+	}
+	if due == nil {
+		return nil, mongo.ErrNoDocuments
+	}
+
+	claimed := *due
+	due.NextAttemptAt = now.Add(lease)
+	return &claimed, nil
+}
+
+func (r *memoryWebhookDeliveryRepository) MarkSucceeded(ctx context.Context, id primitive.ObjectID, attempts, statusCode int, deliveredAt time.Time) error {
+	return r.modify(id, func(d *model.WebhookDelivery) {
+		d.Status = model.WebhookDeliverySucceeded
+		d.Attempts = attempts
+		d.LastStatusCode = statusCode
+		d.LastError = ""
+		d.DeliveredAt = &deliveredAt
+	})
+}
+
+func (r *memoryWebhookDeliveryRepository) MarkRetry(ctx context.Context, id primitive.ObjectID, attempts, statusCode int, lastError string, nextAttemptAt time.Time) error {
+	return r.modify(id, func(d *model.WebhookDelivery) {
+		d.Attempts = attempts
+		d.LastStatusCode = statusCode
+		d.LastError = lastError
+		d.NextAttemptAt = nextAttemptAt
+	})
+}
+
+func (r *memoryWebhookDeliveryRepository) MarkFailed(ctx context.Context, id primitive.ObjectID, attempts, statusCode int, lastError string) error {
+	return r.modify(id, func(d *model.WebhookDelivery) {
+		d.Status = model.WebhookDeliveryFailed
+		d.Attempts = attempts
+		d.LastStatusCode = statusCode
+		d.LastError = lastError
+	})
+}
+
+func (r *memoryWebhookDeliveryRepository) DeleteBySubscription(ctx context.Context, subscriptionID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for id, d := range r.deliveries {
+		if d.SubscriptionID == subscriptionID {
+			delete(r.deliveries, id)
+		}
+	}
+	return nil
+}
+
+func (r *memoryWebhookDeliveryRepository) modify(id primitive.ObjectID, fn func(d *model.WebhookDelivery)) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delivery, ok := r.deliveries[id]
+	if !ok {
+		return mongo.ErrNoDocuments
+	}
+	fn(delivery)
+	return nil
+}
@@ -0,0 +1,159 @@
+package repository
+
+import (
+	"context"
+	"time"
+	"topic-service/internal/topic/model"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type OutboxRepository interface {
+	Create(ctx context.Context, event *model.OutboxEvent) error
+	GetByID(ctx context.Context, id primitive.ObjectID) (*model.OutboxEvent, error)
+	// ListPending trả về event chờ gửi theo thứ tự ghi. Topic có event chưa tới lượt thử lại (next_attempt_at > now)
+	// bị bỏ qua cả topic để giữ thứ tự, nhờ vậy topic đang chờ không chiếm hết batch của topic khác.
+	ListPending(ctx context.Context, now time.Time, limit int) ([]*model.OutboxEvent, error)
+	// ListSince trả về event có (created_at, _id) sau vị trí (afterCreatedAt, afterID) và không muộn hơn until,
+	// theo thứ tự ghi và không phân biệt trạng thái. Event đã gửi chỉ được giữ 7 ngày.
+	ListSince(ctx context.Context, afterCreatedAt time.Time, afterID primitive.ObjectID, until time.Time, limit int) ([]*model.OutboxEvent, error)
+	MarkPublished(ctx context.Context, id primitive.ObjectID, publishedAt time.Time) error
+	MarkRetry(ctx context.Context, id primitive.ObjectID, attempts int, lastError string, nextAttemptAt time.Time) error
+	MarkDead(ctx context.Context, id primitive.ObjectID, attempts int, lastError string) error
+	// AcquireLease giữ quyền relay trong ttl để chỉ một instance gửi event, gọi lại để gia hạn
+	AcquireLease(ctx context.Context, owner string, ttl time.Duration) (bool, error)
+}
+
+const outboxLeaseID = "relay"
+
+type outboxRepository struct {
+	collection *mongo.Collection
+	leases     *mongo.Collection
+}
+
+func NewOutboxRepository(collection, leases *mongo.Collection) OutboxRepository {
+	return &outboxRepository{collection: collection, leases: leases}
+}
+
+func (r *outboxRepository) Create(ctx context.Context, event *model.OutboxEvent) error {
+	_, err := r.collection.InsertOne(ctx, event)
+	return err
+}
+
+func (r *outboxRepository) GetByID(ctx context.Context, id primitive.ObjectID) (*model.OutboxEvent, error) {
+	var event model.OutboxEvent
+	if err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&event); err != nil {
+		return nil, err
+	}
+	return &event, nil
+}
+
+func (r *outboxRepository) ListPending(ctx context.Context, now time.Time, limit int) ([]*model.OutboxEvent, error) {
+	waiting, err := r.collection.Distinct(ctx, "aggregate_id", bson.M{
+		"status":          model.OutboxStatusPending,
+		"next_attempt_at": bson.M{"$gt": now},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	filter := bson.M{"status": model.OutboxStatusPending}
+	if len(waiting) > 0 {
+		filter["aggregate_id"] = bson.M{"$nin": waiting}
+	}
+	opts := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: 1}, {Key: "_id", Value: 1}}).
+		SetLimit(int64(limit))
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var events []*model.OutboxEvent
+	if err := cursor.All(ctx, &events); err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+func (r *outboxRepository) ListSince(ctx context.Context, afterCreatedAt time.Time, afterID primitive.ObjectID, until time.Time, limit int) ([]*model.OutboxEvent, error) {
+	filter := bson.M{
+		"$or": bson.A{
+			bson.M{"created_at": bson.M{"$gt": afterCreatedAt, "$lte": until}},
+			bson.M{"created_at": afterCreatedAt, "_id": bson.M{"$gt": afterID}},
+		},
+	}
+	opts := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: 1}, {Key: "_id", Value: 1}}).
+		SetLimit(int64(limit))
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	events := []*model.OutboxEvent{}
+	if err := cursor.All(ctx, &events); err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+func (r *outboxRepository) MarkPublished(ctx context.Context, id primitive.ObjectID, publishedAt time.Time) error {
+	return r.update(ctx, id, bson.M{
+		"status":       model.OutboxStatusPublished,
+		"published_at": publishedAt,
+		"attempts":     0,
+	})
+}
+
+func (r *outboxRepository) MarkRetry(ctx context.Context, id primitive.ObjectID, attempts int, lastError string, nextAttemptAt time.Time) error {
+	return r.update(ctx, id, bson.M{
+		"attempts":        attempts,
+		"last_error":      lastError,
+		"next_attempt_at": nextAttemptAt,
+	})
+}
+
+func (r *outboxRepository) MarkDead(ctx context.Context, id primitive.ObjectID, attempts int, lastError string) error {
+	return r.update(ctx, id, bson.M{
+		"status":     model.OutboxStatusDead,
+		"attempts":   attempts,
+		"last_error": lastError,
+	})
+}
+
+func (r *outboxRepository) AcquireLease(ctx context.Context, owner string, ttl time.Duration) (bool, error) {
+	now := time.Now()
+	filter := bson.M{
+		"_id": outboxLeaseID,
+		"$or": bson.A{
+			bson.M{"expires_at": bson.M{"$lt": now}},
+			bson.M{"owner": owner},
+		},
+	}
+	update := bson.M{"$set": bson.M{"owner": owner, "expires_at": now.Add(ttl)}}
+
+	_, err := r.leases.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true))
+	if mongo.IsDuplicateKeyError(err) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func (r *outboxRepository) update(ctx context.Context, id primitive.ObjectID, set bson.M) error {
+	result, err := r.collection.UpdateByID(ctx, id, bson.M{"$set": set})
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+	"topic-service/internal/topic/model"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// ProcessedEventRepository ghi nhớ event đã xử lý của từng consumer để event gửi lại không bị xử lý hai lần
+type ProcessedEventRepository interface {
+	IsProcessed(ctx context.Context, consumer, eventID string) (bool, error)
+	MarkProcessed(ctx context.Context, consumer, eventID string) error
+}
+
+type processedEventRepository struct {
+	collection *mongo.Collection
+}
+
+func NewProcessedEventRepository(collection *mongo.Collection) ProcessedEventRepository {
+	return &processedEventRepository{collection}
+}
+
+func processedEventKey(consumer, eventID string) string {
+	return consumer + ":" + eventID
+}
+
+func (r *processedEventRepository) IsProcessed(ctx context.Context, consumer, eventID string) (bool, error) {
+	err := r.collection.FindOne(ctx, bson.M{"_id": processedEventKey(consumer, eventID)}).Err()
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func (r *processedEventRepository) MarkProcessed(ctx context.Context, consumer, eventID string) error {
+	_, err := r.collection.InsertOne(ctx, model.ProcessedEvent{
+		ID:          processedEventKey(consumer, eventID),
+		Consumer:    consumer,
+		EventID:     eventID,
+		ProcessedAt: time.Now(),
+	})
+	// Hai lần giao cùng một event chạy song song thì lần sau đánh dấu trùng, không phải lỗi
+	if mongo.IsDuplicateKeyError(err) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+	"topic-service/internal/topic/model"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type RecentTopicRepository interface {
+	Push(ctx context.Context, userID string, topicIDs []string, limit int) error
+	Get(ctx context.Context, userID string) ([]string, error)
+}
+
+type recentTopicRepository struct {
+	collection *mongo.Collection
+}
+
+func NewRecentTopicRepository(collection *mongo.Collection) RecentTopicRepository {
+	return &recentTopicRepository{collection}
+}
+
+// Push đưa topicIDs (mới nhất đứng đầu) lên đầu danh sách, bỏ bản trùng cũ và cắt còn limit phần tử
+func (r *recentTopicRepository) Push(ctx context.Context, userID string, topicIDs []string, limit int) error {
+	if len(topicIDs) == 0 {
+		return nil
+	}
+
+	filter := bson.M{"_id": userID}
+	_, err := r.collection.UpdateOne(ctx, filter, bson.M{
+		"$pull": bson.M{"topic_ids": bson.M{"$in": topicIDs}},
+	})
+	if err != nil {
+		return err
+	}
+
+	update := bson.M{
+		"$push": bson.M{"topic_ids": bson.M{
+			"$each":     topicIDs,
+			"$position": 0,
+			"$slice":    limit,
+		}},
+		"$set": bson.M{"updated_at": time.Now()},
+	}
+	_, err = r.collection.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true))
+	return err
+}
+
+func (r *recentTopicRepository) Get(ctx context.Context, userID string) ([]string, error) {
+	var recent model.RecentTopics
+	err := r.collection.FindOne(ctx, bson.M{"_id": userID}).Decode(&recent)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return recent.TopicIDs, nil
+}
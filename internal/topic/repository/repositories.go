@@ -0,0 +1,85 @@
+package repository
+
+import "go.mongodb.org/mongo-driver/mongo"
+
+// Repositories gom các repository của service để router không phụ thuộc vào backend lưu trữ
+type Repositories struct {
+	Topic       TopicRepository
+	TopicItem   TopicItemRepository
+	Curriculum  CurriculumRepository
+	Redirect    TopicRedirectRepository
+	Audit       TopicAuditRepository
+	ViewStat    TopicViewStatRepository
+	Favorite    TopicFavoriteRepository
+	RecentTopic RecentTopicRepository
+	Comment     TopicCommentRepository
+	Rating      TopicRatingRepository
+	CoView      TopicCoViewRepository
+	Related     TopicRelatedRepository
+	Outbox      OutboxRepository
+	// WebhookSubscriptions và WebhookDeliveries lưu đăng ký callback của đối tác và nhật ký gửi
+	WebhookSubscriptions WebhookSubscriptionRepository
+	WebhookDeliveries    WebhookDeliveryRepository
+	// ProcessedEvents chống xử lý lặp event nhận từ service khác
+	ProcessedEvents ProcessedEventRepository
+	// Leases giữ quyền chạy độc quyền giữa các instance
+	Leases LeaseRepository
+	// Transactions gom thay đổi topic và outbox event vào cùng một transaction
+	Transactions TransactionManager
+}
+
+func NewMongoRepositories(mongoDB *mongo.Database) *Repositories {
+	return &Repositories{
+		Topic:       NewTopicRepository(mongoDB.Collection("topics")),
+		TopicItem:   NewTopicItemRepository(mongoDB.Collection("topic_items")),
+		Curriculum:  NewCurriculumRepository(mongoDB.Collection("curricula")),
+		Redirect:    NewTopicRedirectRepository(mongoDB.Collection("topic_redirects")),
+		Audit:       NewTopicAuditRepository(mongoDB.Collection("topic_audit_logs")),
+		ViewStat:    NewTopicViewStatRepository(mongoDB.Collection("topic_view_stats")),
+		Favorite:    NewTopicFavoriteRepository(mongoDB.Collection("topic_favorites")),
+		RecentTopic: NewRecentTopicRepository(mongoDB.Collection("topic_recent_views")),
+		Comment:     NewTopicCommentRepository(mongoDB.Collection("topic_comments")),
+		Rating:      NewTopicRatingRepository(mongoDB.Collection("topic_ratings")),
+		CoView:      NewTopicCoViewRepository(mongoDB.Collection("topic_co_views")),
+		Related:     NewTopicRelatedRepository(mongoDB.Collection("topic_related")),
+		Outbox:      NewOutboxRepository(mongoDB.Collection("topic_outbox"), mongoDB.Collection("topic_outbox_lease")),
+
+		WebhookSubscriptions: NewWebhookSubscriptionRepository(mongoDB.Collection("webhook_subscriptions")),
+		WebhookDeliveries:    NewWebhookDeliveryRepository(mongoDB.Collection("webhook_deliveries")),
+		ProcessedEvents:      NewProcessedEventRepository(mongoDB.Collection("processed_events")),
+		Leases:               NewLeaseRepository(mongoDB.Collection("topic_leases")),
+
+		Transactions: NewMongoTransactionManager(mongoDB.Client()),
+	}
+}
+
+// NewMongoTopicProjection ghi projection vào cùng collection topics mà NewMongoRepositories đọc
+func NewMongoTopicProjection(mongoDB *mongo.Database) TopicProjectionRepository {
+	return NewTopicProjectionRepository(mongoDB.Collection("topics"), mongoDB.Collection("projection_checkpoints"))
+}
+
+// NewMemoryRepositories dùng cho chế độ --dev: dữ liệu chỉ nằm trong bộ nhớ và mất khi tắt service
+func NewMemoryRepositories() *Repositories {
+	return &Repositories{
+		Topic:       NewMemoryTopicRepository(),
+		TopicItem:   NewMemoryTopicItemRepository(),
+		Curriculum:  NewMemoryCurriculumRepository(),
+		Redirect:    NewMemoryTopicRedirectRepository(),
+		Audit:       NewMemoryTopicAuditRepository(),
+		ViewStat:    NewMemoryTopicViewStatRepository(),
+		Favorite:    NewMemoryTopicFavoriteRepository(),
+		RecentTopic: NewMemoryRecentTopicRepository(),
+		Comment:     NewMemoryTopicCommentRepository(),
+		Rating:      NewMemoryTopicRatingRepository(),
+		CoView:      NewMemoryTopicCoViewRepository(),
+		Related:     NewMemoryTopicRelatedRepository(),
+		Outbox:      NewMemoryOutboxRepository(),
+
+		WebhookSubscriptions: NewMemoryWebhookSubscriptionRepository(),
+		WebhookDeliveries:    NewMemoryWebhookDeliveryRepository(),
+		ProcessedEvents:      NewMemoryProcessedEventRepository(),
+		Leases:               NewMemoryLeaseRepository(),
+
+		Transactions: NewMemoryTransactionManager(),
+	}
+}
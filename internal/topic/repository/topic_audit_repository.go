@@ -0,0 +1,53 @@
+package repository
+
+import (
+	"context"
+	"topic-service/internal/topic/model"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type TopicAuditRepository interface {
+	Create(ctx context.Context, log *model.TopicAuditLog) error
+	ListByTopic(ctx context.Context, topicID string) ([]*model.TopicAuditLog, error)
+}
+
+type topicAuditRepository struct {
+	collection *mongo.Collection
+}
+
+func NewTopicAuditRepository(collection *mongo.Collection) TopicAuditRepository {
+	return &topicAuditRepository{collection}
+}
+
+func (r *topicAuditRepository) Create(ctx context.Context, log *model.TopicAuditLog) error {
+	_, err := r.collection.InsertOne(ctx, log)
+	return err
+}
+
+// ListByTopic trả về lịch sử của topic, bao gồm cả các bản ghi mà topic là topic bị gộp
+func (r *topicAuditRepository) ListByTopic(ctx context.Context, topicID string) ([]*model.TopicAuditLog, error) {
+	filter := bson.M{"$or": bson.A{
+		bson.M{"topic_id": topicID},
+		bson.M{"details.merged_id": topicID},
+	}}
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}})
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var logs []*model.TopicAuditLog
+	for cursor.Next(ctx) {
+		var log model.TopicAuditLog
+		if err := cursor.Decode(&log); err != nil {
+			return nil, err
+		}
+		logs = append(logs, &log)
+	}
+	return logs, nil
+}
@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"context"
+	"topic-service/internal/topic/model"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type TopicCoViewRepository interface {
+	IncrementPairs(ctx context.Context, pairs []model.TopicCoView) error
+	GetAll(ctx context.Context) ([]model.TopicCoView, error)
+}
+
+type topicCoViewRepository struct {
+	collection *mongo.Collection
+}
+
+func NewTopicCoViewRepository(collection *mongo.Collection) TopicCoViewRepository {
+	return &topicCoViewRepository{collection}
+}
+
+// IncrementPairs cộng dồn số lần xem cùng nhau của từng cặp topic, tạo mới nếu chưa có
+func (r *topicCoViewRepository) IncrementPairs(ctx context.Context, pairs []model.TopicCoView) error {
+	if len(pairs) == 0 {
+		return nil
+	}
+
+	writes := make([]mongo.WriteModel, 0, len(pairs))
+	for _, pair := range pairs {
+		writes = append(writes, mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"topic_a": pair.TopicA, "topic_b": pair.TopicB}).
+			SetUpdate(bson.M{"$inc": bson.M{"count": pair.Count}}).
+			SetUpsert(true))
+	}
+
+	_, err := r.collection.BulkWrite(ctx, writes, options.BulkWrite().SetOrdered(false))
+	return err
+}
+
+func (r *topicCoViewRepository) GetAll(ctx context.Context) ([]model.TopicCoView, error) {
+	cursor, err := r.collection.Find(ctx, bson.M{})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var pairs []model.TopicCoView
+	if err := cursor.All(ctx, &pairs); err != nil {
+		return nil, err
+	}
+	return pairs, nil
+}
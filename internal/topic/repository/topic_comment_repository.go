@@ -0,0 +1,157 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+	"topic-service/internal/topic/model"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type TopicCommentRepository interface {
+	Create(ctx context.Context, comment *model.TopicComment) (*model.TopicComment, error)
+	GetByID(ctx context.Context, topicID, commentID string) (*model.TopicComment, error)
+	ListRoots(ctx context.Context, topicID string, skip, limit int64) ([]*model.TopicComment, error)
+	CountRoots(ctx context.Context, topicID string) (int64, error)
+	ListReplies(ctx context.Context, topicID string, rootIDs []string) ([]*model.TopicComment, error)
+	UpdateContent(ctx context.Context, topicID, commentID, content string, mentions []model.CommentMention) error
+	SoftDelete(ctx context.Context, topicID, commentID string) error
+	SetHidden(ctx context.Context, topicID, commentID string, hidden bool, moderatorID string) error
+	DeleteByTopic(ctx context.Context, topicID string) error
+	MoveToTopic(ctx context.Context, fromTopicID, toTopicID string) error
+}
+
+type topicCommentRepository struct {
+	collection *mongo.Collection
+}
+
+func NewTopicCommentRepository(collection *mongo.Collection) TopicCommentRepository {
+	return &topicCommentRepository{collection}
+}
+
+func (r *topicCommentRepository) Create(ctx context.Context, comment *model.TopicComment) (*model.TopicComment, error) {
+	_, err := r.collection.InsertOne(ctx, comment)
+	if err != nil {
+		return nil, err
+	}
+	return comment, nil
+}
+
+func (r *topicCommentRepository) GetByID(ctx context.Context, topicID, commentID string) (*model.TopicComment, error) {
+	objectID, err := primitive.ObjectIDFromHex(commentID)
+	if err != nil {
+		return nil, errors.New("invalid ID format")
+	}
+
+	var comment model.TopicComment
+	err = r.collection.FindOne(ctx, bson.M{"_id": objectID, "topic_id": topicID}).Decode(&comment)
+	if err != nil {
+		return nil, err
+	}
+	return &comment, nil
+}
+
+// ListRoots trả về các bình luận gốc, mới nhất trước
+func (r *topicCommentRepository) ListRoots(ctx context.Context, topicID string, skip, limit int64) ([]*model.TopicComment, error) {
+	opts := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}}).
+		SetSkip(skip).
+		SetLimit(limit)
+
+	return r.find(ctx, bson.M{"topic_id": topicID, "parent_id": bson.M{"$exists": false}}, opts)
+}
+
+func (r *topicCommentRepository) CountRoots(ctx context.Context, topicID string) (int64, error) {
+	return r.collection.CountDocuments(ctx, bson.M{"topic_id": topicID, "parent_id": bson.M{"$exists": false}})
+}
+
+// ListReplies trả về mọi reply thuộc các thread rootIDs, cũ nhất trước
+func (r *topicCommentRepository) ListReplies(ctx context.Context, topicID string, rootIDs []string) ([]*model.TopicComment, error) {
+	if len(rootIDs) == 0 {
+		return nil, nil
+	}
+
+	filter := bson.M{
+		"topic_id":  topicID,
+		"root_id":   bson.M{"$in": rootIDs},
+		"parent_id": bson.M{"$exists": true},
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}, {Key: "_id", Value: 1}})
+
+	return r.find(ctx, filter, opts)
+}
+
+func (r *topicCommentRepository) UpdateContent(ctx context.Context, topicID, commentID, content string, mentions []model.CommentMention) error {
+	now := time.Now()
+	return r.updateOne(ctx, topicID, commentID, bson.M{"$set": bson.M{
+		"content":    content,
+		"mentions":   mentions,
+		"edited_at":  now,
+		"updated_at": now,
+	}})
+}
+
+// SoftDelete giữ lại bản ghi để các reply vẫn nằm đúng thread
+func (r *topicCommentRepository) SoftDelete(ctx context.Context, topicID, commentID string) error {
+	return r.updateOne(ctx, topicID, commentID, bson.M{"$set": bson.M{
+		"deleted":    true,
+		"content":    "",
+		"mentions":   []model.CommentMention{},
+		"updated_at": time.Now(),
+	}})
+}
+
+func (r *topicCommentRepository) SetHidden(ctx context.Context, topicID, commentID string, hidden bool, moderatorID string) error {
+	return r.updateOne(ctx, topicID, commentID, bson.M{"$set": bson.M{
+		"hidden":     hidden,
+		"hidden_by":  moderatorID,
+		"updated_at": time.Now(),
+	}})
+}
+
+func (r *topicCommentRepository) DeleteByTopic(ctx context.Context, topicID string) error {
+	_, err := r.collection.DeleteMany(ctx, bson.M{"topic_id": topicID})
+	return err
+}
+
+func (r *topicCommentRepository) MoveToTopic(ctx context.Context, fromTopicID, toTopicID string) error {
+	_, err := r.collection.UpdateMany(ctx,
+		bson.M{"topic_id": fromTopicID},
+		bson.M{"$set": bson.M{"topic_id": toTopicID}},
+	)
+	return err
+}
+
+func (r *topicCommentRepository) updateOne(ctx context.Context, topicID, commentID string, update bson.M) error {
+	objectID, err := primitive.ObjectIDFromHex(commentID)
+	if err != nil {
+		return errors.New("invalid ID format")
+	}
+
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": objectID, "topic_id": topicID}, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+func (r *topicCommentRepository) find(ctx context.Context, filter bson.M, opts ...*options.FindOptions) ([]*model.TopicComment, error) {
+	cursor, err := r.collection.Find(ctx, filter, opts...)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var comments []*model.TopicComment
+	if err := cursor.All(ctx, &comments); err != nil {
+		return nil, err
+	}
+	return comments, nil
+}
@@ -0,0 +1,131 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"math"
+	"strings"
+	"topic-service/internal/topic/model"
+
+	"github.com/EventStore/EventStore-Client-Go/esdb"
+)
+
+// ErrStreamConflict: stream đã có event mới hơn version mà lệnh đọc được, cần đọc lại rồi thử lại
+var ErrStreamConflict = errors.New("topic stream was modified concurrently")
+
+// TopicEventStore lưu event của topic trên EventStoreDB, mỗi topic một stream
+type TopicEventStore interface {
+	// Load dựng lại topic từ stream, stream chưa tồn tại thì trả về aggregate có Version -1
+	Load(ctx context.Context, id string) (*model.TopicAggregate, error)
+	// Append ghi event nếu stream vẫn đang ở expectedVersion (-1 là chưa có stream), trả về version mới
+	Append(ctx context.Context, id string, expectedVersion int64, events ...model.TopicStreamEvent) (int64, error)
+	// Subscribe theo dõi event của mọi stream topic sau vị trí from, from nil thì đọc từ đầu
+	Subscribe(ctx context.Context, from *model.ProjectionCheckpoint) (*esdb.Subscription, error)
+	// ReadAll đọc lần lượt mọi event của các stream topic từ đầu
+	ReadAll(ctx context.Context, fn func(event *esdb.ResolvedEvent) error) error
+}
+
+type topicEventStore struct {
+	client *esdb.Client
+}
+
+func NewTopicEventStore(client *esdb.Client) TopicEventStore {
+	return &topicEventStore{client: client}
+}
+
+func (s *topicEventStore) Load(ctx context.Context, id string) (*model.TopicAggregate, error) {
+	aggregate := model.NewTopicAggregate()
+
+	stream, err := s.client.ReadStream(ctx, model.TopicStreamID(id), esdb.ReadStreamOptions{}, math.MaxUint64)
+	if errors.Is(err, esdb.ErrStreamNotFound) {
+		return aggregate, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer stream.Close()
+
+	for {
+		event, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return aggregate, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if err := aggregate.Apply(event.Event.EventType, event.Event.Data); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (s *topicEventStore) Append(ctx context.Context, id string, expectedVersion int64, events ...model.TopicStreamEvent) (int64, error) {
+	data := make([]esdb.EventData, 0, len(events))
+	for _, event := range events {
+		payload, err := json.Marshal(event.Data)
+		if err != nil {
+			return 0, err
+		}
+		data = append(data, esdb.EventData{
+			EventType:   event.Type,
+			ContentType: esdb.JsonContentType,
+			Data:        payload,
+		})
+	}
+
+	var expected esdb.ExpectedRevision = esdb.NoStream{}
+	if expectedVersion >= 0 {
+		expected = esdb.Revision(uint64(expectedVersion))
+	}
+
+	result, err := s.client.AppendToStream(ctx, model.TopicStreamID(id), esdb.AppendToStreamOptions{ExpectedRevision: expected}, data...)
+	if errors.Is(err, esdb.ErrWrongExpectedStreamRevision) {
+		return 0, ErrStreamConflict
+	}
+	if err != nil {
+		return 0, err
+	}
+	return int64(result.NextExpectedVersion), nil
+}
+
+func (s *topicEventStore) Subscribe(ctx context.Context, from *model.ProjectionCheckpoint) (*esdb.Subscription, error) {
+	var position esdb.AllPosition = esdb.Start{}
+	if from != nil {
+		position = esdb.Position{Commit: from.CommitPosition, Prepare: from.PreparePosition}
+	}
+
+	return s.client.SubscribeToAll(ctx, esdb.SubscribeToAllOptions{
+		From: position,
+		Filter: &esdb.SubscriptionFilter{
+			Type:     esdb.StreamFilterType,
+			Prefixes: []string{model.TopicStreamPrefix},
+		},
+	})
+}
+
+func (s *topicEventStore) ReadAll(ctx context.Context, fn func(event *esdb.ResolvedEvent) error) error {
+	stream, err := s.client.ReadAll(ctx, esdb.ReadAllOptions{}, math.MaxUint64)
+	if err != nil {
+		return err
+	}
+	defer stream.Close()
+
+	for {
+		event, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		// $all chứa cả event hệ thống và stream của service khác
+		if event.Event == nil || !strings.HasPrefix(event.Event.StreamID, model.TopicStreamPrefix) {
+			continue
+		}
+		if err := fn(event); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,131 @@
+package repository
+
+import (
+	"context"
+	"time"
+	"topic-service/internal/topic/model"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type TopicFavoriteRepository interface {
+	Add(ctx context.Context, userID, topicID string) error
+	Remove(ctx context.Context, userID, topicID string) error
+	ListByUser(ctx context.Context, userID string) ([]*model.TopicFavorite, error)
+	FavoriteTopicIDs(ctx context.Context, userID string, topicIDs []string) (map[string]bool, error)
+	DeleteByTopic(ctx context.Context, topicID string) error
+	ReplaceTopic(ctx context.Context, oldTopicID, newTopicID string) error
+}
+
+type topicFavoriteRepository struct {
+	collection *mongo.Collection
+}
+
+func NewTopicFavoriteRepository(collection *mongo.Collection) TopicFavoriteRepository {
+	return &topicFavoriteRepository{collection}
+}
+
+// Add là idempotent: thêm lại một favorite đã có không tạo bản ghi mới
+func (r *topicFavoriteRepository) Add(ctx context.Context, userID, topicID string) error {
+	filter := bson.M{"user_id": userID, "topic_id": topicID}
+	update := bson.M{"$setOnInsert": bson.M{
+		"user_id":    userID,
+		"topic_id":   topicID,
+		"created_at": time.Now(),
+	}}
+
+	_, err := r.collection.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true))
+	return err
+}
+
+func (r *topicFavoriteRepository) Remove(ctx context.Context, userID, topicID string) error {
+	result, err := r.collection.DeleteOne(ctx, bson.M{"user_id": userID, "topic_id": topicID})
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+func (r *topicFavoriteRepository) ListByUser(ctx context.Context, userID string) ([]*model.TopicFavorite, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}})
+	cursor, err := r.collection.Find(ctx, bson.M{"user_id": userID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var favorites []*model.TopicFavorite
+	for cursor.Next(ctx) {
+		var favorite model.TopicFavorite
+		if err := cursor.Decode(&favorite); err != nil {
+			return nil, err
+		}
+		favorites = append(favorites, &favorite)
+	}
+	return favorites, nil
+}
+
+// FavoriteTopicIDs trả về trong một query những topic nào thuộc topicIDs được user đánh dấu yêu thích
+func (r *topicFavoriteRepository) FavoriteTopicIDs(ctx context.Context, userID string, topicIDs []string) (map[string]bool, error) {
+	result := make(map[string]bool)
+	if userID == "" || len(topicIDs) == 0 {
+		return result, nil
+	}
+
+	filter := bson.M{"user_id": userID, "topic_id": bson.M{"$in": topicIDs}}
+	opts := options.Find().SetProjection(bson.M{"topic_id": 1})
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var favorite model.TopicFavorite
+		if err := cursor.Decode(&favorite); err != nil {
+			return nil, err
+		}
+		result[favorite.TopicID] = true
+	}
+	return result, nil
+}
+
+func (r *topicFavoriteRepository) DeleteByTopic(ctx context.Context, topicID string) error {
+	_, err := r.collection.DeleteMany(ctx, bson.M{"topic_id": topicID})
+	return err
+}
+
+// ReplaceTopic chuyển favorite sang topic mới, bỏ bản ghi trùng nếu user đã thích cả hai topic
+func (r *topicFavoriteRepository) ReplaceTopic(ctx context.Context, oldTopicID, newTopicID string) error {
+	favorites, err := r.find(ctx, bson.M{"topic_id": oldTopicID})
+	if err != nil {
+		return err
+	}
+
+	for _, favorite := range favorites {
+		if err := r.Add(ctx, favorite.UserID, newTopicID); err != nil {
+			return err
+		}
+	}
+
+	return r.DeleteByTopic(ctx, oldTopicID)
+}
+
+func (r *topicFavoriteRepository) find(ctx context.Context, filter bson.M) ([]*model.TopicFavorite, error) {
+	cursor, err := r.collection.Find(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var favorites []*model.TopicFavorite
+	if err := cursor.All(ctx, &favorites); err != nil {
+		return nil, err
+	}
+	return favorites, nil
+}
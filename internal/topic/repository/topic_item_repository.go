@@ -0,0 +1,226 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+	"topic-service/internal/topic/model"
+	"topic-service/pkg/constants"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type TopicItemRepository interface {
+	Create(ctx context.Context, item *model.TopicItem) (*model.TopicItem, error)
+	CreateMany(ctx context.Context, items []*model.TopicItem) error
+	GetByID(ctx context.Context, topicID, itemID string) (*model.TopicItem, error)
+	ListByTopic(ctx context.Context, topicID string) ([]*model.TopicItem, error)
+	MaxOrder(ctx context.Context, topicID string) (int, error)
+	Update(ctx context.Context, topicID, itemID string, item *model.TopicItem) error
+	UpdateOrder(ctx context.Context, topicID string, itemIDs []string) error
+	Delete(ctx context.Context, topicID, itemID string) error
+	DeleteByTopic(ctx context.Context, topicID string) error
+	MoveToTopic(ctx context.Context, fromTopicID, toTopicID string, orderOffset int) (int64, error)
+}
+
+type topicItemRepository struct {
+	collection *mongo.Collection
+}
+
+func NewTopicItemRepository(collection *mongo.Collection) TopicItemRepository {
+	return &topicItemRepository{collection}
+}
+
+func (r *topicItemRepository) Create(ctx context.Context, item *model.TopicItem) (*model.TopicItem, error) {
+	_, err := r.collection.InsertOne(ctx, item)
+	if err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+func (r *topicItemRepository) CreateMany(ctx context.Context, items []*model.TopicItem) error {
+	if len(items) == 0 {
+		return nil
+	}
+
+	docs := make([]interface{}, 0, len(items))
+	for _, item := range items {
+		docs = append(docs, item)
+	}
+
+	_, err := r.collection.InsertMany(ctx, docs)
+	return err
+}
+
+func (r *topicItemRepository) GetByID(ctx context.Context, topicID, itemID string) (*model.TopicItem, error) {
+	filter, err := itemFilter(topicID, itemID)
+	if err != nil {
+		return nil, err
+	}
+
+	var item model.TopicItem
+	err = r.collection.FindOne(ctx, filter).Decode(&item)
+	if err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+func (r *topicItemRepository) ListByTopic(ctx context.Context, topicID string) ([]*model.TopicItem, error) {
+	topicObjectID, err := primitive.ObjectIDFromHex(topicID)
+	if err != nil {
+		return nil, errors.New("invalid ID format")
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "order", Value: 1}, {Key: "_id", Value: 1}})
+	cursor, err := r.collection.Find(ctx, bson.M{constants.TopicID: topicObjectID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var items []*model.TopicItem
+	for cursor.Next(ctx) {
+		var item model.TopicItem
+		if err := cursor.Decode(&item); err != nil {
+			return nil, err
+		}
+		items = append(items, &item)
+	}
+	return items, nil
+}
+
+// MaxOrder trả về thứ tự lớn nhất hiện có của topic, -1 nếu topic chưa có item
+func (r *topicItemRepository) MaxOrder(ctx context.Context, topicID string) (int, error) {
+	topicObjectID, err := primitive.ObjectIDFromHex(topicID)
+	if err != nil {
+		return 0, errors.New("invalid ID format")
+	}
+
+	opts := options.FindOne().SetSort(bson.D{{Key: "order", Value: -1}})
+	var item model.TopicItem
+	err = r.collection.FindOne(ctx, bson.M{constants.TopicID: topicObjectID}, opts).Decode(&item)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return -1, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return item.Order, nil
+}
+
+func (r *topicItemRepository) Update(ctx context.Context, topicID, itemID string, updated *model.TopicItem) error {
+	filter, err := itemFilter(topicID, itemID)
+	if err != nil {
+		return err
+	}
+
+	updated.UpdatedAt = time.Now()
+
+	update := bson.M{
+		"$set": bson.M{
+			"word":               updated.Word,
+			constants.Images:     updated.Images,
+			constants.CoverImage: updated.CoverImage,
+			"audio_url":          updated.AudioUrl,
+			constants.VideoUrl:   updated.VideoUrl,
+			"updated_at":         updated.UpdatedAt,
+		},
+	}
+
+	result, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+// UpdateOrder gán lại thứ tự cho các item theo đúng vị trí trong itemIDs
+func (r *topicItemRepository) UpdateOrder(ctx context.Context, topicID string, itemIDs []string) error {
+	if len(itemIDs) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	writes := make([]mongo.WriteModel, 0, len(itemIDs))
+	for order, itemID := range itemIDs {
+		filter, err := itemFilter(topicID, itemID)
+		if err != nil {
+			return err
+		}
+		writes = append(writes, mongo.NewUpdateOneModel().
+			SetFilter(filter).
+			SetUpdate(bson.M{"$set": bson.M{"order": order, "updated_at": now}}))
+	}
+
+	_, err := r.collection.BulkWrite(ctx, writes, options.BulkWrite().SetOrdered(false))
+	return err
+}
+
+func (r *topicItemRepository) Delete(ctx context.Context, topicID, itemID string) error {
+	filter, err := itemFilter(topicID, itemID)
+	if err != nil {
+		return err
+	}
+
+	result, err := r.collection.DeleteOne(ctx, filter)
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+func (r *topicItemRepository) DeleteByTopic(ctx context.Context, topicID string) error {
+	topicObjectID, err := primitive.ObjectIDFromHex(topicID)
+	if err != nil {
+		return errors.New("invalid ID format")
+	}
+
+	_, err = r.collection.DeleteMany(ctx, bson.M{constants.TopicID: topicObjectID})
+	return err
+}
+
+// MoveToTopic chuyển toàn bộ item sang topic khác, dời thứ tự ra sau các item sẵn có
+func (r *topicItemRepository) MoveToTopic(ctx context.Context, fromTopicID, toTopicID string, orderOffset int) (int64, error) {
+	fromObjectID, err := primitive.ObjectIDFromHex(fromTopicID)
+	if err != nil {
+		return 0, errors.New("invalid ID format")
+	}
+	toObjectID, err := primitive.ObjectIDFromHex(toTopicID)
+	if err != nil {
+		return 0, errors.New("invalid ID format")
+	}
+
+	update := bson.M{
+		"$set": bson.M{constants.TopicID: toObjectID, "updated_at": time.Now()},
+		"$inc": bson.M{"order": orderOffset},
+	}
+
+	result, err := r.collection.UpdateMany(ctx, bson.M{constants.TopicID: fromObjectID}, update)
+	if err != nil {
+		return 0, err
+	}
+	return result.ModifiedCount, nil
+}
+
+func itemFilter(topicID, itemID string) (bson.M, error) {
+	topicObjectID, err := primitive.ObjectIDFromHex(topicID)
+	if err != nil {
+		return nil, errors.New("invalid ID format")
+	}
+	itemObjectID, err := primitive.ObjectIDFromHex(itemID)
+	if err != nil {
+		return nil, errors.New("invalid ID format")
+	}
+	return bson.M{"_id": itemObjectID, constants.TopicID: topicObjectID}, nil
+}
@@ -0,0 +1,138 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+	"topic-service/internal/topic/model"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// TopicProjectionRepository ghi trạng thái topic dựng từ event vào collection topics mà TopicRepository vẫn đọc.
+// Mỗi document có thêm es_version là version stream đã được chiếu, event cũ hơn nhận lại sẽ bị bỏ qua.
+type TopicProjectionRepository interface {
+	// Get trả về topic đang được chiếu, Version -1 với document có từ trước khi bật event sourcing
+	Get(ctx context.Context, id string) (*model.TopicAggregate, error)
+	// Version trả về es_version của document, found false khi document không còn
+	Version(ctx context.Context, id string) (version int64, found bool, err error)
+	// Save ghi đè document nếu nó đang ở version cũ hơn, aggregate đã xoá thì xoá document
+	Save(ctx context.Context, aggregate *model.TopicAggregate) error
+	// Clear xoá toàn bộ document để dựng lại projection từ đầu
+	Clear(ctx context.Context) error
+	GetCheckpoint(ctx context.Context, name string) (*model.ProjectionCheckpoint, error)
+	SaveCheckpoint(ctx context.Context, checkpoint *model.ProjectionCheckpoint) error
+	DeleteCheckpoint(ctx context.Context, name string) error
+}
+
+// topicProjection là document trong collection topics kèm version stream, Version nil với document cũ
+type topicProjection struct {
+	model.Topic `bson:",inline"`
+	Version     *int64 `bson:"es_version,omitempty"`
+}
+
+type topicProjectionRepository struct {
+	collection  *mongo.Collection
+	checkpoints *mongo.Collection
+}
+
+func NewTopicProjectionRepository(collection, checkpoints *mongo.Collection) TopicProjectionRepository {
+	return &topicProjectionRepository{collection: collection, checkpoints: checkpoints}
+}
+
+func (r *topicProjectionRepository) Get(ctx context.Context, id string) (*model.TopicAggregate, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, errors.New("invalid ID format")
+	}
+
+	var doc topicProjection
+	if err := r.collection.FindOne(ctx, bson.M{"_id": objectID}).Decode(&doc); err != nil {
+		return nil, err
+	}
+
+	aggregate := model.NewTopicAggregate()
+	aggregate.Topic = doc.Topic
+	if doc.Version != nil {
+		aggregate.Version = *doc.Version
+	}
+	return aggregate, nil
+}
+
+func (r *topicProjectionRepository) Version(ctx context.Context, id string) (int64, bool, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return 0, false, errors.New("invalid ID format")
+	}
+
+	var doc struct {
+		Version *int64 `bson:"es_version"`
+	}
+	opts := options.FindOne().SetProjection(bson.M{"es_version": 1})
+	err = r.collection.FindOne(ctx, bson.M{"_id": objectID}, opts).Decode(&doc)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return -1, false, nil
+	}
+	if err != nil {
+		return 0, false, err
+	}
+	if doc.Version == nil {
+		return -1, true, nil
+	}
+	return *doc.Version, true, nil
+}
+
+func (r *topicProjectionRepository) Save(ctx context.Context, aggregate *model.TopicAggregate) error {
+	filter := bson.M{
+		"_id": aggregate.Topic.ID,
+		"$or": bson.A{
+			bson.M{"es_version": bson.M{"$lt": aggregate.Version}},
+			bson.M{"es_version": bson.M{"$exists": false}},
+		},
+	}
+
+	if aggregate.Deleted {
+		_, err := r.collection.DeleteOne(ctx, filter)
+		return err
+	}
+
+	version := aggregate.Version
+	doc := topicProjection{Topic: aggregate.Topic, Version: &version}
+	_, err := r.collection.ReplaceOne(ctx, filter, doc, options.Replace().SetUpsert(true))
+	// Document đã ở version mới hơn nên filter không khớp và upsert trùng _id, không cần ghi nữa
+	if mongo.IsDuplicateKeyError(err) {
+		return nil
+	}
+	return err
+}
+
+func (r *topicProjectionRepository) Clear(ctx context.Context) error {
+	_, err := r.collection.DeleteMany(ctx, bson.M{})
+	return err
+}
+
+func (r *topicProjectionRepository) GetCheckpoint(ctx context.Context, name string) (*model.ProjectionCheckpoint, error) {
+	var checkpoint model.ProjectionCheckpoint
+	err := r.checkpoints.FindOne(ctx, bson.M{"_id": name}).Decode(&checkpoint)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &checkpoint, nil
+}
+
+func (r *topicProjectionRepository) SaveCheckpoint(ctx context.Context, checkpoint *model.ProjectionCheckpoint) error {
+	checkpoint.UpdatedAt = time.Now()
+	_, err := r.checkpoints.ReplaceOne(ctx, bson.M{"_id": checkpoint.Name}, checkpoint, options.Replace().SetUpsert(true))
+	return err
+}
+
+func (r *topicProjectionRepository) DeleteCheckpoint(ctx context.Context, name string) error {
+	_, err := r.checkpoints.DeleteOne(ctx, bson.M{"_id": name})
+	return err
+}
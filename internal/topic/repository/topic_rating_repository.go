@@ -0,0 +1,146 @@
+package repository
+
+import (
+	"context"
+	"time"
+	"topic-service/internal/topic/model"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type TopicRatingRepository interface {
+	// Upsert ghi đánh giá của user và trả về bản ghi trước đó (nil nếu là đánh giá mới)
+	Upsert(ctx context.Context, rating *model.TopicRating) (*model.TopicRating, error)
+	GetByUser(ctx context.Context, topicID, userID string) (*model.TopicRating, error)
+	Delete(ctx context.Context, topicID, userID string) (*model.TopicRating, error)
+	ListByTopic(ctx context.Context, topicID string, skip, limit int64) ([]*model.TopicRating, error)
+	CountByTopic(ctx context.Context, topicID string) (int64, error)
+	DeleteByTopic(ctx context.Context, topicID string) error
+	MoveToTopic(ctx context.Context, fromTopicID, toTopicID string) (model.RatingTotals, error)
+}
+
+type topicRatingRepository struct {
+	collection *mongo.Collection
+}
+
+func NewTopicRatingRepository(collection *mongo.Collection) TopicRatingRepository {
+	return &topicRatingRepository{collection}
+}
+
+func (r *topicRatingRepository) Upsert(ctx context.Context, rating *model.TopicRating) (*model.TopicRating, error) {
+	filter := bson.M{"topic_id": rating.TopicID, "user_id": rating.UserID}
+	update := bson.M{
+		"$set": bson.M{
+			"score":      rating.Score,
+			"feedback":   rating.Feedback,
+			"updated_at": rating.UpdatedAt,
+		},
+		"$setOnInsert": bson.M{"created_at": rating.CreatedAt},
+	}
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.Before)
+
+	var previous model.TopicRating
+	err := r.collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&previous)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &previous, nil
+}
+
+func (r *topicRatingRepository) GetByUser(ctx context.Context, topicID, userID string) (*model.TopicRating, error) {
+	var rating model.TopicRating
+	err := r.collection.FindOne(ctx, bson.M{"topic_id": topicID, "user_id": userID}).Decode(&rating)
+	if err != nil {
+		return nil, err
+	}
+	return &rating, nil
+}
+
+// Delete xoá đánh giá và trả về bản ghi đã xoá để cập nhật aggregate
+func (r *topicRatingRepository) Delete(ctx context.Context, topicID, userID string) (*model.TopicRating, error) {
+	var deleted model.TopicRating
+	err := r.collection.FindOneAndDelete(ctx, bson.M{"topic_id": topicID, "user_id": userID}).Decode(&deleted)
+	if err != nil {
+		return nil, err
+	}
+	return &deleted, nil
+}
+
+// ListByTopic trả về đánh giá mới cập nhật nhất trước
+func (r *topicRatingRepository) ListByTopic(ctx context.Context, topicID string, skip, limit int64) ([]*model.TopicRating, error) {
+	opts := options.Find().
+		SetSort(bson.D{{Key: "updated_at", Value: -1}, {Key: "_id", Value: -1}}).
+		SetSkip(skip).
+		SetLimit(limit)
+
+	cursor, err := r.collection.Find(ctx, bson.M{"topic_id": topicID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var ratings []*model.TopicRating
+	if err := cursor.All(ctx, &ratings); err != nil {
+		return nil, err
+	}
+	return ratings, nil
+}
+
+func (r *topicRatingRepository) CountByTopic(ctx context.Context, topicID string) (int64, error) {
+	return r.collection.CountDocuments(ctx, bson.M{"topic_id": topicID})
+}
+
+func (r *topicRatingRepository) DeleteByTopic(ctx context.Context, topicID string) error {
+	_, err := r.collection.DeleteMany(ctx, bson.M{"topic_id": topicID})
+	return err
+}
+
+// MoveToTopic chuyển đánh giá sang topic khác. User đã đánh giá topic đích thì giữ đánh giá ở đích,
+// bản ghi trùng ở topic nguồn bị xoá. Trả về tổng điểm và số lượt của các đánh giá đã chuyển.
+func (r *topicRatingRepository) MoveToTopic(ctx context.Context, fromTopicID, toTopicID string) (model.RatingTotals, error) {
+	var totals model.RatingTotals
+
+	existingUsers, err := r.collection.Distinct(ctx, "user_id", bson.M{"topic_id": toTopicID})
+	if err != nil {
+		return totals, err
+	}
+	if existingUsers == nil {
+		existingUsers = []interface{}{}
+	}
+
+	filter := bson.M{"topic_id": fromTopicID, "user_id": bson.M{"$nin": existingUsers}}
+
+	cursor, err := r.collection.Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$match", Value: filter}},
+		{{Key: "$group", Value: bson.M{
+			"_id":   nil,
+			"sum":   bson.M{"$sum": "$score"},
+			"count": bson.M{"$sum": 1},
+		}}},
+	})
+	if err != nil {
+		return totals, err
+	}
+	defer cursor.Close(ctx)
+	if cursor.Next(ctx) {
+		if err := cursor.Decode(&totals); err != nil {
+			return totals, err
+		}
+	}
+
+	if _, err := r.collection.UpdateMany(ctx, filter, bson.M{"$set": bson.M{
+		"topic_id":   toTopicID,
+		"updated_at": time.Now(),
+	}}); err != nil {
+		return totals, err
+	}
+
+	// Những đánh giá còn lại là bản trùng của user đã đánh giá topic đích
+	_, err = r.collection.DeleteMany(ctx, bson.M{"topic_id": fromTopicID})
+	return totals, err
+}
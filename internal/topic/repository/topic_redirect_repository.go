@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"context"
+	"topic-service/internal/topic/model"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type TopicRedirectRepository interface {
+	Save(ctx context.Context, redirect *model.TopicRedirect) error
+	GetByID(ctx context.Context, id string) (*model.TopicRedirect, error)
+	RepointTargets(ctx context.Context, oldTargetID, newTargetID string) error
+}
+
+type topicRedirectRepository struct {
+	collection *mongo.Collection
+}
+
+func NewTopicRedirectRepository(collection *mongo.Collection) TopicRedirectRepository {
+	return &topicRedirectRepository{collection}
+}
+
+func (r *topicRedirectRepository) Save(ctx context.Context, redirect *model.TopicRedirect) error {
+	_, err := r.collection.ReplaceOne(ctx, bson.M{"_id": redirect.ID}, redirect, options.Replace().SetUpsert(true))
+	return err
+}
+
+func (r *topicRedirectRepository) GetByID(ctx context.Context, id string) (*model.TopicRedirect, error) {
+	var redirect model.TopicRedirect
+	err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&redirect)
+	if err != nil {
+		return nil, err
+	}
+	return &redirect, nil
+}
+
+// RepointTargets giữ cho chuỗi redirect luôn chỉ một bước khi topic đích lại bị gộp tiếp
+func (r *topicRedirectRepository) RepointTargets(ctx context.Context, oldTargetID, newTargetID string) error {
+	_, err := r.collection.UpdateMany(ctx,
+		bson.M{"target_id": oldTargetID},
+		bson.M{"$set": bson.M{"target_id": newTargetID}},
+	)
+	return err
+}
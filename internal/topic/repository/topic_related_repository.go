@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"topic-service/internal/topic/model"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type TopicRelatedRepository interface {
+	SaveAll(ctx context.Context, related []*model.TopicRelated) error
+	Get(ctx context.Context, topicID string) (*model.TopicRelated, error)
+	DeleteStale(ctx context.Context, keepTopicIDs []string) error
+}
+
+type topicRelatedRepository struct {
+	collection *mongo.Collection
+}
+
+func NewTopicRelatedRepository(collection *mongo.Collection) TopicRelatedRepository {
+	return &topicRelatedRepository{collection}
+}
+
+// SaveAll ghi đè danh sách gợi ý của từng topic
+func (r *topicRelatedRepository) SaveAll(ctx context.Context, related []*model.TopicRelated) error {
+	if len(related) == 0 {
+		return nil
+	}
+
+	writes := make([]mongo.WriteModel, 0, len(related))
+	for _, rel := range related {
+		writes = append(writes, mongo.NewReplaceOneModel().
+			SetFilter(bson.M{"_id": rel.TopicID}).
+			SetReplacement(rel).
+			SetUpsert(true))
+	}
+
+	_, err := r.collection.BulkWrite(ctx, writes, options.BulkWrite().SetOrdered(false))
+	return err
+}
+
+// Get trả về nil nếu topic chưa được tính gợi ý
+func (r *topicRelatedRepository) Get(ctx context.Context, topicID string) (*model.TopicRelated, error) {
+	var related model.TopicRelated
+	err := r.collection.FindOne(ctx, bson.M{"_id": topicID}).Decode(&related)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &related, nil
+}
+
+// DeleteStale xoá gợi ý của các topic không còn tồn tại
+func (r *topicRelatedRepository) DeleteStale(ctx context.Context, keepTopicIDs []string) error {
+	if keepTopicIDs == nil {
+		keepTopicIDs = []string{}
+	}
+	_, err := r.collection.DeleteMany(ctx, bson.M{"_id": bson.M{"$nin": keepTopicIDs}})
+	return err
+}
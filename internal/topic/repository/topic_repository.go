@@ -3,20 +3,72 @@ package repository
 import (
 	"context"
 	"errors"
+	"regexp"
+	"strings"
 	"time"
 	"topic-service/internal/topic/model"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
+// TopicFilter lọc và sắp xếp danh sách topic. Sort là tên field, tiền tố "-" để giảm dần.
+// TopicFilter: OrganizationID khác nil thì chỉ trả về topic của tổ chức đó (chuỗi rỗng là user không thuộc
+// tổ chức nào), template và topic chưa gắn tổ chức, giống Actor.CanView
+type TopicFilter struct {
+	OrganizationID *string
+	IsTemplate     *bool
+	MinRating      *float64
+	MaxRating      *float64
+	MinRatingCount int
+	Sort           string
+}
+
+// TopicSearchFilter: Terms phải khớp nguyên từ, Prefix khớp đầu của một từ bất kỳ.
+// OrganizationID giới hạn theo tổ chức giống TopicFilter.
+type TopicSearchFilter struct {
+	Terms          []string
+	Prefix         string
+	OrganizationID *string
+	Limit          int
+}
+
+// topicSortFields ánh xạ tên sort của API sang field trong collection
+var topicSortFields = map[string]string{
+	"rating":       "rating_average",
+	"rating_count": "rating_count",
+	"created_at":   "created_at",
+}
+
 type TopicRepository interface {
 	Create(ctx context.Context, topic *model.Topic) (*model.Topic, error)
 	GetByID(ctx context.Context, id string) (*model.Topic, error)
 	Update(ctx context.Context, id string, topic *model.Topic) error
 	Delete(ctx context.Context, id string) error
 	GetAll(ctx context.Context) ([]*model.Topic, error)
+	List(ctx context.Context, filter TopicFilter) ([]*model.Topic, error)
+	GetByIDs(ctx context.Context, ids []string) ([]*model.Topic, error)
+	IncrementItemCount(ctx context.Context, id string, delta int) error
+	IncrementCommentCount(ctx context.Context, id string, delta int) error
+	ApplyRatingDelta(ctx context.Context, id string, sumDelta, countDelta int) error
+	AddPrerequisite(ctx context.Context, id string, prerequisiteID string) error
+	RemovePrerequisite(ctx context.Context, id string, prerequisiteID string) error
+	RemovePrerequisiteFromAll(ctx context.Context, prerequisiteID string) error
+	SetTemplate(ctx context.Context, id string, isTemplate bool) error
+	GetTemplates(ctx context.Context) ([]*model.Topic, error)
+	ReplacePrerequisite(ctx context.Context, oldID, newID string) error
+	ApplyMerge(ctx context.Context, id string, merged *model.Topic) error
+	Search(ctx context.Context, filter TopicSearchFilter) ([]*model.Topic, error)
+	ListByCreator(ctx context.Context, userID string) ([]*model.Topic, error)
+	SetOwner(ctx context.Context, id string, ownerID string) error
+}
+
+// EmitsOutboxEvents cho biết outbox event của topic đã được tạo từ event stream, service không ghi thêm
+func EmitsOutboxEvents(repo TopicRepository) bool {
+	emitter, ok := repo.(interface{ EmitsOutboxEvents() bool })
+	return ok && emitter.EmitsOutboxEvents()
 }
 
 type topicRepository struct {
@@ -60,8 +112,12 @@ func (r *topicRepository) Update(ctx context.Context, id string, updated *model.
 
 	update := bson.M{
 		"$set": bson.M{
-			"title":      updated.Title,
-			"updated_at": updated.UpdatedAt,
+			"title":          updated.Title,
+			"titles":         updated.Titles,
+			"descriptions":   updated.Descriptions,
+			"default_locale": updated.DefaultLocale,
+			"search_terms":   updated.SearchTerms,
+			"updated_at":     updated.UpdatedAt,
 		},
 	}
 
@@ -91,6 +147,36 @@ func (r *topicRepository) Delete(ctx context.Context, id string) error {
 	return nil
 }
 
+func (r *topicRepository) GetByIDs(ctx context.Context, ids []string) ([]*model.Topic, error) {
+	objectIDs := make([]primitive.ObjectID, 0, len(ids))
+	for _, id := range ids {
+		objectID, err := primitive.ObjectIDFromHex(id)
+		if err != nil {
+			return nil, errors.New("invalid ID format")
+		}
+		objectIDs = append(objectIDs, objectID)
+	}
+	if len(objectIDs) == 0 {
+		return nil, nil
+	}
+
+	cursor, err := r.collection.Find(ctx, bson.M{"_id": bson.M{"$in": objectIDs}})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var topics []*model.Topic
+	for cursor.Next(ctx) {
+		var topic model.Topic
+		if err := cursor.Decode(&topic); err != nil {
+			return nil, err
+		}
+		topics = append(topics, &topic)
+	}
+	return topics, nil
+}
+
 func (r *topicRepository) GetAll(ctx context.Context) ([]*model.Topic, error) {
 	cursor, err := r.collection.Find(ctx, bson.M{})
 	if err != nil {
@@ -108,3 +194,353 @@ func (r *topicRepository) GetAll(ctx context.Context) ([]*model.Topic, error) {
 	}
 	return topics, nil
 }
+
+func (r *topicRepository) List(ctx context.Context, filter TopicFilter) ([]*model.Topic, error) {
+	query := bson.M{}
+	if filter.OrganizationID != nil {
+		query["$or"] = visibleToOrganization(*filter.OrganizationID)
+	}
+	if filter.IsTemplate != nil {
+		query["is_template"] = *filter.IsTemplate
+	}
+
+	rating := bson.M{}
+	if filter.MinRating != nil {
+		rating["$gte"] = *filter.MinRating
+	}
+	if filter.MaxRating != nil {
+		rating["$lte"] = *filter.MaxRating
+	}
+	if len(rating) > 0 {
+		query["rating_average"] = rating
+	}
+	if filter.MinRatingCount > 0 {
+		query["rating_count"] = bson.M{"$gte": filter.MinRatingCount}
+	}
+
+	opts := options.Find()
+	if filter.Sort != "" {
+		direction := 1
+		key := filter.Sort
+		if strings.HasPrefix(key, "-") {
+			direction = -1
+			key = key[1:]
+		}
+		if field, ok := topicSortFields[key]; ok {
+			opts.SetSort(bson.D{{Key: field, Value: direction}, {Key: "_id", Value: 1}})
+		}
+	}
+
+	cursor, err := r.collection.Find(ctx, query, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var topics []*model.Topic
+	for cursor.Next(ctx) {
+		var topic model.Topic
+		if err := cursor.Decode(&topic); err != nil {
+			return nil, err
+		}
+		topics = append(topics, &topic)
+	}
+	return topics, nil
+}
+
+// IncrementItemCount cập nhật số lượng item được lưu sẵn trên topic
+func (r *topicRepository) IncrementItemCount(ctx context.Context, id string, delta int) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return errors.New("invalid ID format")
+	}
+
+	result, err := r.collection.UpdateByID(ctx, objectID, bson.M{"$inc": bson.M{"item_count": delta}})
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+// IncrementCommentCount cập nhật số bình luận hiển thị được lưu sẵn trên topic
+func (r *topicRepository) IncrementCommentCount(ctx context.Context, id string, delta int) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return errors.New("invalid ID format")
+	}
+
+	result, err := r.collection.UpdateByID(ctx, objectID, bson.M{"$inc": bson.M{"comment_count": delta}})
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+// ApplyRatingDelta cộng dồn tổng điểm và số lượt đánh giá rồi tính lại trung bình trong cùng một update
+func (r *topicRepository) ApplyRatingDelta(ctx context.Context, id string, sumDelta, countDelta int) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return errors.New("invalid ID format")
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$set", Value: bson.M{
+			"rating_sum":   bson.M{"$add": bson.A{bson.M{"$ifNull": bson.A{"$rating_sum", 0}}, sumDelta}},
+			"rating_count": bson.M{"$add": bson.A{bson.M{"$ifNull": bson.A{"$rating_count", 0}}, countDelta}},
+		}}},
+		{{Key: "$set", Value: bson.M{
+			"rating_average": bson.M{"$cond": bson.A{
+				bson.M{"$gt": bson.A{"$rating_count", 0}},
+				bson.M{"$divide": bson.A{"$rating_sum", "$rating_count"}},
+				0,
+			}},
+		}}},
+	}
+
+	result, err := r.collection.UpdateByID(ctx, objectID, pipeline)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+func (r *topicRepository) AddPrerequisite(ctx context.Context, id string, prerequisiteID string) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return errors.New("invalid ID format")
+	}
+
+	update := bson.M{
+		"$addToSet": bson.M{"prerequisite_ids": prerequisiteID},
+		"$set":      bson.M{"updated_at": time.Now()},
+	}
+
+	result, err := r.collection.UpdateByID(ctx, objectID, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+func (r *topicRepository) RemovePrerequisite(ctx context.Context, id string, prerequisiteID string) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return errors.New("invalid ID format")
+	}
+
+	update := bson.M{
+		"$pull": bson.M{"prerequisite_ids": prerequisiteID},
+		"$set":  bson.M{"updated_at": time.Now()},
+	}
+
+	result, err := r.collection.UpdateByID(ctx, objectID, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+// RemovePrerequisiteFromAll xoá mọi cạnh trỏ tới prerequisiteID, dùng khi topic bị xoá
+func (r *topicRepository) RemovePrerequisiteFromAll(ctx context.Context, prerequisiteID string) error {
+	_, err := r.collection.UpdateMany(ctx,
+		bson.M{"prerequisite_ids": prerequisiteID},
+		bson.M{"$pull": bson.M{"prerequisite_ids": prerequisiteID}},
+	)
+	return err
+}
+
+func (r *topicRepository) SetTemplate(ctx context.Context, id string, isTemplate bool) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return errors.New("invalid ID format")
+	}
+
+	update := bson.M{
+		"$set": bson.M{
+			"is_template": isTemplate,
+			"updated_at":  time.Now(),
+		},
+	}
+
+	result, err := r.collection.UpdateByID(ctx, objectID, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+func (r *topicRepository) GetTemplates(ctx context.Context) ([]*model.Topic, error) {
+	cursor, err := r.collection.Find(ctx, bson.M{"is_template": true})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var topics []*model.Topic
+	for cursor.Next(ctx) {
+		var topic model.Topic
+		if err := cursor.Decode(&topic); err != nil {
+			return nil, err
+		}
+		topics = append(topics, &topic)
+	}
+	return topics, nil
+}
+
+// ReplacePrerequisite thay mọi cạnh trỏ tới oldID bằng newID
+func (r *topicRepository) ReplacePrerequisite(ctx context.Context, oldID, newID string) error {
+	filter := bson.M{"prerequisite_ids": oldID}
+
+	// $addToSet và $pull trên cùng một field không dùng chung được trong một update
+	if _, err := r.collection.UpdateMany(ctx, filter, bson.M{"$addToSet": bson.M{"prerequisite_ids": newID}}); err != nil {
+		return err
+	}
+	if _, err := r.collection.UpdateMany(ctx, filter, bson.M{"$pull": bson.M{"prerequisite_ids": oldID}}); err != nil {
+		return err
+	}
+
+	// Topic mới không được là prerequisite của chính nó
+	newObjectID, err := primitive.ObjectIDFromHex(newID)
+	if err != nil {
+		return errors.New("invalid ID format")
+	}
+	_, err = r.collection.UpdateByID(ctx, newObjectID, bson.M{"$pull": bson.M{"prerequisite_ids": newID}})
+	return err
+}
+
+// ApplyMerge ghi các field đã được giải quyết xung đột lên topic còn lại sau khi gộp
+func (r *topicRepository) ApplyMerge(ctx context.Context, id string, merged *model.Topic) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return errors.New("invalid ID format")
+	}
+
+	merged.UpdatedAt = time.Now()
+
+	update := bson.M{
+		"$set": bson.M{
+			"title":            merged.Title,
+			"titles":           merged.Titles,
+			"descriptions":     merged.Descriptions,
+			"default_locale":   merged.DefaultLocale,
+			"search_terms":     merged.SearchTerms,
+			"icon":             merged.Icon,
+			"is_template":      merged.IsTemplate,
+			"prerequisite_ids": merged.PrerequisiteIDs,
+			"item_count":       merged.ItemCount,
+			"comment_count":    merged.CommentCount,
+			"rating_sum":       merged.RatingSum,
+			"rating_count":     merged.RatingCount,
+			"rating_average":   merged.RatingAverage,
+			"updated_at":       merged.UpdatedAt,
+		},
+	}
+
+	result, err := r.collection.UpdateByID(ctx, objectID, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+// visibleToOrganization là các điều kiện $or của topic mà thành viên tổ chức được xem
+func visibleToOrganization(organizationID string) bson.A {
+	return bson.A{
+		bson.M{"organization_id": organizationID},
+		// Topic tạo trước khi có tổ chức không có field organization_id, null khớp cả field bị thiếu
+		bson.M{"organization_id": bson.M{"$in": bson.A{"", nil}}},
+		bson.M{"is_template": true},
+	}
+}
+
+func (r *topicRepository) Search(ctx context.Context, filter TopicSearchFilter) ([]*model.Topic, error) {
+	conditions := bson.A{}
+	if len(filter.Terms) > 0 {
+		conditions = append(conditions, bson.M{"search_terms": bson.M{"$all": filter.Terms}})
+	}
+	if filter.Prefix != "" {
+		conditions = append(conditions, bson.M{"search_terms": bson.M{"$regex": "^" + regexp.QuoteMeta(filter.Prefix)}})
+	}
+	if len(conditions) == 0 {
+		return nil, nil
+	}
+	if filter.OrganizationID != nil {
+		conditions = append(conditions, bson.M{"$or": visibleToOrganization(*filter.OrganizationID)})
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "rating_average", Value: -1}, {Key: "_id", Value: 1}}).
+		SetLimit(int64(filter.Limit))
+
+	cursor, err := r.collection.Find(ctx, bson.M{"$and": conditions}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var topics []*model.Topic
+	if err := cursor.All(ctx, &topics); err != nil {
+		return nil, err
+	}
+	return topics, nil
+}
+
+// ListByCreator trả về các topic mà userID đang sở hữu
+func (r *topicRepository) ListByCreator(ctx context.Context, userID string) ([]*model.Topic, error) {
+	cursor, err := r.collection.Find(ctx, bson.M{"created_by": userID})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var topics []*model.Topic
+	if err := cursor.All(ctx, &topics); err != nil {
+		return nil, err
+	}
+	return topics, nil
+}
+
+// SetOwner chuyển quyền sở hữu topic sang ownerID
+func (r *topicRepository) SetOwner(ctx context.Context, id string, ownerID string) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return errors.New("invalid ID format")
+	}
+
+	update := bson.M{
+		"$set": bson.M{
+			"created_by": ownerID,
+			"updated_at": time.Now(),
+		},
+	}
+
+	result, err := r.collection.UpdateByID(ctx, objectID, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
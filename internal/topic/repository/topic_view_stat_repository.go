@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"context"
+	"topic-service/internal/topic/model"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ViewStatFilter giới hạn khoảng ngày (YYYY-MM-DD, bao gồm hai đầu) và tổ chức khi tổng hợp lượt xem
+type ViewStatFilter struct {
+	From           string
+	To             string
+	OrganizationID string
+}
+
+type TopicViewStatRepository interface {
+	IncrementViews(ctx context.Context, stats []model.TopicViewStat) error
+	SumViews(ctx context.Context, filter ViewStatFilter, limit int) ([]model.TopicViewTotal, error)
+}
+
+type topicViewStatRepository struct {
+	collection *mongo.Collection
+}
+
+func NewTopicViewStatRepository(collection *mongo.Collection) TopicViewStatRepository {
+	return &topicViewStatRepository{collection}
+}
+
+// IncrementViews cộng dồn lượt xem vào bộ đếm theo ngày, tạo mới nếu chưa có
+func (r *topicViewStatRepository) IncrementViews(ctx context.Context, stats []model.TopicViewStat) error {
+	if len(stats) == 0 {
+		return nil
+	}
+
+	writes := make([]mongo.WriteModel, 0, len(stats))
+	for _, stat := range stats {
+		writes = append(writes, mongo.NewUpdateOneModel().
+			SetFilter(bson.M{
+				"topic_id":        stat.TopicID,
+				"organization_id": stat.OrganizationID,
+				"date":            stat.Date,
+			}).
+			SetUpdate(bson.M{"$inc": bson.M{"views": stat.Views}}).
+			SetUpsert(true))
+	}
+
+	_, err := r.collection.BulkWrite(ctx, writes, options.BulkWrite().SetOrdered(false))
+	return err
+}
+
+// SumViews trả về tổng lượt xem theo topic, giảm dần. limit <= 0 nghĩa là không giới hạn.
+func (r *topicViewStatRepository) SumViews(ctx context.Context, filter ViewStatFilter, limit int) ([]model.TopicViewTotal, error) {
+	match := bson.M{"date": bson.M{"$gte": filter.From, "$lte": filter.To}}
+	if filter.OrganizationID != "" {
+		match["organization_id"] = filter.OrganizationID
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: match}},
+		{{Key: "$group", Value: bson.M{"_id": "$topic_id", "views": bson.M{"$sum": "$views"}}}},
+		{{Key: "$sort", Value: bson.D{{Key: "views", Value: -1}, {Key: "_id", Value: 1}}}},
+	}
+	if limit > 0 {
+		pipeline = append(pipeline, bson.D{{Key: "$limit", Value: limit}})
+	}
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var totals []model.TopicViewTotal
+	if err := cursor.All(ctx, &totals); err != nil {
+		return nil, err
+	}
+	return totals, nil
+}
@@ -0,0 +1,169 @@
+package repository
+
+import (
+	"context"
+	"log"
+	"sync"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// TransactionManager chạy fn trong một transaction. Repository nhận ctx của fn sẽ ghi trong transaction đó.
+type TransactionManager interface {
+	WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type txStateKey struct{}
+
+type txState struct {
+	mu          sync.Mutex
+	afterCommit []func()
+	// commands đếm lệnh append lên event store trong lần chạy hiện tại của fn. appended giữ kết quả các lệnh
+	// đã append qua mọi lần WithTransaction chạy lại fn, vì event store không rollback theo MongoDB.
+	commands int
+	appended map[int]appendedCommand
+}
+
+// InTransaction cho biết ctx có đang nằm trong transaction hay không
+func InTransaction(ctx context.Context) bool {
+	return transactionState(ctx) != nil
+}
+
+// WithoutTransaction trả về ctx tách khỏi transaction và không bị huỷ theo ctx gốc, dùng cho hook sau commit
+func WithoutTransaction(ctx context.Context) context.Context {
+	return context.WithValue(context.WithoutCancel(ctx), txStateKey{}, (*txState)(nil))
+}
+
+func transactionState(ctx context.Context) *txState {
+	state, _ := ctx.Value(txStateKey{}).(*txState)
+	return state
+}
+
+// AfterCommit đăng ký fn chạy sau khi transaction commit thành công, ngoài transaction thì chạy ngay
+func AfterCommit(ctx context.Context, fn func()) {
+	state := transactionState(ctx)
+	if state == nil {
+		fn()
+		return
+	}
+	state.mu.Lock()
+	state.afterCommit = append(state.afterCommit, fn)
+	state.mu.Unlock()
+}
+
+func (s *txState) reset() {
+	s.mu.Lock()
+	s.afterCommit = nil
+	s.commands = 0
+	s.mu.Unlock()
+}
+
+func (s *txState) committed() {
+	s.mu.Lock()
+	hooks := s.afterCommit
+	s.afterCommit = nil
+	s.mu.Unlock()
+
+	for _, hook := range hooks {
+		hook()
+	}
+}
+
+type mongoTransactionManager struct {
+	client *mongo.Client
+
+	once      sync.Once
+	supported bool
+}
+
+// NewMongoTransactionManager dùng transaction của MongoDB. Transaction chỉ có trên replica set hoặc sharded cluster,
+// server từ chối khởi động với MongoDB standalone trừ khi bật database.mongodb.allow_standalone; khi đó các thao tác
+// vẫn được chạy nhưng không còn nguyên tử.
+func NewMongoTransactionManager(client *mongo.Client) TransactionManager {
+	return &mongoTransactionManager{client: client}
+}
+
+func (m *mongoTransactionManager) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	// Transaction lồng nhau dùng luôn transaction bên ngoài
+	if InTransaction(ctx) {
+		return fn(ctx)
+	}
+
+	state := &txState{}
+	txCtx := context.WithValue(ctx, txStateKey{}, state)
+
+	if !m.transactionsSupported(ctx) {
+		if err := fn(txCtx); err != nil {
+			return err
+		}
+		state.committed()
+		return nil
+	}
+
+	session, err := m.client.StartSession()
+	if err != nil {
+		return err
+	}
+	defer session.EndSession(context.WithoutCancel(ctx))
+
+	_, err = session.WithTransaction(txCtx, func(sc mongo.SessionContext) (interface{}, error) {
+		// WithTransaction có thể chạy lại fn khi gặp lỗi tạm thời, bỏ các hook của lần chạy trước
+		state.reset()
+		return nil, fn(sc)
+	})
+	if err != nil {
+		return err
+	}
+	state.committed()
+	return nil
+}
+
+func (m *mongoTransactionManager) transactionsSupported(ctx context.Context) bool {
+	m.once.Do(func() {
+		supported, err := MongoSupportsTransactions(ctx, m.client)
+		if err != nil {
+			log.Printf("transaction: failed to detect MongoDB topology, assuming transactions are supported: %v", err)
+			m.supported = true
+			return
+		}
+
+		m.supported = supported
+		if !m.supported {
+			log.Printf("transaction: MongoDB is a standalone server, writes and outbox events are not atomic")
+		}
+	})
+	return m.supported
+}
+
+// MongoSupportsTransactions hỏi server bằng lệnh hello: chỉ replica set (có setName) và mongos mới có transaction
+func MongoSupportsTransactions(ctx context.Context, client *mongo.Client) (bool, error) {
+	var hello struct {
+		SetName string `bson:"setName"`
+		Msg     string `bson:"msg"`
+	}
+	if err := client.Database("admin").RunCommand(ctx, bson.D{{Key: "hello", Value: 1}}).Decode(&hello); err != nil {
+		return false, err
+	}
+	return hello.SetName != "" || hello.Msg == "isdbgrid", nil
+}
+
+type memoryTransactionManager struct{}
+
+// NewMemoryTransactionManager dùng cho chế độ --dev: không rollback khi fn lỗi
+func NewMemoryTransactionManager() TransactionManager {
+	return memoryTransactionManager{}
+}
+
+func (memoryTransactionManager) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if InTransaction(ctx) {
+		return fn(ctx)
+	}
+
+	state := &txState{}
+	if err := fn(context.WithValue(ctx, txStateKey{}, state)); err != nil {
+		return err
+	}
+	state.committed()
+	return nil
+}
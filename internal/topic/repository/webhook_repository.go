@@ -0,0 +1,298 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+	"topic-service/internal/topic/model"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type WebhookSubscriptionRepository interface {
+	Create(ctx context.Context, subscription *model.WebhookSubscription) (*model.WebhookSubscription, error)
+	GetByID(ctx context.Context, id string) (*model.WebhookSubscription, error)
+	ListByOrganization(ctx context.Context, organizationID string) ([]*model.WebhookSubscription, error)
+	// ListActive trả về các subscription đang bật của tổ chức
+	ListActive(ctx context.Context, organizationID string) ([]*model.WebhookSubscription, error)
+	// Update ghi URL, secret, events và trạng thái active. Bật lại subscription thì xoá bộ đếm lỗi.
+	Update(ctx context.Context, subscription *model.WebhookSubscription) error
+	Delete(ctx context.Context, id string) error
+	RecordSuccess(ctx context.Context, id string) error
+	// RecordFailure tăng bộ đếm lỗi liên tiếp, tới disableAfter thì tắt subscription và trả về true
+	RecordFailure(ctx context.Context, id string, disableAfter int, reason string) (bool, error)
+}
+
+type webhookSubscriptionRepository struct {
+	collection *mongo.Collection
+}
+
+func NewWebhookSubscriptionRepository(collection *mongo.Collection) WebhookSubscriptionRepository {
+	return &webhookSubscriptionRepository{collection}
+}
+
+func (r *webhookSubscriptionRepository) Create(ctx context.Context, subscription *model.WebhookSubscription) (*model.WebhookSubscription, error) {
+	if subscription.ID.IsZero() {
+		subscription.ID = primitive.NewObjectID()
+	}
+	if _, err := r.collection.InsertOne(ctx, subscription); err != nil {
+		return nil, err
+	}
+	return subscription, nil
+}
+
+func (r *webhookSubscriptionRepository) GetByID(ctx context.Context, id string) (*model.WebhookSubscription, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, errors.New("invalid ID format")
+	}
+
+	var subscription model.WebhookSubscription
+	if err := r.collection.FindOne(ctx, bson.M{"_id": objectID}).Decode(&subscription); err != nil {
+		return nil, err
+	}
+	return &subscription, nil
+}
+
+func (r *webhookSubscriptionRepository) ListByOrganization(ctx context.Context, organizationID string) ([]*model.WebhookSubscription, error) {
+	return r.find(ctx, bson.M{"organization_id": organizationID})
+}
+
+func (r *webhookSubscriptionRepository) ListActive(ctx context.Context, organizationID string) ([]*model.WebhookSubscription, error) {
+	return r.find(ctx, bson.M{"organization_id": organizationID, "active": true})
+}
+
+func (r *webhookSubscriptionRepository) Update(ctx context.Context, subscription *model.WebhookSubscription) error {
+	set := bson.M{
+		"url":        subscription.URL,
+		"secret":     subscription.Secret,
+		"events":     subscription.Events,
+		"active":     subscription.Active,
+		"updated_at": subscription.UpdatedAt,
+	}
+	update := bson.M{"$set": set}
+	if subscription.Active {
+		set["consecutive_failures"] = 0
+		update["$unset"] = bson.M{"disabled_at": "", "disabled_reason": ""}
+	}
+
+	result, err := r.collection.UpdateByID(ctx, subscription.ID, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+func (r *webhookSubscriptionRepository) Delete(ctx context.Context, id string) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return errors.New("invalid ID format")
+	}
+
+	result, err := r.collection.DeleteOne(ctx, bson.M{"_id": objectID})
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+func (r *webhookSubscriptionRepository) RecordSuccess(ctx context.Context, id string) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return errors.New("invalid ID format")
+	}
+
+	_, err = r.collection.UpdateOne(ctx,
+		bson.M{"_id": objectID, "consecutive_failures": bson.M{"$gt": 0}},
+		bson.M{"$set": bson.M{"consecutive_failures": 0}})
+	return err
+}
+
+func (r *webhookSubscriptionRepository) RecordFailure(ctx context.Context, id string, disableAfter int, reason string) (bool, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return false, errors.New("invalid ID format")
+	}
+
+	now := time.Now()
+	// Chỉ instance làm bộ đếm chạm ngưỡng khi subscription còn bật mới tắt nó, nên lý do tắt chỉ ghi một lần
+	filter := bson.M{"_id": objectID}
+	update := bson.A{bson.M{"$set": bson.M{
+		"consecutive_failures": bson.M{"$add": bson.A{"$consecutive_failures", 1}},
+	}}}
+	if disableAfter > 0 {
+		reached := bson.M{"$and": bson.A{
+			"$active",
+			bson.M{"$gte": bson.A{bson.M{"$add": bson.A{"$consecutive_failures", 1}}, disableAfter}},
+		}}
+		update = append(update, bson.M{"$set": bson.M{
+			"disabled_at":     bson.M{"$cond": bson.A{reached, now, "$disabled_at"}},
+			"disabled_reason": bson.M{"$cond": bson.A{reached, reason, "$disabled_reason"}},
+			"active":          bson.M{"$cond": bson.A{reached, false, "$active"}},
+		}})
+	}
+
+	var before model.WebhookSubscription
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.Before)
+	if err := r.collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&before); err != nil {
+		return false, err
+	}
+	return disableAfter > 0 && before.Active && before.ConsecutiveFailures+1 >= disableAfter, nil
+}
+
+func (r *webhookSubscriptionRepository) find(ctx context.Context, filter bson.M) ([]*model.WebhookSubscription, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}, {Key: "_id", Value: 1}})
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	subscriptions := []*model.WebhookSubscription{}
+	if err := cursor.All(ctx, &subscriptions); err != nil {
+		return nil, err
+	}
+	return subscriptions, nil
+}
+
+type WebhookDeliveryRepository interface {
+	// Create bỏ qua delivery trùng DedupKey, vì relay có thể gửi lại một event
+	Create(ctx context.Context, delivery *model.WebhookDelivery) error
+	GetByID(ctx context.Context, subscriptionID, id string) (*model.WebhookDelivery, error)
+	// ListBySubscription trả về nhật ký gửi của subscription, mới nhất trước
+	ListBySubscription(ctx context.Context, subscriptionID string, skip, limit int64) ([]*model.WebhookDelivery, error)
+	CountBySubscription(ctx context.Context, subscriptionID string) (int64, error)
+	// ClaimDue lấy một delivery pending đã tới lượt gửi và dời lượt kế tiếp thêm lease để instance khác không gửi trùng.
+	// Trả về mongo.ErrNoDocuments khi không còn delivery nào.
+	ClaimDue(ctx context.Context, now time.Time, lease time.Duration) (*model.WebhookDelivery, error)
+	MarkSucceeded(ctx context.Context, id primitive.ObjectID, attempts, statusCode int, deliveredAt time.Time) error
+	MarkRetry(ctx context.Context, id primitive.ObjectID, attempts, statusCode int, lastError string, nextAttemptAt time.Time) error
+	MarkFailed(ctx context.Context, id primitive.ObjectID, attempts, statusCode int, lastError string) error
+	DeleteBySubscription(ctx context.Context, subscriptionID string) error
+}
+
+type webhookDeliveryRepository struct {
+	collection *mongo.Collection
+}
+
+func NewWebhookDeliveryRepository(collection *mongo.Collection) WebhookDeliveryRepository {
+	return &webhookDeliveryRepository{collection}
+}
+
+func (r *webhookDeliveryRepository) Create(ctx context.Context, delivery *model.WebhookDelivery) error {
+	if delivery.ID.IsZero() {
+		delivery.ID = primitive.NewObjectID()
+	}
+	_, err := r.collection.InsertOne(ctx, delivery)
+	if mongo.IsDuplicateKeyError(err) {
+		return nil
+	}
+	return err
+}
+
+func (r *webhookDeliveryRepository) GetByID(ctx context.Context, subscriptionID, id string) (*model.WebhookDelivery, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, errors.New("invalid ID format")
+	}
+
+	var delivery model.WebhookDelivery
+	err = r.collection.FindOne(ctx, bson.M{"_id": objectID, "subscription_id": subscriptionID}).Decode(&delivery)
+	if err != nil {
+		return nil, err
+	}
+	return &delivery, nil
+}
+
+func (r *webhookDeliveryRepository) ListBySubscription(ctx context.Context, subscriptionID string, skip, limit int64) ([]*model.WebhookDelivery, error) {
+	opts := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}}).
+		SetSkip(skip).
+		SetLimit(limit)
+
+	cursor, err := r.collection.Find(ctx, bson.M{"subscription_id": subscriptionID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	deliveries := []*model.WebhookDelivery{}
+	if err := cursor.All(ctx, &deliveries); err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+func (r *webhookDeliveryRepository) CountBySubscription(ctx context.Context, subscriptionID string) (int64, error) {
+	return r.collection.CountDocuments(ctx, bson.M{"subscription_id": subscriptionID})
+}
+
+func (r *webhookDeliveryRepository) ClaimDue(ctx context.Context, now time.Time, lease time.Duration) (*model.WebhookDelivery, error) {
+	filter := bson.M{
+		"status":          model.WebhookDeliveryPending,
+		"next_attempt_at": bson.M{"$lte": now},
+	}
+	update := bson.M{"$set": bson.M{"next_attempt_at": now.Add(lease)}}
+	opts := options.FindOneAndUpdate().
+		SetSort(bson.D{{Key: "next_attempt_at", Value: 1}, {Key: "_id", Value: 1}}).
+		SetReturnDocument(options.Before)
+
+	var delivery model.WebhookDelivery
+	if err := r.collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&delivery); err != nil {
+		return nil, err
+	}
+	return &delivery, nil
+}
+
+func (r *webhookDeliveryRepository) MarkSucceeded(ctx context.Context, id primitive.ObjectID, attempts, statusCode int, deliveredAt time.Time) error {
+	return r.update(ctx, id, bson.M{
+		"status":           model.WebhookDeliverySucceeded,
+		"attempts":         attempts,
+		"last_status_code": statusCode,
+		"last_error":       "",
+		"delivered_at":     deliveredAt,
+	})
+}
+
+func (r *webhookDeliveryRepository) MarkRetry(ctx context.Context, id primitive.ObjectID, attempts, statusCode int, lastError string, nextAttemptAt time.Time) error {
+	return r.update(ctx, id, bson.M{
+		"attempts":         attempts,
+		"last_status_code": statusCode,
+		"last_error":       lastError,
+		"next_attempt_at":  nextAttemptAt,
+	})
+}
+
+func (r *webhookDeliveryRepository) MarkFailed(ctx context.Context, id primitive.ObjectID, attempts, statusCode int, lastError string) error {
+	return r.update(ctx, id, bson.M{
+		"status":           model.WebhookDeliveryFailed,
+		"attempts":         attempts,
+		"last_status_code": statusCode,
+		"last_error":       lastError,
+	})
+}
+
+func (r *webhookDeliveryRepository) DeleteBySubscription(ctx context.Context, subscriptionID string) error {
+	_, err := r.collection.DeleteMany(ctx, bson.M{"subscription_id": subscriptionID})
+	return err
+}
+
+func (r *webhookDeliveryRepository) update(ctx context.Context, id primitive.ObjectID, set bson.M) error {
+	result, err := r.collection.UpdateByID(ctx, id, bson.M{"$set": set})
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
@@ -0,0 +1,28 @@
+package service
+
+import "topic-service/internal/topic/model"
+
+// Actor là người dùng đang gọi API, lấy từ các claim mà middleware.Secured gắn vào context
+type Actor struct {
+	UserID         string
+	OrganizationID string
+	IsAdmin        bool
+}
+
+// CanView: admin xem được mọi topic, user khác chỉ xem topic của tổ chức mình,
+// template và topic chưa gắn tổ chức
+func (a Actor) CanView(topic *model.Topic) bool {
+	if a.IsAdmin || topic.IsTemplate || topic.OrganizationID == "" {
+		return true
+	}
+	return topic.OrganizationID == a.OrganizationID
+}
+
+// organizationScope là điều kiện tổ chức cho các truy vấn danh sách, nil với admin là không giới hạn
+func (a Actor) organizationScope() *string {
+	if a.IsAdmin {
+		return nil
+	}
+	organizationID := a.OrganizationID
+	return &organizationID
+}
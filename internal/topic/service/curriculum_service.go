@@ -0,0 +1,306 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"topic-service/internal/topic/dto/request"
+	"topic-service/internal/topic/dto/response"
+	"topic-service/internal/topic/mapper"
+	"topic-service/internal/topic/model"
+	"topic-service/internal/topic/repository"
+	"topic-service/pkg/i18n"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var (
+	ErrCurriculumTopicNotFound  = errors.New("curriculum references topics that do not exist")
+	ErrCurriculumDuplicateTopic = errors.New("a topic can appear only once in a curriculum")
+	ErrInvalidCurriculumOrder   = errors.New("topic_ids must list every topic of the curriculum exactly once")
+	ErrCurriculumPublished      = errors.New("published curriculum cannot be modified, clone it instead")
+	ErrCurriculumForbidden      = errors.New("only the owner, members of its organization or an admin can change this curriculum")
+)
+
+type CurriculumService interface {
+	CreateCurriculum(ctx context.Context, actor Actor, req *request.CreateCurriculumRequest) (*response.CurriculumResponse, error)
+	GetCurriculumByID(ctx context.Context, id string) (*response.CurriculumResponse, error)
+	ListCurricula(ctx context.Context) ([]response.CurriculumResponse, error)
+	UpdateCurriculum(ctx context.Context, actor Actor, id string, req *request.UpdateCurriculumRequest) (*response.CurriculumResponse, error)
+	ReorderCurriculum(ctx context.Context, actor Actor, id string, req *request.ReorderCurriculumRequest) (*response.CurriculumResponse, error)
+	CloneCurriculum(ctx context.Context, actor Actor, id string, req *request.CloneCurriculumRequest) (*response.CurriculumResponse, error)
+	PublishCurriculum(ctx context.Context, actor Actor, id string) (*response.CurriculumResponse, error)
+	DeleteCurriculum(ctx context.Context, actor Actor, id string) error
+}
+
+type curriculumService struct {
+	repo      repository.CurriculumRepository
+	topicRepo repository.TopicRepository
+}
+
+func NewCurriculumService(repo repository.CurriculumRepository, topicRepo repository.TopicRepository) CurriculumService {
+	return &curriculumService{
+		repo:      repo,
+		topicRepo: topicRepo,
+	}
+}
+
+func (s *curriculumService) CreateCurriculum(ctx context.Context, actor Actor, req *request.CreateCurriculumRequest) (*response.CurriculumResponse, error) {
+	entries, err := buildCurriculumEntries(req.Entries)
+	if err != nil {
+		return nil, err
+	}
+
+	titles, err := s.validateTopics(ctx, entries)
+	if err != nil {
+		return nil, err
+	}
+
+	newCurriculum := &model.Curriculum{
+		ID:             primitive.NewObjectID(),
+		Title:          req.Title,
+		Description:    req.Description,
+		Entries:        entries,
+		Status:         model.CurriculumStatusDraft,
+		CreatedBy:      actor.UserID,
+		OrganizationID: actor.OrganizationID,
+		CreatedAt:      time.Now(),
+		UpdatedAt:      time.Now(),
+	}
+
+	created, err := s.repo.Create(ctx, newCurriculum)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapper.MapCurriculumToResponse(created, titles), nil
+}
+
+func (s *curriculumService) GetCurriculumByID(ctx context.Context, id string) (*response.CurriculumResponse, error) {
+	curriculum, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	titles, err := s.topicTitles(ctx, curriculum.Entries)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapper.MapCurriculumToResponse(curriculum, titles), nil
+}
+
+func (s *curriculumService) ListCurricula(ctx context.Context) ([]response.CurriculumResponse, error) {
+	curricula, err := s.repo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapper.MapCurriculaToResponses(curricula), nil
+}
+
+func (s *curriculumService) UpdateCurriculum(ctx context.Context, actor Actor, id string, req *request.UpdateCurriculumRequest) (*response.CurriculumResponse, error) {
+	if _, err := s.getDraft(ctx, actor, id); err != nil {
+		return nil, err
+	}
+
+	entries, err := buildCurriculumEntries(req.Entries)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.validateTopics(ctx, entries); err != nil {
+		return nil, err
+	}
+
+	err = s.repo.Update(ctx, id, &model.Curriculum{
+		Title:       req.Title,
+		Description: req.Description,
+		Entries:     entries,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetCurriculumByID(ctx, id)
+}
+
+func (s *curriculumService) ReorderCurriculum(ctx context.Context, actor Actor, id string, req *request.ReorderCurriculumRequest) (*response.CurriculumResponse, error) {
+	curriculum, err := s.getDraft(ctx, actor, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(req.TopicIDs) != len(curriculum.Entries) {
+		return nil, ErrInvalidCurriculumOrder
+	}
+
+	byTopic := make(map[string]model.CurriculumEntry, len(curriculum.Entries))
+	for _, e := range curriculum.Entries {
+		byTopic[e.TopicID] = e
+	}
+
+	// Giữ nguyên week/term của từng topic, chỉ đổi vị trí
+	entries := make([]model.CurriculumEntry, 0, len(req.TopicIDs))
+	for order, topicID := range req.TopicIDs {
+		entry, ok := byTopic[topicID]
+		if !ok {
+			return nil, ErrInvalidCurriculumOrder
+		}
+		delete(byTopic, topicID)
+		entry.Order = order
+		entries = append(entries, entry)
+	}
+
+	if err := s.repo.UpdateEntries(ctx, id, entries); err != nil {
+		return nil, err
+	}
+
+	return s.GetCurriculumByID(ctx, id)
+}
+
+func (s *curriculumService) CloneCurriculum(ctx context.Context, actor Actor, id string, req *request.CloneCurriculumRequest) (*response.CurriculumResponse, error) {
+	source, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	title := req.Title
+	if title == "" {
+		title = source.Title + " (copy)"
+	}
+
+	entries := make([]model.CurriculumEntry, len(source.Entries))
+	copy(entries, source.Entries)
+
+	clone := &model.Curriculum{
+		ID:                 primitive.NewObjectID(),
+		Title:              title,
+		Description:        source.Description,
+		Entries:            entries,
+		Status:             model.CurriculumStatusDraft,
+		SourceCurriculumID: source.ID.Hex(),
+		CreatedBy:          actor.UserID,
+		OrganizationID:     actor.OrganizationID,
+		CreatedAt:          time.Now(),
+		UpdatedAt:          time.Now(),
+	}
+
+	if _, err := s.repo.Create(ctx, clone); err != nil {
+		return nil, err
+	}
+
+	return s.GetCurriculumByID(ctx, clone.ID.Hex())
+}
+
+func (s *curriculumService) PublishCurriculum(ctx context.Context, actor Actor, id string) (*response.CurriculumResponse, error) {
+	curriculum, err := s.getDraft(ctx, actor, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Topic có thể đã bị xoá kể từ lúc soạn, kiểm tra lại trước khi phát hành
+	if _, err := s.validateTopics(ctx, curriculum.Entries); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Publish(ctx, id, time.Now()); err != nil {
+		return nil, err
+	}
+
+	return s.GetCurriculumByID(ctx, id)
+}
+
+func (s *curriculumService) DeleteCurriculum(ctx context.Context, actor Actor, id string) error {
+	curriculum, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !canManageCurriculum(actor, curriculum) {
+		return ErrCurriculumForbidden
+	}
+	return s.repo.Delete(ctx, id)
+}
+
+// canManageCurriculum: người tạo, thành viên cùng tổ chức và admin được sửa, phát hành, xoá curriculum
+func canManageCurriculum(actor Actor, curriculum *model.Curriculum) bool {
+	if actor.IsAdmin || curriculum.CreatedBy == actor.UserID {
+		return true
+	}
+	return curriculum.OrganizationID != "" && curriculum.OrganizationID == actor.OrganizationID
+}
+
+func (s *curriculumService) getDraft(ctx context.Context, actor Actor, id string) (*model.Curriculum, error) {
+	curriculum, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !canManageCurriculum(actor, curriculum) {
+		return nil, ErrCurriculumForbidden
+	}
+	if curriculum.Status == model.CurriculumStatusPublished {
+		return nil, ErrCurriculumPublished
+	}
+	return curriculum, nil
+}
+
+// validateTopics kiểm tra mọi topic được tham chiếu đều tồn tại và trả về tiêu đề của chúng
+func (s *curriculumService) validateTopics(ctx context.Context, entries []model.CurriculumEntry) (map[string]string, error) {
+	titles, err := s.topicTitles(ctx, entries)
+	if err != nil {
+		return nil, err
+	}
+
+	var missing []string
+	for _, e := range entries {
+		if _, ok := titles[e.TopicID]; !ok {
+			missing = append(missing, e.TopicID)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("%w: %s", ErrCurriculumTopicNotFound, strings.Join(missing, ", "))
+	}
+	return titles, nil
+}
+
+func (s *curriculumService) topicTitles(ctx context.Context, entries []model.CurriculumEntry) (map[string]string, error) {
+	ids := make([]string, 0, len(entries))
+	for _, e := range entries {
+		if _, err := primitive.ObjectIDFromHex(e.TopicID); err != nil {
+			continue
+		}
+		ids = append(ids, e.TopicID)
+	}
+
+	topics, err := s.topicRepo.GetByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	locale := i18n.Locale(ctx)
+	titles := make(map[string]string, len(topics))
+	for _, t := range topics {
+		titles[t.ID.Hex()], _, _ = t.Localize(locale)
+	}
+	return titles, nil
+}
+
+func buildCurriculumEntries(reqs []request.CurriculumEntryRequest) ([]model.CurriculumEntry, error) {
+	seen := make(map[string]bool, len(reqs))
+	entries := make([]model.CurriculumEntry, 0, len(reqs))
+	for order, e := range reqs {
+		if seen[e.TopicID] {
+			return nil, ErrCurriculumDuplicateTopic
+		}
+		seen[e.TopicID] = true
+		entries = append(entries, model.CurriculumEntry{
+			TopicID: e.TopicID,
+			Order:   order,
+			Week:    e.Week,
+			Term:    e.Term,
+		})
+	}
+	return entries, nil
+}
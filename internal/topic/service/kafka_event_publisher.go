@@ -0,0 +1,36 @@
+package service
+
+import (
+	"context"
+	"topic-service/internal/topic/model"
+	"topic-service/pkg/kafka"
+)
+
+// DefaultTopicEventsTopic là Kafka topic nhận event thay đổi topic khi config không chỉ định
+const DefaultTopicEventsTopic = "topic-service.topic-events"
+
+const (
+	eventTypeHeader = "event_type"
+	eventIDHeader   = "event_id"
+)
+
+type kafkaEventPublisher struct {
+	producer *kafka.Producer
+	topic    string
+}
+
+// NewKafkaEventPublisher gửi event vào Kafka với key là topic ID, nên các event của một topic
+// luôn nằm cùng partition và được đọc theo đúng thứ tự
+func NewKafkaEventPublisher(producer *kafka.Producer, topic string) EventPublisher {
+	if topic == "" {
+		topic = DefaultTopicEventsTopic
+	}
+	return &kafkaEventPublisher{producer: producer, topic: topic}
+}
+
+func (p *kafkaEventPublisher) Publish(ctx context.Context, event *model.OutboxEvent) error {
+	return p.producer.Send(ctx, p.topic, []byte(event.AggregateID), []byte(event.Payload),
+		kafka.Header{Key: eventTypeHeader, Value: []byte(event.Type)},
+		kafka.Header{Key: eventIDHeader, Value: []byte(event.ID.Hex())},
+	)
+}
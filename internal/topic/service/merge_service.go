@@ -0,0 +1,306 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+	"topic-service/internal/topic/dto/request"
+	"topic-service/internal/topic/dto/response"
+	"topic-service/internal/topic/mapper"
+	"topic-service/internal/topic/model"
+	"topic-service/internal/topic/repository"
+	"topic-service/pkg/i18n"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	mergeKeepSurvivor = "survivor"
+	mergeKeepMerged   = "merged"
+)
+
+var (
+	ErrMergeSameTopic         = errors.New("survivor_id and merged_id must be different")
+	ErrInvalidMergeResolution = errors.New("invalid merge resolution")
+	ErrMergeCycle             = errors.New("merging these topics would create a prerequisite cycle")
+)
+
+// mergeableFields là các field có thể chọn giá trị khi gộp
+var mergeableFields = map[string]bool{
+	"title":       true,
+	"icon":        true,
+	"is_template": true,
+}
+
+type MergeService interface {
+	MergeTopics(ctx context.Context, actorID string, req *request.MergeTopicsRequest) (*response.TopicResponse, error)
+	GetAuditTrail(ctx context.Context, topicID string) ([]response.TopicAuditLogResponse, error)
+}
+
+type mergeService struct {
+	topicRepo      repository.TopicRepository
+	itemRepo       repository.TopicItemRepository
+	curriculumRepo repository.CurriculumRepository
+	redirectRepo   repository.TopicRedirectRepository
+	auditRepo      repository.TopicAuditRepository
+	favoriteRepo   repository.TopicFavoriteRepository
+	commentRepo    repository.TopicCommentRepository
+	ratingRepo     repository.TopicRatingRepository
+	prerequisites  *prerequisiteService
+	events         *topicEvents
+}
+
+func NewMergeService(
+	topicRepo repository.TopicRepository,
+	itemRepo repository.TopicItemRepository,
+	curriculumRepo repository.CurriculumRepository,
+	redirectRepo repository.TopicRedirectRepository,
+	auditRepo repository.TopicAuditRepository,
+	favoriteRepo repository.TopicFavoriteRepository,
+	commentRepo repository.TopicCommentRepository,
+	ratingRepo repository.TopicRatingRepository,
+	outboxRepo repository.OutboxRepository,
+	transactions repository.TransactionManager,
+) MergeService {
+	return &mergeService{
+		topicRepo:      topicRepo,
+		itemRepo:       itemRepo,
+		curriculumRepo: curriculumRepo,
+		redirectRepo:   redirectRepo,
+		auditRepo:      auditRepo,
+		favoriteRepo:   favoriteRepo,
+		commentRepo:    commentRepo,
+		ratingRepo:     ratingRepo,
+		prerequisites:  &prerequisiteService{repo: topicRepo},
+		events:         newTopicEvents(topicRepo, outboxRepo, transactions),
+	}
+}
+
+// MergeTopics gộp topic MergedID vào SurvivorID: chuyển item, prerequisite và curriculum
+// sang survivor, xoá topic bị gộp và để lại redirect để GetByID vẫn tìm được.
+// Toàn bộ thao tác chạy trong một transaction.
+func (s *mergeService) MergeTopics(ctx context.Context, actorID string, req *request.MergeTopicsRequest) (*response.TopicResponse, error) {
+	var res *response.TopicResponse
+	err := s.events.inTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		res, err = s.mergeTopics(ctx, actorID, req)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (s *mergeService) mergeTopics(ctx context.Context, actorID string, req *request.MergeTopicsRequest) (*response.TopicResponse, error) {
+	if req.SurvivorID == req.MergedID {
+		return nil, ErrMergeSameTopic
+	}
+	for field, choice := range req.Resolution {
+		if !mergeableFields[field] || (choice != mergeKeepSurvivor && choice != mergeKeepMerged) {
+			return nil, fmt.Errorf("%w: %s=%s", ErrInvalidMergeResolution, field, choice)
+		}
+	}
+
+	survivor, err := s.topicRepo.GetByID(ctx, req.SurvivorID)
+	if err != nil {
+		return nil, err
+	}
+	merged, err := s.topicRepo.GetByID(ctx, req.MergedID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := *survivor
+	// Tiêu đề được chọn kèm toàn bộ bản dịch của topic đó
+	if req.Resolution["title"] == mergeKeepMerged {
+		result.Title = merged.Title
+		result.Titles = merged.Titles
+		result.Descriptions = merged.Descriptions
+		result.DefaultLocale = merged.DefaultLocale
+		result.RefreshSearchTerms()
+	}
+	if req.Resolution["icon"] == mergeKeepMerged {
+		result.Icon = merged.Icon
+	}
+	if req.Resolution["is_template"] == mergeKeepMerged {
+		result.IsTemplate = merged.IsTemplate
+	}
+
+	result.PrerequisiteIDs = mergePrerequisites(survivor, merged)
+	if err := s.checkMergeCycle(ctx, survivor, merged, result.PrerequisiteIDs); err != nil {
+		return nil, err
+	}
+
+	// Item của topic bị gộp được nối vào sau item của survivor
+	maxOrder, err := s.itemRepo.MaxOrder(ctx, req.SurvivorID)
+	if err != nil {
+		return nil, err
+	}
+	movedItems, err := s.itemRepo.MoveToTopic(ctx, req.MergedID, req.SurvivorID, maxOrder+1)
+	if err != nil {
+		return nil, err
+	}
+	result.ItemCount = survivor.ItemCount + int(movedItems)
+
+	if err := s.commentRepo.MoveToTopic(ctx, req.MergedID, req.SurvivorID); err != nil {
+		return nil, err
+	}
+	result.CommentCount = survivor.CommentCount + merged.CommentCount
+
+	// Đánh giá trùng user giữ bản của survivor, aggregate chỉ cộng thêm phần được chuyển sang
+	movedRatings, err := s.ratingRepo.MoveToTopic(ctx, req.MergedID, req.SurvivorID)
+	if err != nil {
+		return nil, err
+	}
+	result.RatingSum = survivor.RatingSum + movedRatings.Sum
+	result.RatingCount = survivor.RatingCount + movedRatings.Count
+	result.RatingAverage = 0
+	if result.RatingCount > 0 {
+		result.RatingAverage = float64(result.RatingSum) / float64(result.RatingCount)
+	}
+
+	if err := s.topicRepo.ApplyMerge(ctx, req.SurvivorID, &result); err != nil {
+		return nil, err
+	}
+
+	if err := s.topicRepo.ReplacePrerequisite(ctx, req.MergedID, req.SurvivorID); err != nil {
+		return nil, err
+	}
+
+	repointedCurricula, err := s.repointCurricula(ctx, req.MergedID, req.SurvivorID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.favoriteRepo.ReplaceTopic(ctx, req.MergedID, req.SurvivorID); err != nil {
+		return nil, err
+	}
+
+	if err := s.topicRepo.Delete(ctx, req.MergedID); err != nil {
+		return nil, err
+	}
+	if err := s.events.record(ctx, model.TopicDeleted, merged); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if err := s.redirectRepo.Save(ctx, &model.TopicRedirect{
+		ID:        req.MergedID,
+		TargetID:  req.SurvivorID,
+		CreatedBy: actorID,
+		CreatedAt: now,
+	}); err != nil {
+		return nil, err
+	}
+	if err := s.redirectRepo.RepointTargets(ctx, req.MergedID, req.SurvivorID); err != nil {
+		return nil, err
+	}
+
+	err = s.auditRepo.Create(ctx, &model.TopicAuditLog{
+		ID:      primitive.NewObjectID(),
+		Action:  model.AuditActionMerge,
+		TopicID: req.SurvivorID,
+		ActorID: actorID,
+		Details: map[string]interface{}{
+			"merged_id":           req.MergedID,
+			"merged_title":        merged.Title,
+			"resolution":          req.Resolution,
+			"moved_items":         movedItems,
+			"repointed_curricula": repointedCurricula,
+		},
+		CreatedAt: now,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	updated, err := s.topicRepo.GetByID(ctx, req.SurvivorID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.events.record(ctx, model.TopicUpdated, updated); err != nil {
+		return nil, err
+	}
+	return mapper.MapTopicToResponse(updated, i18n.Locale(ctx)), nil
+}
+
+func (s *mergeService) GetAuditTrail(ctx context.Context, topicID string) ([]response.TopicAuditLogResponse, error) {
+	logs, err := s.auditRepo.ListByTopic(ctx, topicID)
+	if err != nil {
+		return nil, err
+	}
+	return mapper.MapTopicAuditLogsToResponses(logs), nil
+}
+
+// checkMergeCycle: nếu một prerequisite mới của survivor lại phụ thuộc (gián tiếp)
+// vào survivor hoặc topic bị gộp thì sau khi gộp sẽ thành chu trình
+func (s *mergeService) checkMergeCycle(ctx context.Context, survivor, merged *model.Topic, prerequisiteIDs []string) error {
+	closure, err := s.prerequisites.loadClosure(ctx, prerequisiteIDs)
+	if err != nil {
+		return err
+	}
+	if _, ok := closure[survivor.ID.Hex()]; ok {
+		return ErrMergeCycle
+	}
+	if _, ok := closure[merged.ID.Hex()]; ok {
+		return ErrMergeCycle
+	}
+	return nil
+}
+
+// repointCurricula thay topic bị gộp bằng survivor trong mọi curriculum,
+// bỏ entry trùng nếu curriculum đã có survivor
+func (s *mergeService) repointCurricula(ctx context.Context, mergedID, survivorID string) ([]string, error) {
+	curricula, err := s.curriculumRepo.FindByTopicID(ctx, mergedID)
+	if err != nil {
+		return nil, err
+	}
+
+	repointed := make([]string, 0, len(curricula))
+	for _, c := range curricula {
+		hasSurvivor := false
+		for _, e := range c.Entries {
+			if e.TopicID == survivorID {
+				hasSurvivor = true
+				break
+			}
+		}
+
+		entries := make([]model.CurriculumEntry, 0, len(c.Entries))
+		for _, e := range c.Entries {
+			if e.TopicID == mergedID {
+				if hasSurvivor {
+					continue
+				}
+				e.TopicID = survivorID
+			}
+			e.Order = len(entries)
+			entries = append(entries, e)
+		}
+
+		if err := s.curriculumRepo.UpdateEntries(ctx, c.ID.Hex(), entries); err != nil {
+			return nil, err
+		}
+		repointed = append(repointed, c.ID.Hex())
+	}
+	return repointed, nil
+}
+
+func mergePrerequisites(survivor, merged *model.Topic) []string {
+	exclude := map[string]bool{
+		survivor.ID.Hex(): true,
+		merged.ID.Hex():   true,
+	}
+
+	var result []string
+	for _, id := range append(append([]string(nil), survivor.PrerequisiteIDs...), merged.PrerequisiteIDs...) {
+		if exclude[id] {
+			continue
+		}
+		exclude[id] = true
+		result = append(result, id)
+	}
+	return result
+}
@@ -0,0 +1,233 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"os"
+	"time"
+	"topic-service/internal/topic/model"
+	"topic-service/internal/topic/repository"
+)
+
+const (
+	DefaultOutboxPollInterval   = time.Second
+	DefaultOutboxBatchSize      = 100
+	DefaultOutboxMaxAttempts    = 10
+	DefaultOutboxInitialBackoff = time.Second
+	DefaultOutboxMaxBackoff     = 5 * time.Minute
+	DefaultOutboxLeaseTTL       = 30 * time.Second
+	DefaultOutboxPublishTimeout = 10 * time.Second
+)
+
+// EventPublisher gửi event trong outbox tới hệ thống bên ngoài. Event có thể được gửi lại nhiều lần
+// (at-least-once), bên nhận dựa vào event_id trong payload để bỏ qua bản trùng.
+type EventPublisher interface {
+	Publish(ctx context.Context, event *model.OutboxEvent) error
+}
+
+type logEventPublisher struct{}
+
+// NewLogEventPublisher chỉ ghi event ra log, dùng khi chưa cấu hình message broker
+func NewLogEventPublisher() EventPublisher {
+	return logEventPublisher{}
+}
+
+func (logEventPublisher) Publish(ctx context.Context, event *model.OutboxEvent) error {
+	log.Printf("outbox: %s topic=%s id=%s", event.Type, event.AggregateID, event.ID.Hex())
+	return nil
+}
+
+type OutboxRelayOptions struct {
+	PollInterval time.Duration
+	BatchSize    int
+	// MaxAttempts là số lần gửi lỗi trước khi event bị chuyển sang trạng thái dead
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	LeaseTTL       time.Duration
+	PublishTimeout time.Duration
+}
+
+func (o OutboxRelayOptions) withDefaults() OutboxRelayOptions {
+	if o.PollInterval <= 0 {
+		o.PollInterval = DefaultOutboxPollInterval
+	}
+	if o.BatchSize <= 0 {
+		o.BatchSize = DefaultOutboxBatchSize
+	}
+	if o.MaxAttempts <= 0 {
+		o.MaxAttempts = DefaultOutboxMaxAttempts
+	}
+	if o.InitialBackoff <= 0 {
+		o.InitialBackoff = DefaultOutboxInitialBackoff
+	}
+	if o.MaxBackoff <= 0 {
+		o.MaxBackoff = DefaultOutboxMaxBackoff
+	}
+	if o.LeaseTTL <= 0 {
+		o.LeaseTTL = DefaultOutboxLeaseTTL
+	}
+	if o.PublishTimeout <= 0 {
+		o.PublishTimeout = DefaultOutboxPublishTimeout
+	}
+	return o
+}
+
+// OutboxRelay gửi event trong outbox cho EventPublisher
+type OutboxRelay interface {
+	Close()
+}
+
+type outboxRelay struct {
+	outboxRepo repository.OutboxRepository
+	publisher  EventPublisher
+	opts       OutboxRelayOptions
+	owner      string
+	stop       chan struct{}
+	done       chan struct{}
+}
+
+// NewOutboxRelay chạy relay ngay khi khởi tạo. Khi có nhiều instance, chỉ instance giữ lease mới gửi event
+// để giữ đúng thứ tự theo topic.
+func NewOutboxRelay(outboxRepo repository.OutboxRepository, publisher EventPublisher, opts OutboxRelayOptions) OutboxRelay {
+	r := &outboxRelay{
+		outboxRepo: outboxRepo,
+		publisher:  publisher,
+		opts:       opts.withDefaults(),
+		owner:      instanceID(),
+		stop:       make(chan struct{}),
+		done:       make(chan struct{}),
+	}
+	go r.run()
+	return r
+}
+
+// Close dừng relay và chờ batch đang gửi (nếu có) kết thúc
+func (r *outboxRelay) Close() {
+	select {
+	case <-r.stop:
+	default:
+		close(r.stop)
+	}
+	<-r.done
+}
+
+func (r *outboxRelay) run() {
+	defer close(r.done)
+
+	ticker := time.NewTicker(r.opts.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		// Batch đầy nghĩa là có thể còn event đang chờ, gửi tiếp không đợi tick
+		for r.relayBatch() {
+			select {
+			case <-r.stop:
+				return
+			default:
+			}
+		}
+		select {
+		case <-r.stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// relayBatch gửi một batch event pending, trả về true nếu batch đầy và mọi event đều đã được xử lý
+func (r *outboxRelay) relayBatch() bool {
+	ctx, cancel := context.WithTimeout(context.Background(), r.opts.LeaseTTL)
+	defer cancel()
+
+	acquired, err := r.outboxRepo.AcquireLease(ctx, r.owner, r.opts.LeaseTTL)
+	if err != nil {
+		log.Printf("outbox: failed to acquire relay lease: %v", err)
+		return false
+	}
+	if !acquired {
+		return false
+	}
+
+	// Topic đang chờ gửi lại đã bị ListPending loại khỏi batch, không chiếm chỗ của topic khác
+	events, err := r.outboxRepo.ListPending(ctx, time.Now(), r.opts.BatchSize)
+	if err != nil {
+		log.Printf("outbox: failed to load pending events: %v", err)
+		return false
+	}
+
+	// Event gửi lỗi trong batch này thì các event sau của cùng topic phải đợi để giữ thứ tự
+	blocked := make(map[string]bool)
+	for _, event := range events {
+		if blocked[event.AggregateID] {
+			continue
+		}
+		if !r.deliver(ctx, event) {
+			blocked[event.AggregateID] = true
+		}
+	}
+	return len(events) == r.opts.BatchSize && len(blocked) == 0
+}
+
+// deliver gửi một event, trả về false nếu event còn phải gửi lại
+func (r *outboxRelay) deliver(ctx context.Context, event *model.OutboxEvent) bool {
+	publishCtx, cancel := context.WithTimeout(ctx, r.opts.PublishTimeout)
+	err := r.publisher.Publish(publishCtx, event)
+	cancel()
+
+	if err == nil {
+		if err := r.outboxRepo.MarkPublished(ctx, event.ID, time.Now()); err != nil {
+			// Event sẽ được gửi lại ở lần sau, bên nhận tự loại bản trùng
+			log.Printf("outbox: failed to mark event %s as published: %v", event.ID.Hex(), err)
+			return false
+		}
+		return true
+	}
+
+	attempts := event.Attempts + 1
+	if attempts >= r.opts.MaxAttempts {
+		// Event lỗi quá nhiều lần được đưa vào dead-letter để không chặn các event sau của topic
+		log.Printf("outbox: event %s (%s topic=%s) moved to dead letter after %d attempts: %v",
+			event.ID.Hex(), event.Type, event.AggregateID, attempts, err)
+		if err := r.outboxRepo.MarkDead(ctx, event.ID, attempts, err.Error()); err != nil {
+			log.Printf("outbox: failed to mark event %s as dead: %v", event.ID.Hex(), err)
+			return false
+		}
+		return true
+	}
+
+	nextAttemptAt := time.Now().Add(r.backoff(attempts))
+	log.Printf("outbox: failed to publish event %s (attempt %d), retrying at %s: %v",
+		event.ID.Hex(), attempts, nextAttemptAt.Format(time.RFC3339), err)
+	if err := r.outboxRepo.MarkRetry(ctx, event.ID, attempts, err.Error(), nextAttemptAt); err != nil {
+		log.Printf("outbox: failed to schedule retry for event %s: %v", event.ID.Hex(), err)
+	}
+	return false
+}
+
+func (r *outboxRelay) backoff(attempts int) time.Duration {
+	return exponentialBackoff(r.opts.InitialBackoff, r.opts.MaxBackoff, attempts)
+}
+
+// exponentialBackoff tăng gấp đôi sau mỗi lần lỗi, tối đa maxDelay
+func exponentialBackoff(initial, maxDelay time.Duration, attempts int) time.Duration {
+	delay := initial
+	for i := 1; i < attempts && delay < maxDelay; i++ {
+		delay *= 2
+	}
+	if delay > maxDelay {
+		delay = maxDelay
+	}
+	return delay
+}
+
+// instanceID định danh instance hiện tại, dùng làm owner của lease và tên consumer group riêng
+func instanceID() string {
+	host, _ := os.Hostname()
+	suffix := make([]byte, 4)
+	_, _ = rand.Read(suffix)
+	return fmt.Sprintf("%s-%d-%s", host, os.Getpid(), hex.EncodeToString(suffix))
+}
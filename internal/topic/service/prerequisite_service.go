@@ -0,0 +1,307 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sort"
+	"time"
+	"topic-service/internal/topic/dto/request"
+	"topic-service/internal/topic/dto/response"
+	"topic-service/internal/topic/mapper"
+	"topic-service/internal/topic/model"
+	"topic-service/internal/topic/repository"
+	"topic-service/pkg/i18n"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+var (
+	ErrPrerequisiteCycle = errors.New("prerequisite would create a cycle")
+	ErrPrerequisiteBusy  = errors.New("another prerequisite is being added, try again")
+)
+
+const (
+	// prerequisiteLease khoá việc thêm cạnh prerequisite trên mọi instance
+	prerequisiteLease = "prerequisite_graph"
+	// prerequisiteLeaseTTL cũng là thời gian tối đa chờ lấy lease
+	prerequisiteLeaseTTL   = 10 * time.Second
+	prerequisiteLeaseRetry = 20 * time.Millisecond
+)
+
+type PrerequisiteService interface {
+	AddPrerequisite(ctx context.Context, topicID string, req *request.AddPrerequisiteRequest) (*response.TopicResponse, error)
+	RemovePrerequisite(ctx context.Context, topicID, prerequisiteID string) error
+	GetPrerequisites(ctx context.Context, topicID string) ([]response.TopicResponse, error)
+	GetLearningPath(ctx context.Context, req *request.LearningPathRequest) ([]response.TopicResponse, error)
+}
+
+// prerequisiteService chỉ dùng TopicRepository nên chạy được trên mọi storage backend
+type prerequisiteService struct {
+	repo   repository.TopicRepository
+	leases repository.LeaseRepository
+	events *topicEvents
+}
+
+func NewPrerequisiteService(
+	repo repository.TopicRepository,
+	leases repository.LeaseRepository,
+	outboxRepo repository.OutboxRepository,
+	transactions repository.TransactionManager,
+) PrerequisiteService {
+	return &prerequisiteService{repo: repo, leases: leases, events: newTopicEvents(repo, outboxRepo, transactions)}
+}
+
+func (s *prerequisiteService) AddPrerequisite(ctx context.Context, topicID string, req *request.AddPrerequisiteRequest) (*response.TopicResponse, error) {
+	if topicID == req.PrerequisiteID {
+		return nil, ErrPrerequisiteCycle
+	}
+
+	if _, err := s.repo.GetByID(ctx, topicID); err != nil {
+		return nil, err
+	}
+
+	// Kiểm tra chu trình rồi ghi là hai bước, hai request A→B và B→A chạy song song đều qua được bước kiểm tra.
+	// Giữ lease trong suốt hai bước để các lần thêm cạnh chạy lần lượt.
+	release, err := s.lockGraph(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	err = s.events.inTransaction(ctx, func(ctx context.Context) error {
+		// Nếu topic đã là prerequisite (trực tiếp hoặc gián tiếp) của prerequisite mới thì sẽ tạo chu trình
+		closure, err := s.loadClosure(ctx, []string{req.PrerequisiteID})
+		if err != nil {
+			return err
+		}
+		if _, ok := closure[topicID]; ok {
+			return ErrPrerequisiteCycle
+		}
+
+		if err := s.repo.AddPrerequisite(ctx, topicID, req.PrerequisiteID); err != nil {
+			return err
+		}
+		return s.events.recordUpdated(ctx, topicID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	topic, err := s.repo.GetByID(ctx, topicID)
+	if err != nil {
+		return nil, err
+	}
+	return mapper.MapTopicToResponse(topic, i18n.Locale(ctx)), nil
+}
+
+// lockGraph chờ tới khi giữ được lease của đồ thị prerequisite, trả về hàm nhả lease
+func (s *prerequisiteService) lockGraph(ctx context.Context) (func(), error) {
+	owner := primitive.NewObjectID().Hex()
+	waitCtx, cancel := context.WithTimeout(ctx, prerequisiteLeaseTTL)
+	defer cancel()
+
+	for {
+		acquired, err := s.leases.Acquire(waitCtx, prerequisiteLease, owner, prerequisiteLeaseTTL)
+		if err != nil && waitCtx.Err() == nil {
+			return nil, err
+		}
+		if acquired {
+			return func() {
+				if err := s.leases.Release(repository.WithoutTransaction(ctx), prerequisiteLease, owner); err != nil {
+					log.Printf("prerequisite: failed to release lease: %v", err)
+				}
+			}, nil
+		}
+
+		select {
+		case <-waitCtx.Done():
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			return nil, ErrPrerequisiteBusy
+		case <-time.After(prerequisiteLeaseRetry):
+		}
+	}
+}
+
+func (s *prerequisiteService) RemovePrerequisite(ctx context.Context, topicID, prerequisiteID string) error {
+	return s.events.inTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.RemovePrerequisite(ctx, topicID, prerequisiteID); err != nil {
+			return err
+		}
+		return s.events.recordUpdated(ctx, topicID)
+	})
+}
+
+// GetPrerequisites trả về toàn bộ prerequisite (kể cả gián tiếp) của topic theo thứ tự học
+func (s *prerequisiteService) GetPrerequisites(ctx context.Context, topicID string) ([]response.TopicResponse, error) {
+	topic, err := s.repo.GetByID(ctx, topicID)
+	if err != nil {
+		return nil, err
+	}
+
+	closure, err := s.loadClosure(ctx, topic.PrerequisiteIDs)
+	if err != nil {
+		return nil, err
+	}
+	delete(closure, topicID)
+
+	ordered, err := topologicalSort(closure, topic.PrerequisiteIDs)
+	if err != nil {
+		return nil, err
+	}
+	return mapper.MapTopicsToResponses(ordered, i18n.Locale(ctx)), nil
+}
+
+func (s *prerequisiteService) GetLearningPath(ctx context.Context, req *request.LearningPathRequest) ([]response.TopicResponse, error) {
+	closure, err := s.loadClosure(ctx, req.TopicIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	ordered, err := topologicalSort(closure, req.TopicIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	// Thứ tự được tính trên toàn bộ closure để giữ ràng buộc gián tiếp,
+	// sau đó mới lọc về các topic được yêu cầu
+	if !req.IncludePrerequisites {
+		requested := make(map[string]bool, len(req.TopicIDs))
+		for _, id := range req.TopicIDs {
+			requested[id] = true
+		}
+		filtered := make([]*model.Topic, 0, len(requested))
+		for _, t := range ordered {
+			if requested[t.ID.Hex()] {
+				filtered = append(filtered, t)
+			}
+		}
+		ordered = filtered
+	}
+
+	return mapper.MapTopicsToResponses(ordered, i18n.Locale(ctx)), nil
+}
+
+// loadClosure tải các topic trong ids cùng toàn bộ prerequisite của chúng.
+// Trả về mongo.ErrNoDocuments nếu một topic trong ids không tồn tại;
+// các cạnh trỏ tới topic đã bị xoá được bỏ qua.
+func (s *prerequisiteService) loadClosure(ctx context.Context, ids []string) (map[string]*model.Topic, error) {
+	closure := make(map[string]*model.Topic)
+	requested := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		requested[id] = true
+	}
+
+	frontier := uniqueIDs(ids)
+	visited := make(map[string]bool)
+	for len(frontier) > 0 {
+		for _, id := range frontier {
+			visited[id] = true
+		}
+
+		topics, err := s.repo.GetByIDs(ctx, frontier)
+		if err != nil {
+			return nil, err
+		}
+
+		var next []string
+		for _, t := range topics {
+			closure[t.ID.Hex()] = t
+			for _, prerequisiteID := range t.PrerequisiteIDs {
+				if !visited[prerequisiteID] {
+					visited[prerequisiteID] = true
+					next = append(next, prerequisiteID)
+				}
+			}
+		}
+		frontier = next
+	}
+
+	for id := range requested {
+		if _, ok := closure[id]; !ok {
+			return nil, mongo.ErrNoDocuments
+		}
+	}
+	return closure, nil
+}
+
+// topologicalSort sắp xếp các topic sao cho prerequisite luôn đứng trước.
+// Khi có nhiều lựa chọn, ưu tiên theo thứ tự trong hint rồi tới ID để kết quả ổn định.
+func topologicalSort(topics map[string]*model.Topic, hint []string) ([]*model.Topic, error) {
+	rank := make(map[string]int, len(hint))
+	for i, id := range hint {
+		if _, ok := rank[id]; !ok {
+			rank[id] = i
+		}
+	}
+
+	inDegree := make(map[string]int, len(topics))
+	dependents := make(map[string][]string, len(topics))
+	for id, t := range topics {
+		if _, ok := inDegree[id]; !ok {
+			inDegree[id] = 0
+		}
+		for _, prerequisiteID := range t.PrerequisiteIDs {
+			if _, ok := topics[prerequisiteID]; !ok {
+				continue
+			}
+			inDegree[id]++
+			dependents[prerequisiteID] = append(dependents[prerequisiteID], id)
+		}
+	}
+
+	var ready []string
+	for id, degree := range inDegree {
+		if degree == 0 {
+			ready = append(ready, id)
+		}
+	}
+
+	less := func(a, b string) bool {
+		ra, okA := rank[a]
+		rb, okB := rank[b]
+		switch {
+		case okA && okB:
+			return ra < rb
+		case okA != okB:
+			return okA
+		default:
+			return a < b
+		}
+	}
+
+	ordered := make([]*model.Topic, 0, len(topics))
+	for len(ready) > 0 {
+		sort.Slice(ready, func(i, j int) bool { return less(ready[i], ready[j]) })
+		id := ready[0]
+		ready = ready[1:]
+		ordered = append(ordered, topics[id])
+
+		for _, dependent := range dependents[id] {
+			inDegree[dependent]--
+			if inDegree[dependent] == 0 {
+				ready = append(ready, dependent)
+			}
+		}
+	}
+
+	if len(ordered) != len(topics) {
+		return nil, ErrPrerequisiteCycle
+	}
+	return ordered, nil
+}
+
+func uniqueIDs(ids []string) []string {
+	seen := make(map[string]bool, len(ids))
+	result := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			result = append(result, id)
+		}
+	}
+	return result
+}
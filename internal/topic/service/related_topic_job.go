@@ -0,0 +1,236 @@
+package service
+
+import (
+	"context"
+	"log"
+	"sort"
+	"strings"
+	"time"
+	"topic-service/internal/topic/model"
+	"topic-service/internal/topic/repository"
+	"unicode"
+)
+
+const (
+	DefaultRelatedRefreshInterval = time.Hour
+	relatedRefreshTimeout         = 5 * time.Minute
+	// relatedTopicLease chỉ cho một instance tính lại gợi ý, các instance khác bỏ qua lượt của mình
+	relatedTopicLease = "related_topics"
+	// Số gợi ý lưu cho mỗi topic, lớn hơn số trả về để còn đủ sau khi lọc quyền xem
+	storedRelatedLimit = 30
+	titleScoreWeight   = 0.5
+	coViewScoreWeight  = 0.5
+)
+
+// RelatedTopicJob tính lại gợi ý topic liên quan theo chu kỳ
+type RelatedTopicJob interface {
+	Close()
+}
+
+type relatedTopicJob struct {
+	topicRepo   repository.TopicRepository
+	coViewRepo  repository.TopicCoViewRepository
+	relatedRepo repository.TopicRelatedRepository
+	leases      repository.LeaseRepository
+	owner       string
+	interval    time.Duration
+	stop        chan struct{}
+	done        chan struct{}
+}
+
+// NewRelatedTopicJob chạy lần đầu ngay khi khởi tạo, sau đó lặp lại mỗi interval.
+// Khi chạy nhiều instance, chỉ instance giữ lease relatedTopicLease thực sự tính lại.
+func NewRelatedTopicJob(
+	topicRepo repository.TopicRepository,
+	coViewRepo repository.TopicCoViewRepository,
+	relatedRepo repository.TopicRelatedRepository,
+	leases repository.LeaseRepository,
+	interval time.Duration,
+) RelatedTopicJob {
+	j := &relatedTopicJob{
+		topicRepo:   topicRepo,
+		coViewRepo:  coViewRepo,
+		relatedRepo: relatedRepo,
+		leases:      leases,
+		owner:       instanceID(),
+		interval:    interval,
+		stop:        make(chan struct{}),
+		done:        make(chan struct{}),
+	}
+	go j.run()
+	return j
+}
+
+// Close dừng job và chờ lần tính đang chạy (nếu có) kết thúc
+func (j *relatedTopicJob) Close() {
+	select {
+	case <-j.stop:
+	default:
+		close(j.stop)
+	}
+	<-j.done
+}
+
+func (j *relatedTopicJob) run() {
+	defer close(j.done)
+
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		j.refresh()
+		select {
+		case <-j.stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (j *relatedTopicJob) refresh() {
+	ctx, cancel := context.WithTimeout(context.Background(), relatedRefreshTimeout)
+	defer cancel()
+
+	// Lease dài hơn một chu kỳ nên instance đang giữ luôn gia hạn kịp ở lượt sau,
+	// instance đó dừng thì instance khác nhận lại sau khi lease hết hạn
+	acquired, err := j.leases.Acquire(ctx, relatedTopicLease, j.owner, j.interval+relatedRefreshTimeout)
+	if err != nil {
+		log.Printf("related topics: failed to acquire lease: %v", err)
+		return
+	}
+	if !acquired {
+		return
+	}
+
+	topics, err := j.topicRepo.GetAll(ctx)
+	if err != nil {
+		log.Printf("related topics: failed to load topics: %v", err)
+		return
+	}
+	coViews, err := j.coViewRepo.GetAll(ctx)
+	if err != nil {
+		log.Printf("related topics: failed to load co-views: %v", err)
+		return
+	}
+
+	related := computeRelatedTopics(topics, coViews, time.Now())
+	if err := j.relatedRepo.SaveAll(ctx, related); err != nil {
+		log.Printf("related topics: failed to save recommendations: %v", err)
+		return
+	}
+
+	ids := make([]string, 0, len(topics))
+	for _, t := range topics {
+		ids = append(ids, t.ID.Hex())
+	}
+	if err := j.relatedRepo.DeleteStale(ctx, ids); err != nil {
+		log.Printf("related topics: failed to delete stale recommendations: %v", err)
+	}
+}
+
+// computeRelatedTopics chấm điểm mọi cặp topic bằng độ giống của tiêu đề (Jaccard trên token)
+// và số lần được xem cùng phiên (chuẩn hoá theo cặp xem cùng nhiều nhất của topic).
+// So sánh từng cặp nên chi phí là O(n^2) theo số topic.
+func computeRelatedTopics(topics []*model.Topic, coViews []model.TopicCoView, now time.Time) []*model.TopicRelated {
+	tokens := make(map[string]map[string]bool, len(topics))
+	for _, t := range topics {
+		tokens[t.ID.Hex()] = titleTokens(t.Title)
+	}
+
+	coViewCounts := make(map[string]map[string]int64)
+	maxCoView := make(map[string]int64)
+	addCoView := func(a, b string, count int64) {
+		if coViewCounts[a] == nil {
+			coViewCounts[a] = make(map[string]int64)
+		}
+		coViewCounts[a][b] += count
+		if coViewCounts[a][b] > maxCoView[a] {
+			maxCoView[a] = coViewCounts[a][b]
+		}
+	}
+	for _, pair := range coViews {
+		addCoView(pair.TopicA, pair.TopicB, pair.Count)
+		addCoView(pair.TopicB, pair.TopicA, pair.Count)
+	}
+
+	results := make([]*model.TopicRelated, 0, len(topics))
+	for _, t := range topics {
+		id := t.ID.Hex()
+
+		var scores []model.RelatedTopicScore
+		for _, other := range topics {
+			otherID := other.ID.Hex()
+			if otherID == id {
+				continue
+			}
+
+			titleScore := jaccard(tokens[id], tokens[otherID])
+			coViewScore := 0.0
+			if maxCoView[id] > 0 {
+				coViewScore = float64(coViewCounts[id][otherID]) / float64(maxCoView[id])
+			}
+
+			score := titleScoreWeight*titleScore + coViewScoreWeight*coViewScore
+			if score <= 0 {
+				continue
+			}
+			scores = append(scores, model.RelatedTopicScore{
+				TopicID:     otherID,
+				Score:       score,
+				TitleScore:  titleScore,
+				CoViewScore: coViewScore,
+			})
+		}
+
+		sort.Slice(scores, func(i, k int) bool {
+			if scores[i].Score != scores[k].Score {
+				return scores[i].Score > scores[k].Score
+			}
+			return scores[i].TopicID < scores[k].TopicID
+		})
+		if len(scores) > storedRelatedLimit {
+			scores = scores[:storedRelatedLimit]
+		}
+		if scores == nil {
+			scores = []model.RelatedTopicScore{}
+		}
+
+		results = append(results, &model.TopicRelated{
+			TopicID:    id,
+			Related:    scores,
+			ComputedAt: now,
+		})
+	}
+	return results
+}
+
+// titleTokens tách tiêu đề thành các từ viết thường, bỏ dấu câu và từ một ký tự
+func titleTokens(title string) map[string]bool {
+	fields := strings.FieldsFunc(strings.ToLower(title), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	tokens := make(map[string]bool, len(fields))
+	for _, f := range fields {
+		if len([]rune(f)) < 2 {
+			continue
+		}
+		tokens[f] = true
+	}
+	return tokens
+}
+
+func jaccard(a, b map[string]bool) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+
+	intersection := 0
+	for token := range a {
+		if b[token] {
+			intersection++
+		}
+	}
+	union := len(a) + len(b) - intersection
+	return float64(intersection) / float64(union)
+}
@@ -0,0 +1,85 @@
+package service
+
+import (
+	"context"
+	"topic-service/internal/topic/dto/response"
+	"topic-service/internal/topic/mapper"
+	"topic-service/internal/topic/model"
+	"topic-service/internal/topic/repository"
+	"topic-service/pkg/i18n"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const defaultRelatedLimit = 10
+
+type RelatedTopicService interface {
+	GetRelated(ctx context.Context, actor Actor, topicID string, limit int) ([]response.RelatedTopicResponse, error)
+}
+
+type relatedTopicService struct {
+	topicRepo   repository.TopicRepository
+	relatedRepo repository.TopicRelatedRepository
+}
+
+func NewRelatedTopicService(topicRepo repository.TopicRepository, relatedRepo repository.TopicRelatedRepository) RelatedTopicService {
+	return &relatedTopicService{
+		topicRepo:   topicRepo,
+		relatedRepo: relatedRepo,
+	}
+}
+
+// GetRelated trả về gợi ý đã tính sẵn, bỏ các topic đã bị xoá hoặc actor không được xem
+func (s *relatedTopicService) GetRelated(ctx context.Context, actor Actor, topicID string, limit int) ([]response.RelatedTopicResponse, error) {
+	if limit <= 0 {
+		limit = defaultRelatedLimit
+	}
+
+	topic, err := s.topicRepo.GetByID(ctx, topicID)
+	if err != nil {
+		return nil, err
+	}
+	// Không tiết lộ sự tồn tại của topic ngoài phạm vi được xem
+	if !actor.CanView(topic) {
+		return nil, mongo.ErrNoDocuments
+	}
+
+	related, err := s.relatedRepo.Get(ctx, topicID)
+	if err != nil {
+		return nil, err
+	}
+	results := []response.RelatedTopicResponse{}
+	if related == nil || len(related.Related) == 0 {
+		return results, nil
+	}
+
+	ids := make([]string, 0, len(related.Related))
+	for _, r := range related.Related {
+		ids = append(ids, r.TopicID)
+	}
+	topics, err := s.topicRepo.GetByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[string]*model.Topic, len(topics))
+	for _, t := range topics {
+		byID[t.ID.Hex()] = t
+	}
+
+	for _, r := range related.Related {
+		t, ok := byID[r.TopicID]
+		if !ok || !actor.CanView(t) {
+			continue
+		}
+		results = append(results, response.RelatedTopicResponse{
+			Topic:       *mapper.MapTopicToResponse(t, i18n.Locale(ctx)),
+			Score:       r.Score,
+			TitleScore:  r.TitleScore,
+			CoViewScore: r.CoViewScore,
+		})
+		if len(results) == limit {
+			break
+		}
+	}
+	return results, nil
+}
@@ -0,0 +1,262 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+	"topic-service/internal/topic/dto/request"
+	"topic-service/internal/topic/dto/response"
+	"topic-service/internal/topic/mapper"
+	"topic-service/internal/topic/model"
+	"topic-service/internal/topic/repository"
+	"topic-service/pkg/helper"
+	"topic-service/pkg/i18n"
+)
+
+const (
+	defaultAnalyticsDays  = 30
+	defaultAnalyticsLimit = 10
+)
+
+var ErrInvalidDateRange = errors.New("invalid date range, expected from <= to in YYYY-MM-DD format")
+
+type TopicAnalyticsService interface {
+	MostViewed(ctx context.Context, actor Actor, query *request.TopicAnalyticsQuery) ([]response.TopicPopularityResponse, error)
+	Trending(ctx context.Context, actor Actor, query *request.TopicAnalyticsQuery) ([]response.TopicTrendResponse, error)
+	NeverUsed(ctx context.Context, actor Actor, query *request.TopicAnalyticsQuery) ([]response.TopicResponse, error)
+}
+
+type topicAnalyticsService struct {
+	statRepo  repository.TopicViewStatRepository
+	topicRepo repository.TopicRepository
+}
+
+func NewTopicAnalyticsService(statRepo repository.TopicViewStatRepository, topicRepo repository.TopicRepository) TopicAnalyticsService {
+	return &topicAnalyticsService{
+		statRepo:  statRepo,
+		topicRepo: topicRepo,
+	}
+}
+
+func (s *topicAnalyticsService) MostViewed(ctx context.Context, actor Actor, query *request.TopicAnalyticsQuery) ([]response.TopicPopularityResponse, error) {
+	scopeAnalyticsQuery(actor, query)
+	from, to, err := parseAnalyticsRange(query)
+	if err != nil {
+		return nil, err
+	}
+
+	totals, err := s.statRepo.SumViews(ctx, repository.ViewStatFilter{
+		From:           helper.FormatDate(from),
+		To:             helper.FormatDate(to),
+		OrganizationID: query.OrganizationID,
+	}, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	titles, err := s.existingTitles(ctx, totals)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := analyticsLimit(query)
+	result := make([]response.TopicPopularityResponse, 0, limit)
+	for _, total := range totals {
+		title, ok := titles[total.TopicID]
+		if !ok {
+			continue
+		}
+		result = append(result, response.TopicPopularityResponse{
+			TopicID: total.TopicID,
+			Title:   title,
+			Views:   total.Views,
+		})
+		if len(result) == limit {
+			break
+		}
+	}
+	return result, nil
+}
+
+// Trending xếp hạng topic theo mức tăng lượt xem so với khoảng thời gian liền trước
+func (s *topicAnalyticsService) Trending(ctx context.Context, actor Actor, query *request.TopicAnalyticsQuery) ([]response.TopicTrendResponse, error) {
+	scopeAnalyticsQuery(actor, query)
+	from, to, err := parseAnalyticsRange(query)
+	if err != nil {
+		return nil, err
+	}
+
+	days := int(to.Sub(from).Hours()/24) + 1
+	previousTo := from.AddDate(0, 0, -1)
+	previousFrom := previousTo.AddDate(0, 0, -(days - 1))
+
+	current, err := s.statRepo.SumViews(ctx, repository.ViewStatFilter{
+		From:           helper.FormatDate(from),
+		To:             helper.FormatDate(to),
+		OrganizationID: query.OrganizationID,
+	}, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	previous, err := s.statRepo.SumViews(ctx, repository.ViewStatFilter{
+		From:           helper.FormatDate(previousFrom),
+		To:             helper.FormatDate(previousTo),
+		OrganizationID: query.OrganizationID,
+	}, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	previousViews := make(map[string]int64, len(previous))
+	for _, total := range previous {
+		previousViews[total.TopicID] = total.Views
+	}
+
+	titles, err := s.existingTitles(ctx, current)
+	if err != nil {
+		return nil, err
+	}
+
+	var trends []response.TopicTrendResponse
+	for _, total := range current {
+		title, ok := titles[total.TopicID]
+		if !ok {
+			continue
+		}
+
+		before := previousViews[total.TopicID]
+		if total.Views <= before {
+			continue
+		}
+
+		base := before
+		if base == 0 {
+			base = 1
+		}
+		trends = append(trends, response.TopicTrendResponse{
+			TopicID:       total.TopicID,
+			Title:         title,
+			Views:         total.Views,
+			PreviousViews: before,
+			Growth:        float64(total.Views-before) / float64(base),
+		})
+	}
+
+	sort.SliceStable(trends, func(i, j int) bool {
+		if trends[i].Growth != trends[j].Growth {
+			return trends[i].Growth > trends[j].Growth
+		}
+		return trends[i].Views > trends[j].Views
+	})
+
+	if limit := analyticsLimit(query); len(trends) > limit {
+		trends = trends[:limit]
+	}
+	return trends, nil
+}
+
+// NeverUsed trả về các topic đã tồn tại trong khoảng thời gian nhưng không có lượt xem nào
+func (s *topicAnalyticsService) NeverUsed(ctx context.Context, actor Actor, query *request.TopicAnalyticsQuery) ([]response.TopicResponse, error) {
+	scopeAnalyticsQuery(actor, query)
+	from, to, err := parseAnalyticsRange(query)
+	if err != nil {
+		return nil, err
+	}
+
+	totals, err := s.statRepo.SumViews(ctx, repository.ViewStatFilter{
+		From:           helper.FormatDate(from),
+		To:             helper.FormatDate(to),
+		OrganizationID: query.OrganizationID,
+	}, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	viewed := make(map[string]bool, len(totals))
+	for _, total := range totals {
+		viewed[total.TopicID] = true
+	}
+
+	topics, err := s.topicRepo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	endOfRange := to.AddDate(0, 0, 1)
+	var unused []*model.Topic
+	for _, t := range topics {
+		if query.OrganizationID != "" && t.OrganizationID != query.OrganizationID {
+			continue
+		}
+		if !t.CreatedAt.Before(endOfRange) || viewed[t.ID.Hex()] {
+			continue
+		}
+		unused = append(unused, t)
+	}
+
+	if query.Limit > 0 && len(unused) > query.Limit {
+		unused = unused[:query.Limit]
+	}
+	return mapper.MapTopicsToResponses(unused, i18n.Locale(ctx)), nil
+}
+
+// existingTitles lấy tiêu đề của các topic có lượt xem, bỏ qua topic đã bị xoá
+func (s *topicAnalyticsService) existingTitles(ctx context.Context, totals []model.TopicViewTotal) (map[string]string, error) {
+	ids := make([]string, 0, len(totals))
+	for _, total := range totals {
+		ids = append(ids, total.TopicID)
+	}
+
+	topics, err := s.topicRepo.GetByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	locale := i18n.Locale(ctx)
+	titles := make(map[string]string, len(topics))
+	for _, t := range topics {
+		titles[t.ID.Hex()], _, _ = t.Localize(locale)
+	}
+	return titles, nil
+}
+
+// scopeAnalyticsQuery: chỉ admin chọn được organization_id, người dùng khác luôn xem thống kê của tổ chức mình
+func scopeAnalyticsQuery(actor Actor, query *request.TopicAnalyticsQuery) {
+	if !actor.IsAdmin {
+		query.OrganizationID = actor.OrganizationID
+	}
+}
+
+func parseAnalyticsRange(query *request.TopicAnalyticsQuery) (time.Time, time.Time, error) {
+	to := time.Now().UTC().Truncate(24 * time.Hour)
+	if query.To != "" {
+		parsed, err := time.Parse("2006-01-02", query.To)
+		if err != nil {
+			return time.Time{}, time.Time{}, ErrInvalidDateRange
+		}
+		to = parsed
+	}
+
+	from := to.AddDate(0, 0, -(defaultAnalyticsDays - 1))
+	if query.From != "" {
+		parsed, err := time.Parse("2006-01-02", query.From)
+		if err != nil {
+			return time.Time{}, time.Time{}, ErrInvalidDateRange
+		}
+		from = parsed
+	}
+
+	if !helper.ValidateDateRange(from, to) {
+		return time.Time{}, time.Time{}, ErrInvalidDateRange
+	}
+	return from, to, nil
+}
+
+func analyticsLimit(query *request.TopicAnalyticsQuery) int {
+	if query.Limit > 0 {
+		return query.Limit
+	}
+	return defaultAnalyticsLimit
+}
@@ -0,0 +1,331 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"slices"
+	"sync"
+	"time"
+	"topic-service/internal/topic/model"
+	"topic-service/internal/topic/repository"
+	"topic-service/pkg/kafka"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const (
+	// DefaultTopicChangeResumeLimit là số event tối đa được đọc lại từ outbox khi client nối lại,
+	// lỡ nhiều hơn thì client phải tải lại danh sách
+	DefaultTopicChangeResumeLimit = 1000
+	// Mỗi stream được đệm tối đa chừng này event, client đọc chậm hơn bị ngắt và tự nối lại bằng Last-Event-ID
+	topicChangeSubscriberBuffer = 64
+
+	topicChangeFeedInterval  = time.Second
+	topicChangeFeedBatchSize = 500
+)
+
+// TopicChange là một event thay đổi topic. ID là _id của outbox event nên giống nhau trên mọi instance,
+// client nối lại vào instance nào cũng tiếp tục được từ Last-Event-ID.
+type TopicChange struct {
+	ID    string
+	Event model.TopicEvent
+}
+
+// TopicChangeFilter chọn event mà một stream nhận
+type TopicChangeFilter struct {
+	Actor Actor
+	// OrganizationID chỉ dành cho admin, để xem riêng một tổ chức
+	OrganizationID string
+	// TopicIDs rỗng là nhận mọi topic
+	TopicIDs []string
+}
+
+// Matches: user thường chỉ thấy topic của tổ chức mình và topic không gắn tổ chức, giống Actor.CanView
+func (f TopicChangeFilter) Matches(event *model.TopicEvent) bool {
+	if len(f.TopicIDs) > 0 && !slices.Contains(f.TopicIDs, event.TopicID) {
+		return false
+	}
+	if f.Actor.IsAdmin {
+		return f.OrganizationID == "" || event.OrganizationID == f.OrganizationID
+	}
+	if event.OrganizationID == "" || event.OrganizationID == f.Actor.OrganizationID {
+		return true
+	}
+	return event.Topic != nil && event.Topic.IsTemplate
+}
+
+// TopicChangeSubscription nhận event của một stream. Changes bị đóng khi hub dừng hoặc stream đọc quá chậm.
+type TopicChangeSubscription struct {
+	// Missed là các event sau Last-Event-ID đọc lại từ outbox, cần gửi trước các event mới.
+	// Event trong Missed có thể tới lần nữa qua Changes.
+	Missed []TopicChange
+	// ResumeGap: Last-Event-ID không còn trong outbox hoặc lỡ quá nhiều event, client nên tải lại danh sách
+	ResumeGap bool
+	Changes   <-chan TopicChange
+
+	hub     *topicChangeHub
+	changes chan TopicChange
+	filter  TopicChangeFilter
+}
+
+// Close huỷ đăng ký, gọi khi client ngắt kết nối
+func (s *TopicChangeSubscription) Close() {
+	s.hub.unsubscribe(s)
+}
+
+// TopicChangeHub phát thay đổi topic tới các stream SSE đang mở trên instance này
+type TopicChangeHub interface {
+	Publish(event model.TopicEvent)
+	// Subscribe mở stream, lastEventID rỗng là chỉ nhận event mới
+	Subscribe(ctx context.Context, filter TopicChangeFilter, lastEventID string) (*TopicChangeSubscription, error)
+	// Close đóng mọi stream, các Subscribe sau đó nhận subscription đã đóng
+	Close()
+}
+
+type topicChangeHub struct {
+	outboxRepo  repository.OutboxRepository
+	resumeLimit int
+
+	mu          sync.Mutex
+	subscribers map[*TopicChangeSubscription]struct{}
+	closed      bool
+}
+
+// NewTopicChangeHub tạo hub nối lại stream bằng cách đọc outbox, resumeLimit <= 0 thì dùng mặc định
+func NewTopicChangeHub(outboxRepo repository.OutboxRepository, resumeLimit int) TopicChangeHub {
+	if resumeLimit <= 0 {
+		resumeLimit = DefaultTopicChangeResumeLimit
+	}
+	return &topicChangeHub{
+		outboxRepo:  outboxRepo,
+		resumeLimit: resumeLimit,
+		subscribers: make(map[*TopicChangeSubscription]struct{}),
+	}
+}
+
+func (h *topicChangeHub) Publish(event model.TopicEvent) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.closed {
+		return
+	}
+
+	change := TopicChange{ID: event.EventID, Event: event}
+	for sub := range h.subscribers {
+		if !sub.filter.Matches(&change.Event) {
+			continue
+		}
+		select {
+		case sub.changes <- change:
+		default:
+			// Không chặn các stream khác vì một client chậm
+			delete(h.subscribers, sub)
+			close(sub.changes)
+		}
+	}
+}
+
+func (h *topicChangeHub) Subscribe(ctx context.Context, filter TopicChangeFilter, lastEventID string) (*TopicChangeSubscription, error) {
+	changes := make(chan TopicChange, topicChangeSubscriberBuffer)
+	sub := &TopicChangeSubscription{Changes: changes, hub: h, changes: changes, filter: filter}
+
+	h.mu.Lock()
+	if h.closed {
+		h.mu.Unlock()
+		close(changes)
+		return sub, nil
+	}
+	h.subscribers[sub] = struct{}{}
+	h.mu.Unlock()
+
+	if lastEventID == "" {
+		return sub, nil
+	}
+
+	// Đăng ký trước rồi mới đọc outbox: event được phát giữa hai bước nằm trong Changes, có thể trùng với Missed
+	// nhưng không bị lỡ
+	missed, gap, err := h.since(ctx, lastEventID)
+	if err != nil {
+		sub.Close()
+		return nil, err
+	}
+	sub.ResumeGap = gap
+	for _, change := range missed {
+		if filter.Matches(&change.Event) {
+			sub.Missed = append(sub.Missed, change)
+		}
+	}
+	return sub, nil
+}
+
+// since đọc từ outbox các event ghi sau lastEventID, gap true khi không xác định được các event đã lỡ
+func (h *topicChangeHub) since(ctx context.Context, lastEventID string) ([]TopicChange, bool, error) {
+	id, err := primitive.ObjectIDFromHex(lastEventID)
+	if err != nil {
+		return nil, true, nil
+	}
+	last, err := h.outboxRepo.GetByID(ctx, id)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, true, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	// Event đã gửi bị xoá sau 7 ngày, event sau một vị trí quá cũ có thể đã mất
+	if last.CreatedAt.Before(time.Now().Add(-topicSyncTokenMaxAge)) {
+		return nil, true, nil
+	}
+
+	events, err := h.outboxRepo.ListSince(ctx, last.CreatedAt, last.ID, time.Now(), h.resumeLimit+1)
+	if err != nil {
+		return nil, false, err
+	}
+	if len(events) > h.resumeLimit {
+		return nil, true, nil
+	}
+
+	changes := make([]TopicChange, 0, len(events))
+	for _, event := range events {
+		if change, ok := outboxTopicChange(event); ok {
+			changes = append(changes, change)
+		}
+	}
+	return changes, false, nil
+}
+
+func (h *topicChangeHub) unsubscribe(sub *TopicChangeSubscription) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if _, ok := h.subscribers[sub]; ok {
+		delete(h.subscribers, sub)
+		close(sub.changes)
+	}
+}
+
+func (h *topicChangeHub) Close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.closed {
+		return
+	}
+	h.closed = true
+	for sub := range h.subscribers {
+		delete(h.subscribers, sub)
+		close(sub.changes)
+	}
+}
+
+func outboxTopicChange(event *model.OutboxEvent) (TopicChange, bool) {
+	var payload model.TopicEvent
+	if err := json.Unmarshal([]byte(event.Payload), &payload); err != nil {
+		log.Printf("stream: skipping outbox event %s with invalid payload: %v", event.ID.Hex(), err)
+		return TopicChange{}, false
+	}
+	return TopicChange{ID: event.ID.Hex(), Event: payload}, true
+}
+
+// TopicChangeFeed đưa thay đổi topic vào hub khi không có Kafka
+type TopicChangeFeed interface {
+	Close()
+}
+
+type outboxTopicChangeFeed struct {
+	outboxRepo repository.OutboxRepository
+	hub        TopicChangeHub
+	createdAt  time.Time
+	lastID     primitive.ObjectID
+	stop       chan struct{}
+	done       chan struct{}
+}
+
+// NewOutboxTopicChangeFeed đọc outbox định kỳ trên từng instance và đưa event mới vào hub, để stream trên
+// instance nào cũng nhận được thay đổi chứ không chỉ instance đang giữ lease của relay. Chỉ đọc event đã ghi
+// quá topicSyncLag như GET /topic/changes nên event tới stream chậm vài giây.
+func NewOutboxTopicChangeFeed(outboxRepo repository.OutboxRepository, hub TopicChangeHub) TopicChangeFeed {
+	f := &outboxTopicChangeFeed{
+		outboxRepo: outboxRepo,
+		hub:        hub,
+		createdAt:  time.Now().Add(-topicSyncLag),
+		stop:       make(chan struct{}),
+		done:       make(chan struct{}),
+	}
+	go f.run()
+	return f
+}
+
+// Close dừng feed và chờ lần đọc đang chạy kết thúc
+func (f *outboxTopicChangeFeed) Close() {
+	select {
+	case <-f.stop:
+	default:
+		close(f.stop)
+	}
+	<-f.done
+}
+
+func (f *outboxTopicChangeFeed) run() {
+	defer close(f.done)
+
+	ticker := time.NewTicker(topicChangeFeedInterval)
+	defer ticker.Stop()
+
+	for {
+		for f.poll() {
+			select {
+			case <-f.stop:
+				return
+			default:
+			}
+		}
+		select {
+		case <-f.stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// poll phát một batch event mới, trả về true nếu batch đầy và còn event chờ đọc
+func (f *outboxTopicChangeFeed) poll() bool {
+	ctx, cancel := context.WithTimeout(context.Background(), topicChangeFeedInterval*5)
+	defer cancel()
+
+	events, err := f.outboxRepo.ListSince(ctx, f.createdAt, f.lastID, time.Now().Add(-topicSyncLag), topicChangeFeedBatchSize)
+	if err != nil {
+		log.Printf("stream: failed to read outbox: %v", err)
+		return false
+	}
+	for _, event := range events {
+		if change, ok := outboxTopicChange(event); ok {
+			f.hub.Publish(change.Event)
+		}
+		f.createdAt, f.lastID = event.CreatedAt, event.ID
+	}
+	return len(events) == topicChangeFeedBatchSize
+}
+
+// TopicChangeGroupID là consumer group riêng của instance này cho NewTopicChangeHandler. Group của instance
+// đã dừng không được dùng lại và tự hết hạn theo offsets.retention.minutes của Kafka.
+func TopicChangeGroupID() string {
+	return "topic-service.stream." + instanceID()
+}
+
+// NewTopicChangeHandler đưa event đọc từ Kafka topic event vào hub. Mỗi instance cần consumer group riêng
+// để instance nào cũng nhận đủ event.
+func NewTopicChangeHandler(hub TopicChangeHub) kafka.Handler {
+	return func(ctx context.Context, message *kafka.Message) error {
+		var payload model.TopicEvent
+		if err := json.Unmarshal(message.Value, &payload); err != nil {
+			log.Printf("stream: skipping malformed topic event at %s/%d@%d: %v", message.Topic, message.Partition, message.Offset, err)
+			return nil
+		}
+		hub.Publish(payload)
+		return nil
+	}
+}
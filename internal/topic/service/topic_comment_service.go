@@ -0,0 +1,259 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"regexp"
+	"time"
+	"topic-service/internal/gateway"
+	"topic-service/internal/topic/dto/request"
+	"topic-service/internal/topic/dto/response"
+	"topic-service/internal/topic/mapper"
+	"topic-service/internal/topic/model"
+	"topic-service/internal/topic/repository"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const (
+	defaultCommentPageSize = 20
+	maxCommentMentions     = 20
+)
+
+var (
+	ErrCommentForbidden      = errors.New("only the author or an admin can change this comment")
+	ErrCommentParentNotFound = errors.New("parent comment not found in this topic")
+	ErrCommentDeleted        = errors.New("comment has been deleted")
+)
+
+// mentionPattern khớp "@<user_id>" trong nội dung bình luận
+var mentionPattern = regexp.MustCompile(`@([A-Za-z0-9_-]{3,64})`)
+
+type TopicCommentService interface {
+	CreateComment(ctx context.Context, actor Actor, topicID string, req *request.CreateCommentRequest) (*response.CommentResponse, error)
+	ListComments(ctx context.Context, actor Actor, topicID string, query *request.ListCommentsQuery) (*response.CommentPageResponse, error)
+	UpdateComment(ctx context.Context, actor Actor, topicID, commentID string, req *request.UpdateCommentRequest) (*response.CommentResponse, error)
+	DeleteComment(ctx context.Context, actor Actor, topicID, commentID string) error
+	ModerateComment(ctx context.Context, actor Actor, topicID, commentID string, req *request.ModerateCommentRequest) (*response.CommentResponse, error)
+}
+
+type topicCommentService struct {
+	repo        repository.TopicCommentRepository
+	topicRepo   repository.TopicRepository
+	userGateway gateway.UserGateway
+}
+
+func NewTopicCommentService(repo repository.TopicCommentRepository, topicRepo repository.TopicRepository, userGateway gateway.UserGateway) TopicCommentService {
+	return &topicCommentService{
+		repo:        repo,
+		topicRepo:   topicRepo,
+		userGateway: userGateway,
+	}
+}
+
+// CreateComment: ctx phải mang token của user để gọi UserGateway khi giải quyết mention
+func (s *topicCommentService) CreateComment(ctx context.Context, actor Actor, topicID string, req *request.CreateCommentRequest) (*response.CommentResponse, error) {
+	if _, err := s.topicRepo.GetByID(ctx, topicID); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	comment := &model.TopicComment{
+		ID:        primitive.NewObjectID(),
+		TopicID:   topicID,
+		AuthorID:  actor.UserID,
+		Content:   req.Content,
+		Mentions:  s.resolveMentions(ctx, req.Content),
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	comment.RootID = comment.ID.Hex()
+
+	if req.ParentID != "" {
+		parent, err := s.repo.GetByID(ctx, topicID, req.ParentID)
+		if err != nil {
+			return nil, ErrCommentParentNotFound
+		}
+		comment.ParentID = parent.ID.Hex()
+		comment.RootID = parent.RootID
+	}
+
+	created, err := s.repo.Create(ctx, comment)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.topicRepo.IncrementCommentCount(ctx, topicID, 1); err != nil {
+		return nil, err
+	}
+
+	return mapper.MapCommentToResponse(created), nil
+}
+
+// ListComments phân trang theo thread gốc, mỗi thread kèm toàn bộ reply.
+// Bình luận bị ẩn chỉ hiện nội dung cho admin, user khác thấy một chỗ trống để giữ cấu trúc thread.
+func (s *topicCommentService) ListComments(ctx context.Context, actor Actor, topicID string, query *request.ListCommentsQuery) (*response.CommentPageResponse, error) {
+	if _, err := s.topicRepo.GetByID(ctx, topicID); err != nil {
+		return nil, err
+	}
+
+	page, size := query.Page, query.Size
+	if page <= 0 {
+		page = 1
+	}
+	if size <= 0 {
+		size = defaultCommentPageSize
+	}
+
+	total, err := s.repo.CountRoots(ctx, topicID)
+	if err != nil {
+		return nil, err
+	}
+
+	roots, err := s.repo.ListRoots(ctx, topicID, int64((page-1)*size), int64(size))
+	if err != nil {
+		return nil, err
+	}
+
+	rootIDs := make([]string, 0, len(roots))
+	for _, root := range roots {
+		rootIDs = append(rootIDs, root.ID.Hex())
+	}
+
+	replies, err := s.repo.ListReplies(ctx, topicID, rootIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	if !actor.IsAdmin {
+		maskHidden(roots)
+		maskHidden(replies)
+	}
+
+	return &response.CommentPageResponse{
+		Items: mapper.MapCommentThreads(roots, replies),
+		Page:  page,
+		Size:  size,
+		Total: total,
+	}, nil
+}
+
+func (s *topicCommentService) UpdateComment(ctx context.Context, actor Actor, topicID, commentID string, req *request.UpdateCommentRequest) (*response.CommentResponse, error) {
+	comment, err := s.repo.GetByID(ctx, topicID, commentID)
+	if err != nil {
+		return nil, err
+	}
+	if comment.AuthorID != actor.UserID {
+		return nil, ErrCommentForbidden
+	}
+	if comment.Deleted {
+		return nil, ErrCommentDeleted
+	}
+
+	if err := s.repo.UpdateContent(ctx, topicID, commentID, req.Content, s.resolveMentions(ctx, req.Content)); err != nil {
+		return nil, err
+	}
+
+	updated, err := s.repo.GetByID(ctx, topicID, commentID)
+	if err != nil {
+		return nil, err
+	}
+	return mapper.MapCommentToResponse(updated), nil
+}
+
+func (s *topicCommentService) DeleteComment(ctx context.Context, actor Actor, topicID, commentID string) error {
+	comment, err := s.repo.GetByID(ctx, topicID, commentID)
+	if err != nil {
+		return err
+	}
+	if comment.AuthorID != actor.UserID && !actor.IsAdmin {
+		return ErrCommentForbidden
+	}
+	if comment.Deleted {
+		return mongo.ErrNoDocuments
+	}
+
+	if err := s.repo.SoftDelete(ctx, topicID, commentID); err != nil {
+		return err
+	}
+
+	// Bình luận đang bị ẩn đã được trừ khỏi bộ đếm lúc ẩn
+	if comment.Hidden {
+		return nil
+	}
+	return s.topicRepo.IncrementCommentCount(ctx, topicID, -1)
+}
+
+// ModerateComment cho phép admin ẩn hoặc hiện lại một bình luận
+func (s *topicCommentService) ModerateComment(ctx context.Context, actor Actor, topicID, commentID string, req *request.ModerateCommentRequest) (*response.CommentResponse, error) {
+	if !actor.IsAdmin {
+		return nil, ErrCommentForbidden
+	}
+
+	comment, err := s.repo.GetByID(ctx, topicID, commentID)
+	if err != nil {
+		return nil, err
+	}
+
+	hidden := *req.Hidden
+	if comment.Hidden != hidden {
+		if err := s.repo.SetHidden(ctx, topicID, commentID, hidden, actor.UserID); err != nil {
+			return nil, err
+		}
+
+		if !comment.Deleted {
+			delta := 1
+			if hidden {
+				delta = -1
+			}
+			if err := s.topicRepo.IncrementCommentCount(ctx, topicID, delta); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	updated, err := s.repo.GetByID(ctx, topicID, commentID)
+	if err != nil {
+		return nil, err
+	}
+	return mapper.MapCommentToResponse(updated), nil
+}
+
+// resolveMentions tra cứu các "@user_id" qua UserGateway, bỏ qua user không tìm thấy
+func (s *topicCommentService) resolveMentions(ctx context.Context, content string) []model.CommentMention {
+	mentions := []model.CommentMention{}
+	seen := make(map[string]bool)
+
+	for _, match := range mentionPattern.FindAllStringSubmatch(content, -1) {
+		userID := match[1]
+		if seen[userID] {
+			continue
+		}
+		seen[userID] = true
+		if len(seen) > maxCommentMentions {
+			break
+		}
+
+		user, err := s.userGateway.GetAuthorInfo(ctx, userID)
+		if err != nil || user == nil {
+			log.Printf("cannot resolve mention @%s: %v", userID, err)
+			continue
+		}
+		mentions = append(mentions, model.CommentMention{
+			UserID: userID,
+			Name:   user.Name,
+		})
+	}
+	return mentions
+}
+
+func maskHidden(comments []*model.TopicComment) {
+	for _, c := range comments {
+		if c.Hidden {
+			c.Content = ""
+			c.Mentions = nil
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package service
+
+import (
+	"context"
+	"topic-service/internal/topic/model"
+	"topic-service/internal/topic/repository"
+)
+
+// topicEvents ghi outbox event cho thay đổi topic. Thay đổi và event phải được ghi trong cùng một
+// transaction (bọc bằng inTransaction) để không có event cho thay đổi bị rollback và ngược lại.
+// Khi topic được event sourcing, projection tạo outbox event từ stream nên record không ghi gì.
+type topicEvents struct {
+	topicRepo    repository.TopicRepository
+	outboxRepo   repository.OutboxRepository
+	transactions repository.TransactionManager
+	fromStream   bool
+}
+
+func newTopicEvents(topicRepo repository.TopicRepository, outboxRepo repository.OutboxRepository, transactions repository.TransactionManager) *topicEvents {
+	return &topicEvents{
+		topicRepo:    topicRepo,
+		outboxRepo:   outboxRepo,
+		transactions: transactions,
+		fromStream:   repository.EmitsOutboxEvents(topicRepo),
+	}
+}
+
+func (e *topicEvents) inTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return e.transactions.WithTransaction(ctx, fn)
+}
+
+func (e *topicEvents) record(ctx context.Context, eventType string, topic *model.Topic) error {
+	if e.fromStream {
+		return nil
+	}
+	event, err := model.NewTopicOutboxEvent(eventType, topic)
+	if err != nil {
+		return err
+	}
+	return e.outboxRepo.Create(ctx, event)
+}
+
+// recordUpdated đọc lại topic trong transaction để payload là trạng thái sau khi ghi
+func (e *topicEvents) recordUpdated(ctx context.Context, id string) error {
+	if e.fromStream {
+		return nil
+	}
+	topic, err := e.topicRepo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	return e.record(ctx, model.TopicUpdated, topic)
+}
@@ -0,0 +1,115 @@
+package service
+
+import (
+	"context"
+	"topic-service/internal/topic/dto/response"
+	"topic-service/internal/topic/mapper"
+	"topic-service/internal/topic/model"
+	"topic-service/internal/topic/repository"
+	"topic-service/pkg/i18n"
+)
+
+type TopicFavoriteService interface {
+	AddFavorite(ctx context.Context, userID, topicID string) error
+	RemoveFavorite(ctx context.Context, userID, topicID string) error
+	ListFavorites(ctx context.Context, userID string) ([]response.TopicResponse, error)
+	ListRecentlyViewed(ctx context.Context, userID string) ([]response.TopicResponse, error)
+}
+
+type topicFavoriteService struct {
+	favoriteRepo repository.TopicFavoriteRepository
+	recentRepo   repository.RecentTopicRepository
+	topicRepo    repository.TopicRepository
+}
+
+func NewTopicFavoriteService(
+	favoriteRepo repository.TopicFavoriteRepository,
+	recentRepo repository.RecentTopicRepository,
+	topicRepo repository.TopicRepository,
+) TopicFavoriteService {
+	return &topicFavoriteService{
+		favoriteRepo: favoriteRepo,
+		recentRepo:   recentRepo,
+		topicRepo:    topicRepo,
+	}
+}
+
+func (s *topicFavoriteService) AddFavorite(ctx context.Context, userID, topicID string) error {
+	if _, err := s.topicRepo.GetByID(ctx, topicID); err != nil {
+		return err
+	}
+
+	return s.favoriteRepo.Add(ctx, userID, topicID)
+}
+
+func (s *topicFavoriteService) RemoveFavorite(ctx context.Context, userID, topicID string) error {
+	return s.favoriteRepo.Remove(ctx, userID, topicID)
+}
+
+func (s *topicFavoriteService) ListFavorites(ctx context.Context, userID string) ([]response.TopicResponse, error) {
+	favorites, err := s.favoriteRepo.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, 0, len(favorites))
+	for _, f := range favorites {
+		ids = append(ids, f.TopicID)
+	}
+
+	responses, err := s.topicsInOrder(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	for i := range responses {
+		responses[i].IsFavorite = true
+	}
+	return responses, nil
+}
+
+func (s *topicFavoriteService) ListRecentlyViewed(ctx context.Context, userID string) ([]response.TopicResponse, error) {
+	ids, err := s.recentRepo.Get(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses, err := s.topicsInOrder(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	favorites, err := s.favoriteRepo.FavoriteTopicIDs(ctx, userID, ids)
+	if err != nil {
+		return nil, err
+	}
+	for i := range responses {
+		responses[i].IsFavorite = favorites[responses[i].ID]
+	}
+	return responses, nil
+}
+
+// topicsInOrder tải các topic theo đúng thứ tự ids, bỏ qua topic đã bị xoá
+func (s *topicFavoriteService) topicsInOrder(ctx context.Context, ids []string) ([]response.TopicResponse, error) {
+	topics, err := s.topicRepo.GetByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[string]*model.Topic, len(topics))
+	for _, t := range topics {
+		byID[t.ID.Hex()] = t
+	}
+
+	ordered := make([]*model.Topic, 0, len(topics))
+	for _, id := range ids {
+		if t, ok := byID[id]; ok {
+			ordered = append(ordered, t)
+		}
+	}
+
+	responses := mapper.MapTopicsToResponses(ordered, i18n.Locale(ctx))
+	if responses == nil {
+		responses = []response.TopicResponse{}
+	}
+	return responses, nil
+}
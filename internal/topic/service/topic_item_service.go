@@ -0,0 +1,151 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+	"topic-service/internal/topic/dto/request"
+	"topic-service/internal/topic/dto/response"
+	"topic-service/internal/topic/mapper"
+	"topic-service/internal/topic/model"
+	"topic-service/internal/topic/repository"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var ErrInvalidItemOrder = errors.New("item_ids must list every item of the topic exactly once")
+
+type TopicItemService interface {
+	CreateItem(ctx context.Context, topicID string, req *request.CreateTopicItemRequest) (*response.TopicItemResponse, error)
+	GetItem(ctx context.Context, topicID, itemID string) (*response.TopicItemResponse, error)
+	ListItems(ctx context.Context, topicID string) ([]response.TopicItemResponse, error)
+	UpdateItem(ctx context.Context, topicID, itemID string, req *request.UpdateTopicItemRequest) error
+	ReorderItems(ctx context.Context, topicID string, req *request.ReorderTopicItemsRequest) ([]response.TopicItemResponse, error)
+	DeleteItem(ctx context.Context, topicID, itemID string) error
+}
+
+type topicItemService struct {
+	repo         repository.TopicItemRepository
+	topicRepo    repository.TopicRepository
+	transactions repository.TransactionManager
+}
+
+// NewTopicItemService: ghi item và cập nhật item_count của topic trong cùng một transaction
+func NewTopicItemService(repo repository.TopicItemRepository, topicRepo repository.TopicRepository, transactions repository.TransactionManager) TopicItemService {
+	return &topicItemService{
+		repo:         repo,
+		topicRepo:    topicRepo,
+		transactions: transactions,
+	}
+}
+
+func (s *topicItemService) CreateItem(ctx context.Context, topicID string, req *request.CreateTopicItemRequest) (*response.TopicItemResponse, error) {
+	topic, err := s.topicRepo.GetByID(ctx, topicID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Item mới luôn được thêm vào cuối danh sách
+	maxOrder, err := s.repo.MaxOrder(ctx, topicID)
+	if err != nil {
+		return nil, err
+	}
+
+	newItem := &model.TopicItem{
+		ID:         primitive.NewObjectID(),
+		TopicID:    topic.ID,
+		Word:       req.Word,
+		Images:     req.Images,
+		CoverImage: req.CoverImage,
+		AudioUrl:   req.AudioUrl,
+		VideoUrl:   req.VideoUrl,
+		Order:      maxOrder + 1,
+		CreatedAt:  time.Now(),
+		UpdatedAt:  time.Now(),
+	}
+
+	var createdItem *model.TopicItem
+	err = s.transactions.WithTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		createdItem, err = s.repo.Create(ctx, newItem)
+		if err != nil {
+			return err
+		}
+		return s.topicRepo.IncrementItemCount(ctx, topicID, 1)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return mapper.MapTopicItemToResponse(createdItem), nil
+}
+
+func (s *topicItemService) GetItem(ctx context.Context, topicID, itemID string) (*response.TopicItemResponse, error) {
+	item, err := s.repo.GetByID(ctx, topicID, itemID)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapper.MapTopicItemToResponse(item), nil
+}
+
+func (s *topicItemService) ListItems(ctx context.Context, topicID string) ([]response.TopicItemResponse, error) {
+	if _, err := s.topicRepo.GetByID(ctx, topicID); err != nil {
+		return nil, err
+	}
+
+	items, err := s.repo.ListByTopic(ctx, topicID)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapper.MapTopicItemsToResponses(items), nil
+}
+
+func (s *topicItemService) UpdateItem(ctx context.Context, topicID, itemID string, req *request.UpdateTopicItemRequest) error {
+	return s.repo.Update(ctx, topicID, itemID, &model.TopicItem{
+		Word:       req.Word,
+		Images:     req.Images,
+		CoverImage: req.CoverImage,
+		AudioUrl:   req.AudioUrl,
+		VideoUrl:   req.VideoUrl,
+	})
+}
+
+func (s *topicItemService) ReorderItems(ctx context.Context, topicID string, req *request.ReorderTopicItemsRequest) ([]response.TopicItemResponse, error) {
+	items, err := s.repo.ListByTopic(ctx, topicID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(req.ItemIDs) != len(items) {
+		return nil, ErrInvalidItemOrder
+	}
+
+	existing := make(map[string]bool, len(items))
+	for _, item := range items {
+		existing[item.ID.Hex()] = true
+	}
+	for _, id := range req.ItemIDs {
+		if !existing[id] {
+			return nil, ErrInvalidItemOrder
+		}
+		// Đánh dấu đã dùng để phát hiện ID bị lặp
+		delete(existing, id)
+	}
+
+	if err := s.repo.UpdateOrder(ctx, topicID, req.ItemIDs); err != nil {
+		return nil, err
+	}
+
+	return s.ListItems(ctx, topicID)
+}
+
+func (s *topicItemService) DeleteItem(ctx context.Context, topicID, itemID string) error {
+	return s.transactions.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.Delete(ctx, topicID, itemID); err != nil {
+			return err
+		}
+		return s.topicRepo.IncrementItemCount(ctx, topicID, -1)
+	})
+}
@@ -0,0 +1,293 @@
+package service
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"log"
+	"strings"
+	"time"
+	"topic-service/internal/topic/model"
+	"topic-service/internal/topic/repository"
+	"topic-service/pkg/constants"
+
+	"github.com/EventStore/EventStore-Client-Go/esdb"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const (
+	// TopicProjectionName là tên checkpoint của projection topic trong MongoDB
+	TopicProjectionName  = "topics"
+	topicProjectionGroup = "topic-service.topics"
+
+	projectionInitialBackoff = time.Second
+	projectionMaxBackoff     = 30 * time.Second
+)
+
+// ProjectionLogger ghi log từng event projection đã xử lý, zap.Logger của service thoả interface này
+type ProjectionLogger interface {
+	ProjectionEvent(projectionName string, groupName string, event *esdb.ResolvedEvent, workerID int)
+}
+
+// TopicProjector chiếu event của topic trên EventStoreDB vào collection topics
+type TopicProjector interface {
+	Close()
+}
+
+type topicProjector struct {
+	store      repository.TopicEventStore
+	projection repository.TopicProjectionRepository
+	outbox     repository.OutboxRepository
+	logger     ProjectionLogger
+	cancel     context.CancelFunc
+	done       chan struct{}
+}
+
+// NewTopicProjector chạy catch-up subscription ngay khi khởi tạo, bắt đầu từ checkpoint đã lưu.
+// Mỗi instance chạy một projector, event đã được instance khác chiếu sẽ bị bỏ qua nhờ es_version.
+// Projector cũng ghi outbox event cho từng thay đổi, xem ProjectTopicEvent.
+func NewTopicProjector(store repository.TopicEventStore, projection repository.TopicProjectionRepository, outbox repository.OutboxRepository, logger ProjectionLogger) TopicProjector {
+	ctx, cancel := context.WithCancel(context.Background())
+	p := &topicProjector{
+		store:      store,
+		projection: projection,
+		outbox:     outbox,
+		logger:     logger,
+		cancel:     cancel,
+		done:       make(chan struct{}),
+	}
+	go p.run(ctx)
+	return p
+}
+
+// Close dừng subscription và chờ event đang xử lý kết thúc
+func (p *topicProjector) Close() {
+	p.cancel()
+	<-p.done
+}
+
+func (p *topicProjector) run(ctx context.Context) {
+	defer close(p.done)
+
+	backoff := projectionInitialBackoff
+	for {
+		err := p.subscribe(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		log.Printf("projection: topic subscription stopped, retrying in %s: %v", backoff, err)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, projectionMaxBackoff)
+	}
+}
+
+func (p *topicProjector) subscribe(ctx context.Context) error {
+	checkpoint, err := p.projection.GetCheckpoint(ctx, TopicProjectionName)
+	if err != nil {
+		return err
+	}
+	subscription, err := p.store.Subscribe(ctx, checkpoint)
+	if err != nil {
+		return err
+	}
+	defer subscription.Close()
+
+	for {
+		event := subscription.Recv()
+		switch {
+		case event.SubscriptionDropped != nil:
+			return event.SubscriptionDropped.Error
+		case event.EventAppeared != nil:
+			if err := ProjectTopicEvent(ctx, p.store, p.projection, p.outbox, event.EventAppeared); err != nil {
+				return err
+			}
+			p.logger.ProjectionEvent(constants.MongoProjection, topicProjectionGroup, event.EventAppeared, 0)
+			if err := p.saveCheckpoint(ctx, event.EventAppeared.OriginalEvent().Position); err != nil {
+				return err
+			}
+		case event.CheckPointReached != nil:
+			// Server báo đã lọc qua tới vị trí này, lưu lại để lần sau không phải quét lại các event không liên quan
+			if err := p.saveCheckpoint(ctx, *event.CheckPointReached); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (p *topicProjector) saveCheckpoint(ctx context.Context, position esdb.Position) error {
+	return p.projection.SaveCheckpoint(ctx, &model.ProjectionCheckpoint{
+		Name:            TopicProjectionName,
+		CommitPosition:  position.Commit,
+		PreparePosition: position.Prepare,
+	})
+}
+
+// ProjectTopicEvent áp dụng một event lên document của topic. Event đã chiếu rồi thì bỏ qua. Document chưa có
+// hoặc bị thiếu event ở giữa thì dựng lại từ toàn bộ stream, nên topic đã xoá không bị tạo lại khi nhận lại event cũ.
+// outbox khác nil thì outbox event của thay đổi được ghi trước document, để lỗi giữa hai bước chỉ dẫn tới
+// việc chiếu lại event chứ không làm mất outbox event.
+func ProjectTopicEvent(ctx context.Context, store repository.TopicEventStore, projection repository.TopicProjectionRepository, outbox repository.OutboxRepository, event *esdb.ResolvedEvent) error {
+	recorded := event.OriginalEvent()
+	id := strings.TrimPrefix(recorded.StreamID, model.TopicStreamPrefix)
+	if !primitive.IsValidObjectID(id) {
+		log.Printf("projection: skipping event %s of unexpected stream %s", recorded.EventID, recorded.StreamID)
+		return nil
+	}
+	number := int64(recorded.EventNumber)
+
+	current, err := projection.Get(ctx, id)
+	if err != nil && !errors.Is(err, mongo.ErrNoDocuments) {
+		return err
+	}
+	if err == nil {
+		if current.Version >= number {
+			return nil
+		}
+		if current.Version == number-1 {
+			if err := current.Apply(recorded.EventType, recorded.Data); err != nil {
+				return err
+			}
+			if err := emitOutboxEvent(ctx, outbox, recorded, current); err != nil {
+				return err
+			}
+			return projection.Save(ctx, current)
+		}
+	}
+
+	aggregate, err := store.Load(ctx, id)
+	if err != nil {
+		return err
+	}
+	if aggregate.Version < 0 {
+		return nil
+	}
+	if err := emitOutboxEvent(ctx, outbox, recorded, aggregate); err != nil {
+		return err
+	}
+	return projection.Save(ctx, aggregate)
+}
+
+// outboxEventTypes ánh xạ event trên stream sang outbox event. Thay đổi bộ đếm không tạo outbox event,
+// giống khi topic được ghi thẳng vào MongoDB.
+var outboxEventTypes = map[string]string{
+	model.EventTopicCreated:             model.TopicCreated,
+	model.EventTopicDetailsChanged:      model.TopicUpdated,
+	model.EventTopicPrerequisiteAdded:   model.TopicUpdated,
+	model.EventTopicPrerequisiteRemoved: model.TopicUpdated,
+	model.EventTopicTemplateChanged:     model.TopicUpdated,
+	model.EventTopicMerged:              model.TopicUpdated,
+	model.EventTopicOwnerChanged:        model.TopicUpdated,
+	model.EventTopicDeleted:             model.TopicDeleted,
+}
+
+// emitOutboxEvent ghi outbox event cho event vừa chiếu. ID suy ra từ event trên stream nên nhiều instance
+// cùng chiếu một event, hoặc chiếu lại sau lỗi, cũng chỉ tạo một bản ghi.
+func emitOutboxEvent(ctx context.Context, outbox repository.OutboxRepository, recorded *esdb.RecordedEvent, aggregate *model.TopicAggregate) error {
+	eventType, ok := outboxEventTypes[recorded.EventType]
+	if outbox == nil || !ok {
+		return nil
+	}
+	// Dựng lại từ stream của topic đã xoá thì chỉ event xoá còn ý nghĩa
+	if aggregate.Deleted && eventType != model.TopicDeleted {
+		return nil
+	}
+
+	event, err := model.NewTopicOutboxEventWithID(outboxEventID(recorded), eventType, &aggregate.Topic)
+	if err != nil {
+		return err
+	}
+	err = outbox.Create(ctx, event)
+	if mongo.IsDuplicateKeyError(err) {
+		return nil
+	}
+	return err
+}
+
+// outboxEventID giữ 4 byte thời gian như ObjectID thường để outbox event vẫn xếp theo thời gian,
+// 8 byte còn lại lấy từ ID ngẫu nhiên của event trên stream
+func outboxEventID(recorded *esdb.RecordedEvent) primitive.ObjectID {
+	var id primitive.ObjectID
+	binary.BigEndian.PutUint32(id[:4], uint32(recorded.CreatedDate.Unix()))
+	copy(id[4:], recorded.EventID.Bytes()[8:])
+	return id
+}
+
+// RebuildTopicProjection xoá collection topics rồi chiếu lại toàn bộ event từ đầu. Topic tạo trước khi bật
+// event sourcing và chưa từng được sửa chưa có stream, chúng được nhập vào stream trước để không bị mất.
+// Các instance đang chạy projector nên được dừng trong lúc rebuild. Rebuild không tạo lại outbox event.
+func RebuildTopicProjection(ctx context.Context, store repository.TopicEventStore, projection repository.TopicProjectionRepository, topicRepo repository.TopicRepository) (int, error) {
+	if err := importLegacyTopics(ctx, store, projection, topicRepo); err != nil {
+		return 0, err
+	}
+
+	if err := projection.Clear(ctx); err != nil {
+		return 0, err
+	}
+	if err := projection.DeleteCheckpoint(ctx, TopicProjectionName); err != nil {
+		return 0, err
+	}
+
+	var (
+		count int
+		last  *esdb.Position
+	)
+	err := store.ReadAll(ctx, func(event *esdb.ResolvedEvent) error {
+		if err := ProjectTopicEvent(ctx, store, projection, nil, event); err != nil {
+			return err
+		}
+		position := event.OriginalEvent().Position
+		last = &position
+		count++
+		return nil
+	})
+	if err != nil {
+		return count, err
+	}
+
+	if last == nil {
+		return 0, nil
+	}
+	return count, projection.SaveCheckpoint(ctx, &model.ProjectionCheckpoint{
+		Name:            TopicProjectionName,
+		CommitPosition:  last.Commit,
+		PreparePosition: last.Prepare,
+	})
+}
+
+func importLegacyTopics(ctx context.Context, store repository.TopicEventStore, projection repository.TopicProjectionRepository, topicRepo repository.TopicRepository) error {
+	topics, err := topicRepo.GetAll(ctx)
+	if err != nil {
+		return err
+	}
+
+	imported := 0
+	for _, topic := range topics {
+		id := topic.ID.Hex()
+		version, found, err := projection.Version(ctx, id)
+		if err != nil {
+			return err
+		}
+		if !found || version >= 0 {
+			continue
+		}
+
+		_, err = store.Append(ctx, id, -1, model.TopicStreamEvent{
+			Type: model.EventTopicCreated,
+			Data: model.TopicCreatedData{Topic: *topic, SearchTerms: topic.SearchTerms, RatingSum: topic.RatingSum},
+		})
+		if err != nil && !errors.Is(err, repository.ErrStreamConflict) {
+			return err
+		}
+		imported++
+	}
+	if imported > 0 {
+		log.Printf("projection: imported %d topic(s) created before event sourcing", imported)
+	}
+	return nil
+}
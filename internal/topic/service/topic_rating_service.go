@@ -0,0 +1,115 @@
+package service
+
+import (
+	"context"
+	"time"
+	"topic-service/internal/topic/dto/request"
+	"topic-service/internal/topic/dto/response"
+	"topic-service/internal/topic/mapper"
+	"topic-service/internal/topic/model"
+	"topic-service/internal/topic/repository"
+)
+
+const defaultRatingPageSize = 20
+
+type TopicRatingService interface {
+	RateTopic(ctx context.Context, actor Actor, topicID string, req *request.RateTopicRequest) (*response.RatingResponse, error)
+	GetMyRating(ctx context.Context, actor Actor, topicID string) (*response.RatingResponse, error)
+	DeleteRating(ctx context.Context, actor Actor, topicID string) error
+	ListRatings(ctx context.Context, topicID string, query *request.ListRatingsQuery) (*response.RatingPageResponse, error)
+}
+
+type topicRatingService struct {
+	repo      repository.TopicRatingRepository
+	topicRepo repository.TopicRepository
+}
+
+func NewTopicRatingService(repo repository.TopicRatingRepository, topicRepo repository.TopicRepository) TopicRatingService {
+	return &topicRatingService{
+		repo:      repo,
+		topicRepo: topicRepo,
+	}
+}
+
+// RateTopic tạo hoặc cập nhật đánh giá của actor, aggregate trên topic chỉ cộng phần chênh lệch
+func (s *topicRatingService) RateTopic(ctx context.Context, actor Actor, topicID string, req *request.RateTopicRequest) (*response.RatingResponse, error) {
+	if _, err := s.topicRepo.GetByID(ctx, topicID); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	previous, err := s.repo.Upsert(ctx, &model.TopicRating{
+		TopicID:   topicID,
+		UserID:    actor.UserID,
+		Score:     req.Score,
+		Feedback:  req.Feedback,
+		CreatedAt: now,
+		UpdatedAt: now,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sumDelta, countDelta := req.Score, 1
+	if previous != nil {
+		sumDelta, countDelta = req.Score-previous.Score, 0
+	}
+	if sumDelta != 0 || countDelta != 0 {
+		if err := s.topicRepo.ApplyRatingDelta(ctx, topicID, sumDelta, countDelta); err != nil {
+			return nil, err
+		}
+	}
+
+	rating, err := s.repo.GetByUser(ctx, topicID, actor.UserID)
+	if err != nil {
+		return nil, err
+	}
+	return mapper.MapRatingToResponse(rating), nil
+}
+
+func (s *topicRatingService) GetMyRating(ctx context.Context, actor Actor, topicID string) (*response.RatingResponse, error) {
+	rating, err := s.repo.GetByUser(ctx, topicID, actor.UserID)
+	if err != nil {
+		return nil, err
+	}
+	return mapper.MapRatingToResponse(rating), nil
+}
+
+func (s *topicRatingService) DeleteRating(ctx context.Context, actor Actor, topicID string) error {
+	deleted, err := s.repo.Delete(ctx, topicID, actor.UserID)
+	if err != nil {
+		return err
+	}
+	return s.topicRepo.ApplyRatingDelta(ctx, topicID, -deleted.Score, -1)
+}
+
+func (s *topicRatingService) ListRatings(ctx context.Context, topicID string, query *request.ListRatingsQuery) (*response.RatingPageResponse, error) {
+	if _, err := s.topicRepo.GetByID(ctx, topicID); err != nil {
+		return nil, err
+	}
+
+	page, size := query.Page, query.Size
+	if page <= 0 {
+		page = 1
+	}
+	if size <= 0 {
+		size = defaultRatingPageSize
+	}
+
+	total, err := s.repo.CountByTopic(ctx, topicID)
+	if err != nil {
+		return nil, err
+	}
+
+	ratings, err := s.repo.ListByTopic(ctx, topicID, int64((page-1)*size), int64(size))
+	if err != nil {
+		return nil, err
+	}
+
+	return &response.RatingPageResponse{
+		Items: mapper.MapRatingsToResponses(ratings),
+		Page:  page,
+		Size:  size,
+		Total: total,
+	}, nil
+}
@@ -2,6 +2,8 @@ package service
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"time"
 	"topic-service/internal/gateway"
 	"topic-service/internal/topic/dto/request"
@@ -9,32 +11,80 @@ import (
 	"topic-service/internal/topic/mapper"
 	"topic-service/internal/topic/model"
 	"topic-service/internal/topic/repository"
+	"topic-service/pkg/helper"
+	"topic-service/pkg/i18n"
 
-	"github.com/gin-gonic/gin"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
 )
 
+// TopicInUseError được trả về khi xoá topic vẫn còn nằm trong curriculum
+type TopicInUseError struct {
+	Curricula []response.CurriculumRefResponse
+}
+
+func (e *TopicInUseError) Error() string {
+	return fmt.Sprintf("topic is referenced by %d curricula", len(e.Curricula))
+}
+
 type TopicService interface {
-	CreateTopic(ctx *gin.Context, req *request.CreateTopicRequest) (*response.TopicResponse, error)
-	GetTopicByID(ctx context.Context, id string) (*response.TopicResponse, error)
-	UpdateTopic(ctx context.Context, id string, topic *model.Topic) error
-	DeleteTopic(ctx context.Context, id string) error
-	ListTopics(ctx context.Context) ([]response.TopicResponse, error)
+	CreateTopic(ctx context.Context, actor Actor, req *request.CreateTopicRequest) (*response.TopicResponse, error)
+	GetTopicByID(ctx context.Context, actor Actor, id string) (*response.TopicResponse, error)
+	BatchGetTopics(ctx context.Context, actor Actor, ids []string) ([]response.TopicResponse, []string, error)
+	UpdateTopic(ctx context.Context, actor Actor, id string, topic *model.Topic) error
+	DeleteTopic(ctx context.Context, actor Actor, id string) error
+	ListTopics(ctx context.Context, actor Actor, query *request.ListTopicsQuery) ([]response.TopicResponse, error)
+	CloneTopic(ctx context.Context, actor Actor, id string, req *request.CloneTopicRequest) (*response.TopicResponse, error)
+	SetTemplate(ctx context.Context, id string, isTemplate bool) (*response.TopicResponse, error)
+	ListTemplates(ctx context.Context, query *request.ListTopicsQuery) ([]response.TopicResponse, error)
+	SearchTopics(ctx context.Context, actor Actor, query *request.SearchTopicsQuery) ([]response.TopicResponse, error)
 }
 
+const defaultSearchLimit = 10
+
+// MaxBatchTopicIDs là số topic tối đa của một lần BatchGetTopics
+const MaxBatchTopicIDs = 100
+
+var ErrTooManyTopicIDs = fmt.Errorf("at most %d topic IDs can be requested at once", MaxBatchTopicIDs)
+
 type topicService struct {
-	repo        repository.TopicRepository
-	userGateway gateway.UserGateway
+	repo           repository.TopicRepository
+	itemRepo       repository.TopicItemRepository
+	curriculumRepo repository.CurriculumRepository
+	redirectRepo   repository.TopicRedirectRepository
+	favoriteRepo   repository.TopicFavoriteRepository
+	commentRepo    repository.TopicCommentRepository
+	ratingRepo     repository.TopicRatingRepository
+	userGateway    gateway.UserGateway
+	events         *topicEvents
 }
 
-func NewTopicService(repo repository.TopicRepository, userGateway gateway.UserGateway) TopicService {
+func NewTopicService(
+	repo repository.TopicRepository,
+	itemRepo repository.TopicItemRepository,
+	curriculumRepo repository.CurriculumRepository,
+	redirectRepo repository.TopicRedirectRepository,
+	favoriteRepo repository.TopicFavoriteRepository,
+	commentRepo repository.TopicCommentRepository,
+	ratingRepo repository.TopicRatingRepository,
+	outboxRepo repository.OutboxRepository,
+	transactions repository.TransactionManager,
+	userGateway gateway.UserGateway,
+) TopicService {
 	return &topicService{
-		repo:        repo,
-		userGateway: userGateway,
+		repo:           repo,
+		itemRepo:       itemRepo,
+		curriculumRepo: curriculumRepo,
+		redirectRepo:   redirectRepo,
+		favoriteRepo:   favoriteRepo,
+		commentRepo:    commentRepo,
+		ratingRepo:     ratingRepo,
+		userGateway:    userGateway,
+		events:         newTopicEvents(repo, outboxRepo, transactions),
 	}
 }
 
-func (s *topicService) CreateTopic(ctx *gin.Context, req *request.CreateTopicRequest) (*response.TopicResponse, error) {
+func (s *topicService) CreateTopic(ctx context.Context, actor Actor, req *request.CreateTopicRequest) (*response.TopicResponse, error) {
 
 	// userIDRaw, exists := ctx.Get("user_id")
 	// if !exists {
@@ -56,46 +106,402 @@ func (s *topicService) CreateTopic(ctx *gin.Context, req *request.CreateTopicReq
 	// 	return nil, errors.New("user not found")
 	// }
 
+	locale := req.Locale
+	if locale == "" {
+		locale = i18n.Locale(ctx)
+	}
+
 	newTopic := &model.Topic{
-		ID:        primitive.NewObjectID(),
-		Title:     req.Title,
-		Icon:      req.Icon,
-		CreatedAt: time.Now(),
-		UpdatedAt: time.Now(),
+		ID:             primitive.NewObjectID(),
+		Icon:           req.Icon,
+		DefaultLocale:  locale,
+		CreatedBy:      actor.UserID,
+		OrganizationID: actor.OrganizationID,
+		CreatedAt:      time.Now(),
+		UpdatedAt:      time.Now(),
 	}
+	newTopic.SetTranslation(locale, req.Title, req.Description)
 
-	createdTopic, err := s.repo.Create(ctx, newTopic)
+	var createdTopic *model.Topic
+	err := s.events.inTransaction(ctx, func(txCtx context.Context) error {
+		var err error
+		createdTopic, err = s.repo.Create(txCtx, newTopic)
+		if err != nil {
+			return err
+		}
+		return s.events.record(txCtx, model.TopicCreated, createdTopic)
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	return mapper.MapTopicToResponse(createdTopic), nil
+	return mapper.MapTopicToResponse(createdTopic, i18n.Locale(ctx)), nil
 }
 
-func (s *topicService) GetTopicByID(ctx context.Context, id string) (*response.TopicResponse, error) {
+// GetTopicByID trả về mongo.ErrNoDocuments cả khi topic thuộc tổ chức khác, để người gọi không phân biệt được
+func (s *topicService) GetTopicByID(ctx context.Context, actor Actor, id string) (*response.TopicResponse, error) {
 	topic, err := s.repo.GetByID(ctx, id)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		// Topic có thể đã bị gộp vào topic khác
+		redirect, redirectErr := s.redirectRepo.GetByID(ctx, id)
+		if redirectErr != nil {
+			return nil, err
+		}
+
+		topic, err = s.repo.GetByID(ctx, redirect.TargetID)
+		if err != nil {
+			return nil, err
+		}
+		if !actor.CanView(topic) {
+			return nil, mongo.ErrNoDocuments
+		}
+
+		res := mapper.MapTopicToResponse(topic, i18n.Locale(ctx))
+		res.RedirectedFrom = id
+		return res, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !actor.CanView(topic) {
+		return nil, mongo.ErrNoDocuments
+	}
+
+	return mapper.MapTopicToResponse(topic, i18n.Locale(ctx)), nil
+}
+
+// BatchGetTopics lấy nhiều topic trong một query, kết quả theo thứ tự ids và bỏ ID trùng.
+// ID đã bị gộp trả về topic đích kèm RedirectedFrom giống GetTopicByID. ID không tìm thấy hoặc actor không
+// được xem (Actor.CanView) nằm trong notFound, để người gọi không phân biệt được topic của tổ chức khác.
+func (s *topicService) BatchGetTopics(ctx context.Context, actor Actor, ids []string) ([]response.TopicResponse, []string, error) {
+	ids = uniqueIDs(ids)
+	if len(ids) > MaxBatchTopicIDs {
+		return nil, nil, ErrTooManyTopicIDs
+	}
+
+	topics, err := s.repo.GetByIDs(ctx, ids)
+	if err != nil {
+		return nil, nil, err
+	}
+	found := make(map[string]*model.Topic, len(topics))
+	for _, t := range topics {
+		found[t.ID.Hex()] = t
+	}
+
+	// Topic có thể đã bị gộp vào topic khác
+	redirects := make(map[string]string)
+	var targetIDs []string
+	for _, id := range ids {
+		if _, ok := found[id]; ok {
+			continue
+		}
+		redirect, err := s.redirectRepo.GetByID(ctx, id)
+		if err != nil {
+			continue
+		}
+		redirects[id] = redirect.TargetID
+		if _, ok := found[redirect.TargetID]; !ok {
+			targetIDs = append(targetIDs, redirect.TargetID)
+		}
+	}
+	if len(targetIDs) > 0 {
+		targets, err := s.repo.GetByIDs(ctx, uniqueIDs(targetIDs))
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, t := range targets {
+			found[t.ID.Hex()] = t
+		}
+	}
+
+	locale := i18n.Locale(ctx)
+	responses := make([]response.TopicResponse, 0, len(ids))
+	notFound := []string{}
+	for _, id := range ids {
+		if t, ok := found[id]; ok {
+			if actor.CanView(t) {
+				responses = append(responses, *mapper.MapTopicToResponse(t, locale))
+			} else {
+				notFound = append(notFound, id)
+			}
+			continue
+		}
+		if t, ok := found[redirects[id]]; ok && actor.CanView(t) {
+			res := mapper.MapTopicToResponse(t, locale)
+			res.RedirectedFrom = id
+			responses = append(responses, *res)
+			continue
+		}
+		notFound = append(notFound, id)
+	}
+	return responses, notFound, nil
+}
+
+// UpdateTopic đổi tiêu đề ở locale mặc định, các bản dịch khác giữ nguyên.
+// Topic actor không được xem trả về mongo.ErrNoDocuments và không bị sửa.
+func (s *topicService) UpdateTopic(ctx context.Context, actor Actor, id string, topic *model.Topic) error {
+	return s.events.inTransaction(ctx, func(ctx context.Context) error {
+		existing, err := s.repo.GetByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if !actor.CanView(existing) {
+			return mongo.ErrNoDocuments
+		}
+
+		ensureDefaultLocale(existing)
+		existing.SetTranslation(existing.DefaultLocale, topic.Title, existing.Descriptions[existing.DefaultLocale])
+		if err := s.repo.Update(ctx, id, existing); err != nil {
+			return err
+		}
+		return s.events.record(ctx, model.TopicUpdated, existing)
+	})
+}
+
+// DeleteTopic xoá topic cùng các dữ liệu phụ thuộc trong một transaction.
+// Topic actor không được xem trả về mongo.ErrNoDocuments và không bị xoá.
+func (s *topicService) DeleteTopic(ctx context.Context, actor Actor, id string) error {
+	return s.events.inTransaction(ctx, func(ctx context.Context) error {
+		topic, err := s.repo.GetByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if !actor.CanView(topic) {
+			return mongo.ErrNoDocuments
+		}
+		return s.deleteTopic(ctx, id)
+	})
+}
+
+func (s *topicService) deleteTopic(ctx context.Context, id string) error {
+	curricula, err := s.curriculumRepo.FindByTopicID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if len(curricula) > 0 {
+		return &TopicInUseError{Curricula: mapper.MapCurriculaToRefs(curricula)}
+	}
+
+	topic, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.Delete(ctx, id); err != nil {
+		return err
+	}
+	if err := s.events.record(ctx, model.TopicDeleted, topic); err != nil {
+		return err
+	}
+
+	// Xoá các cạnh prerequisite trỏ tới topic
+	if err := s.repo.RemovePrerequisiteFromAll(ctx, id); err != nil {
+		return err
+	}
+
+	if err := s.favoriteRepo.DeleteByTopic(ctx, id); err != nil {
+		return err
+	}
+
+	if err := s.commentRepo.DeleteByTopic(ctx, id); err != nil {
+		return err
+	}
+
+	if err := s.ratingRepo.DeleteByTopic(ctx, id); err != nil {
+		return err
+	}
+
+	// Xoá các item thuộc topic
+	return s.itemRepo.DeleteByTopic(ctx, id)
+}
+
+// ListTopics chỉ trả về topic actor được xem, giống SearchTopics
+func (s *topicService) ListTopics(ctx context.Context, actor Actor, query *request.ListTopicsQuery) ([]response.TopicResponse, error) {
+	filter := topicFilterFromQuery(query)
+	filter.OrganizationID = actor.organizationScope()
+
+	topics, err := s.repo.List(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := mapper.MapTopicsToResponses(topics, i18n.Locale(ctx))
+
+	// Lấy toàn bộ favorite của trang hiện tại trong một query
+	ids := make([]string, 0, len(responses))
+	for _, res := range responses {
+		ids = append(ids, res.ID)
+	}
+	favorites, err := s.favoriteRepo.FavoriteTopicIDs(ctx, actor.UserID, ids)
 	if err != nil {
 		return nil, err
 	}
+	for i := range responses {
+		responses[i].IsFavorite = favorites[responses[i].ID]
+	}
 
-	return mapper.MapTopicToResponse(topic), nil
+	return responses, nil
 }
 
-func (s *topicService) UpdateTopic(ctx context.Context, id string, topic *model.Topic) error {
-	return s.repo.Update(ctx, id, topic)
+// CloneTopic sao chép topic cùng các dữ liệu liên quan được chọn thành topic mới thuộc về actor.
+// Chỉ sao chép được topic actor được xem (Actor.CanView, gồm cả template), topic khác trả về mongo.ErrNoDocuments.
+func (s *topicService) CloneTopic(ctx context.Context, actor Actor, id string, req *request.CloneTopicRequest) (*response.TopicResponse, error) {
+	source, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !actor.CanView(source) {
+		return nil, mongo.ErrNoDocuments
+	}
+
+	suffix := " (copy)"
+	if req.TitleSuffix != nil {
+		suffix = *req.TitleSuffix
+	}
+
+	now := time.Now()
+	clone := &model.Topic{
+		ID:             primitive.NewObjectID(),
+		Title:          source.Title + suffix,
+		DefaultLocale:  source.DefaultLocale,
+		CreatedBy:      actor.UserID,
+		OrganizationID: actor.OrganizationID,
+		SourceTopicID:  source.ID.Hex(),
+		ClonedAt:       &now,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+	// Hậu tố được thêm vào tiêu đề ở mọi locale
+	for locale, title := range source.Titles {
+		clone.SetTranslation(locale, title+suffix, source.Descriptions[locale])
+	}
+	clone.RefreshSearchTerms()
+
+	if req.IncludeIcon == nil || *req.IncludeIcon {
+		clone.Icon = source.Icon
+	}
+
+	if req.IncludePrerequisites {
+		clone.PrerequisiteIDs = append([]string(nil), source.PrerequisiteIDs...)
+	}
+
+	var items []*model.TopicItem
+	if req.IncludeItems {
+		sourceItems, err := s.itemRepo.ListByTopic(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range sourceItems {
+			copied := *item
+			copied.ID = primitive.NewObjectID()
+			copied.TopicID = clone.ID
+			copied.Images = append([]string(nil), item.Images...)
+			copied.CreatedAt = now
+			copied.UpdatedAt = now
+			items = append(items, &copied)
+		}
+		clone.ItemCount = len(items)
+	}
+
+	var createdTopic *model.Topic
+	err = s.events.inTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		createdTopic, err = s.repo.Create(ctx, clone)
+		if err != nil {
+			return err
+		}
+		if err := s.itemRepo.CreateMany(ctx, items); err != nil {
+			return err
+		}
+		return s.events.record(ctx, model.TopicCreated, createdTopic)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return mapper.MapTopicToResponse(createdTopic, i18n.Locale(ctx)), nil
 }
 
-func (s *topicService) DeleteTopic(ctx context.Context, id string) error {
-	return s.repo.Delete(ctx, id)
+func (s *topicService) SetTemplate(ctx context.Context, id string, isTemplate bool) (*response.TopicResponse, error) {
+	err := s.events.inTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.SetTemplate(ctx, id, isTemplate); err != nil {
+			return err
+		}
+		return s.events.recordUpdated(ctx, id)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Route chỉ dành cho admin
+	return s.GetTopicByID(ctx, Actor{IsAdmin: true}, id)
 }
 
-func (s *topicService) ListTopics(ctx context.Context) ([]response.TopicResponse, error) {
-	topics, err := s.repo.GetAll(ctx)
+func (s *topicService) ListTemplates(ctx context.Context, query *request.ListTopicsQuery) ([]response.TopicResponse, error) {
+	filter := topicFilterFromQuery(query)
+	isTemplate := true
+	filter.IsTemplate = &isTemplate
+
+	topics, err := s.repo.List(ctx, filter)
 	if err != nil {
 		return nil, err
 	}
 
-	return mapper.MapTopicsToResponses(topics), nil
+	return mapper.MapTopicsToResponses(topics, i18n.Locale(ctx)), nil
+}
+
+// SearchTopics tìm theo tiêu đề ở mọi locale, không phân biệt dấu. Từ cuối của q được khớp
+// theo tiền tố để dùng cho typeahead.
+func (s *topicService) SearchTopics(ctx context.Context, actor Actor, query *request.SearchTopicsQuery) ([]response.TopicResponse, error) {
+	tokens := helper.SearchTokens(query.Q)
+	if len(tokens) == 0 {
+		return []response.TopicResponse{}, nil
+	}
+
+	limit := query.Limit
+	if limit <= 0 {
+		limit = defaultSearchLimit
+	}
+
+	filter := repository.TopicSearchFilter{
+		Terms:          tokens[:len(tokens)-1],
+		Prefix:         tokens[len(tokens)-1],
+		Limit:          limit,
+		OrganizationID: actor.organizationScope(),
+	}
+
+	topics, err := s.repo.Search(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := mapper.MapTopicsToResponses(topics, i18n.Locale(ctx))
+	if responses == nil {
+		responses = []response.TopicResponse{}
+	}
+	return responses, nil
+}
+
+// ensureDefaultLocale gán locale mặc định cho topic tạo trước khi có bản dịch
+func ensureDefaultLocale(t *model.Topic) {
+	if t.DefaultLocale != "" {
+		return
+	}
+	t.DefaultLocale = i18n.DefaultLocale
+	if t.Titles[t.DefaultLocale] == "" {
+		t.SetTranslation(t.DefaultLocale, t.Title, "")
+	}
+}
+
+func topicFilterFromQuery(query *request.ListTopicsQuery) repository.TopicFilter {
+	if query == nil {
+		return repository.TopicFilter{}
+	}
+	return repository.TopicFilter{
+		MinRating:      query.MinRating,
+		MaxRating:      query.MaxRating,
+		MinRatingCount: query.MinRatingCount,
+		Sort:           query.Sort,
+	}
 }
 
 func (s *topicService) GetAuthorInfo(ctx context.Context, userID string) (*gateway.User, error) {
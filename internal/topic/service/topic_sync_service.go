@@ -0,0 +1,170 @@
+package service
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"time"
+	"topic-service/internal/topic/dto/request"
+	"topic-service/internal/topic/dto/response"
+	"topic-service/internal/topic/mapper"
+	"topic-service/internal/topic/model"
+	"topic-service/internal/topic/repository"
+	"topic-service/pkg/i18n"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	defaultTopicChangesLimit = 200
+	// Token chỉ dùng được khi outbox còn giữ mọi event sau nó, ngắn hơn TTL 7 ngày của event đã gửi
+	topicSyncTokenMaxAge = 6 * 24 * time.Hour
+	// Chỉ đọc event đã ghi quá topicSyncLag để transaction ghi event sớm hơn nhưng commit muộn hơn
+	// không bị token vượt qua
+	topicSyncLag     = 5 * time.Second
+	topicSyncVersion = 1
+)
+
+var ErrInvalidSyncToken = errors.New("invalid sync token")
+
+// TopicSyncService cho client offline tải các thay đổi topic kể từ lần đồng bộ trước.
+// Thay đổi được đọc từ outbox nên gồm mọi thay đổi có TopicCreated/TopicUpdated/TopicDeleted;
+// bộ đếm item, bình luận và đánh giá chỉ được cập nhật khi topic có thay đổi khác.
+type TopicSyncService interface {
+	GetChanges(ctx context.Context, actor Actor, query *request.TopicChangesQuery) (*response.TopicChangesResponse, error)
+}
+
+type topicSyncService struct {
+	topicRepo  repository.TopicRepository
+	outboxRepo repository.OutboxRepository
+}
+
+func NewTopicSyncService(topicRepo repository.TopicRepository, outboxRepo repository.OutboxRepository) TopicSyncService {
+	return &topicSyncService{topicRepo: topicRepo, outboxRepo: outboxRepo}
+}
+
+// syncPosition là vị trí trong outbox mà client đã đồng bộ tới, token là base64 của JSON này
+type syncPosition struct {
+	Version   int    `json:"v"`
+	CreatedAt int64  `json:"t"`
+	EventID   string `json:"id,omitempty"`
+}
+
+func (s *topicSyncService) GetChanges(ctx context.Context, actor Actor, query *request.TopicChangesQuery) (*response.TopicChangesResponse, error) {
+	now := time.Now()
+	until := now.Add(-topicSyncLag)
+
+	if query.Since == "" {
+		return resyncResponse(until), nil
+	}
+	createdAt, eventID, err := decodeSyncToken(query.Since)
+	if err != nil {
+		return nil, err
+	}
+	if createdAt.Before(now.Add(-topicSyncTokenMaxAge)) {
+		return resyncResponse(until), nil
+	}
+
+	limit := query.Limit
+	if limit <= 0 {
+		limit = defaultTopicChangesLimit
+	}
+
+	events, err := s.outboxRepo.ListSince(ctx, createdAt, eventID, until, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &response.TopicChangesResponse{
+		Topics:    []response.TopicResponse{},
+		Deleted:   []response.TopicTombstoneResponse{},
+		NextToken: encodeSyncToken(createdAt, eventID),
+		HasMore:   len(events) == limit,
+	}
+	if len(events) == 0 {
+		return res, nil
+	}
+	last := events[len(events)-1]
+	res.NextToken = encodeSyncToken(last.CreatedAt, last.ID)
+
+	// Chỉ event cuối của mỗi topic trong trang là đáng kể, thứ tự theo lần thay đổi cuối
+	latest := make(map[string]*model.OutboxEvent, len(events))
+	var order []string
+	for _, event := range events {
+		if _, seen := latest[event.AggregateID]; !seen {
+			order = append(order, event.AggregateID)
+		}
+		latest[event.AggregateID] = event
+	}
+
+	var changedIDs []string
+	for _, id := range order {
+		if latest[id].Type != model.TopicDeleted {
+			changedIDs = append(changedIDs, id)
+		}
+	}
+	topics, err := s.topicRepo.GetByIDs(ctx, changedIDs)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[string]*model.Topic, len(topics))
+	for _, topic := range topics {
+		byID[topic.ID.Hex()] = topic
+	}
+
+	locale := i18n.Locale(ctx)
+	for _, id := range order {
+		event := latest[id]
+		topic, found := byID[id]
+		if event.Type == model.TopicDeleted || !found {
+			// Tombstone chỉ chứa ID nên được gửi cho mọi client, kể cả template của tổ chức khác
+			// mà client có thể đang lưu
+			res.Deleted = append(res.Deleted, response.TopicTombstoneResponse{ID: id, DeletedAt: event.CreatedAt})
+			continue
+		}
+		if actor.CanView(topic) {
+			res.Topics = append(res.Topics, *mapper.MapTopicToResponse(topic, locale))
+		}
+	}
+	return res, nil
+}
+
+// resyncResponse trả về token ở thời điểm until để client đồng bộ tiếp sau khi tải lại toàn bộ
+func resyncResponse(until time.Time) *response.TopicChangesResponse {
+	return &response.TopicChangesResponse{
+		Topics:         []response.TopicResponse{},
+		Deleted:        []response.TopicTombstoneResponse{},
+		NextToken:      encodeSyncToken(until, primitive.NilObjectID),
+		ResyncRequired: true,
+	}
+}
+
+func encodeSyncToken(createdAt time.Time, eventID primitive.ObjectID) string {
+	position := syncPosition{Version: topicSyncVersion, CreatedAt: createdAt.UnixMilli()}
+	if !eventID.IsZero() {
+		position.EventID = eventID.Hex()
+	}
+	data, _ := json.Marshal(position)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeSyncToken(token string) (time.Time, primitive.ObjectID, error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return time.Time{}, primitive.NilObjectID, ErrInvalidSyncToken
+	}
+	var position syncPosition
+	if err := json.Unmarshal(data, &position); err != nil || position.Version != topicSyncVersion {
+		return time.Time{}, primitive.NilObjectID, ErrInvalidSyncToken
+	}
+
+	eventID := primitive.NilObjectID
+	if position.EventID != "" {
+		eventID, err = primitive.ObjectIDFromHex(position.EventID)
+		if err != nil {
+			return time.Time{}, primitive.NilObjectID, ErrInvalidSyncToken
+		}
+	}
+	return time.UnixMilli(position.CreatedAt), eventID, nil
+}
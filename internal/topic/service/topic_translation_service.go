@@ -0,0 +1,113 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"topic-service/internal/topic/dto/request"
+	"topic-service/internal/topic/dto/response"
+	"topic-service/internal/topic/model"
+	"topic-service/internal/topic/repository"
+	"topic-service/pkg/i18n"
+)
+
+var (
+	ErrUnsupportedLocale        = errors.New("locale is not supported")
+	ErrDeleteDefaultTranslation = errors.New("cannot delete the translation of the default locale")
+)
+
+type TopicTranslationService interface {
+	GetTranslations(ctx context.Context, topicID string) (*response.TopicTranslationsResponse, error)
+	UpsertTranslation(ctx context.Context, topicID, locale string, req *request.UpsertTranslationRequest) (*response.TopicTranslationsResponse, error)
+	DeleteTranslation(ctx context.Context, topicID, locale string) (*response.TopicTranslationsResponse, error)
+}
+
+type topicTranslationService struct {
+	topicRepo repository.TopicRepository
+	events    *topicEvents
+}
+
+func NewTopicTranslationService(
+	topicRepo repository.TopicRepository,
+	outboxRepo repository.OutboxRepository,
+	transactions repository.TransactionManager,
+) TopicTranslationService {
+	return &topicTranslationService{
+		topicRepo: topicRepo,
+		events:    newTopicEvents(topicRepo, outboxRepo, transactions),
+	}
+}
+
+func (s *topicTranslationService) GetTranslations(ctx context.Context, topicID string) (*response.TopicTranslationsResponse, error) {
+	topic, err := s.topicRepo.GetByID(ctx, topicID)
+	if err != nil {
+		return nil, err
+	}
+
+	ensureDefaultLocale(topic)
+	return mapTranslations(topic), nil
+}
+
+func (s *topicTranslationService) UpsertTranslation(ctx context.Context, topicID, locale string, req *request.UpsertTranslationRequest) (*response.TopicTranslationsResponse, error) {
+	if !i18n.IsSupported(locale) {
+		return nil, ErrUnsupportedLocale
+	}
+
+	topic, err := s.topicRepo.GetByID(ctx, topicID)
+	if err != nil {
+		return nil, err
+	}
+
+	ensureDefaultLocale(topic)
+	if req.IsDefault {
+		topic.DefaultLocale = locale
+	}
+	topic.SetTranslation(locale, req.Title, req.Description)
+
+	if err := s.update(ctx, topicID, topic); err != nil {
+		return nil, err
+	}
+	return mapTranslations(topic), nil
+}
+
+func (s *topicTranslationService) DeleteTranslation(ctx context.Context, topicID, locale string) (*response.TopicTranslationsResponse, error) {
+	topic, err := s.topicRepo.GetByID(ctx, topicID)
+	if err != nil {
+		return nil, err
+	}
+
+	ensureDefaultLocale(topic)
+	if locale == topic.DefaultLocale {
+		return nil, ErrDeleteDefaultTranslation
+	}
+	topic.RemoveTranslation(locale)
+
+	if err := s.update(ctx, topicID, topic); err != nil {
+		return nil, err
+	}
+	return mapTranslations(topic), nil
+}
+
+func (s *topicTranslationService) update(ctx context.Context, topicID string, topic *model.Topic) error {
+	return s.events.inTransaction(ctx, func(ctx context.Context) error {
+		if err := s.topicRepo.Update(ctx, topicID, topic); err != nil {
+			return err
+		}
+		return s.events.record(ctx, model.TopicUpdated, topic)
+	})
+}
+
+func mapTranslations(topic *model.Topic) *response.TopicTranslationsResponse {
+	translations := make(map[string]response.TopicTranslationResponse, len(topic.Titles))
+	for locale, title := range topic.Titles {
+		translations[locale] = response.TopicTranslationResponse{
+			Title:       title,
+			Description: topic.Descriptions[locale],
+		}
+	}
+
+	return &response.TopicTranslationsResponse{
+		TopicID:       topic.ID.Hex(),
+		DefaultLocale: topic.DefaultLocale,
+		Translations:  translations,
+	}
+}
@@ -0,0 +1,34 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"topic-service/internal/topic/model"
+	"topic-service/pkg/kafka"
+)
+
+// Kafka topic và consumer group mặc định cho event vòng đời user của go-main-service
+const (
+	DefaultUserEventsTopic = "go-main-service.user-events"
+	DefaultUserEventsGroup = "topic-service.user-lifecycle"
+)
+
+// NewUserEventHandler đọc UserLifecycleEvent từ message Kafka. Lỗi xử lý được trả về để consumer group thử lại,
+// message không đọc được thì bị bỏ qua vì gửi lại cũng không đọc được.
+func NewUserEventHandler(svc UserLifecycleService) kafka.Handler {
+	return func(ctx context.Context, message *kafka.Message) error {
+		var event model.UserLifecycleEvent
+		if err := json.Unmarshal(message.Value, &event); err != nil {
+			log.Printf("user-lifecycle: skipping malformed message at %s/%d@%d: %v", message.Topic, message.Partition, message.Offset, err)
+			return nil
+		}
+		_, err := svc.HandleUserEvent(ctx, &event)
+		if errors.Is(err, ErrInvalidUserEvent) {
+			log.Printf("user-lifecycle: skipping invalid event at %s/%d@%d: %v", message.Topic, message.Partition, message.Offset, err)
+			return nil
+		}
+		return err
+	}
+}
@@ -0,0 +1,190 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+	"topic-service/internal/gateway"
+	"topic-service/internal/topic/dto/response"
+	"topic-service/internal/topic/model"
+	"topic-service/internal/topic/repository"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const (
+	// userLifecycleConsumer là tên consumer khi đánh dấu event đã xử lý
+	userLifecycleConsumer = "user-lifecycle"
+	// systemActorID là actor của các thay đổi do service tự thực hiện
+	systemActorID = "system"
+
+	transferTargetAdmin    = "organization_admin"
+	transferTargetFallback = "fallback_owner"
+)
+
+var ErrInvalidUserEvent = errors.New("user event has no user ID")
+
+// UserLifecycleOptions: FallbackOwnerID nhận topic khi tổ chức của topic không còn admin nào khác
+type UserLifecycleOptions struct {
+	FallbackOwnerID string
+}
+
+// UserLifecycleService xử lý event user bị xoá hoặc bị khoá: topic của user được chuyển cho admin của tổ chức
+// hoặc fallback owner, mỗi lần chuyển được ghi vào audit log của topic.
+type UserLifecycleService interface {
+	HandleUserEvent(ctx context.Context, event *model.UserLifecycleEvent) (*response.UserEventResultResponse, error)
+}
+
+type userLifecycleService struct {
+	topicRepo     repository.TopicRepository
+	auditRepo     repository.TopicAuditRepository
+	processedRepo repository.ProcessedEventRepository
+	userGateway   gateway.UserGateway
+	events        *topicEvents
+	opts          UserLifecycleOptions
+}
+
+func NewUserLifecycleService(
+	topicRepo repository.TopicRepository,
+	auditRepo repository.TopicAuditRepository,
+	processedRepo repository.ProcessedEventRepository,
+	outboxRepo repository.OutboxRepository,
+	transactions repository.TransactionManager,
+	userGateway gateway.UserGateway,
+	opts UserLifecycleOptions,
+) UserLifecycleService {
+	return &userLifecycleService{
+		topicRepo:     topicRepo,
+		auditRepo:     auditRepo,
+		processedRepo: processedRepo,
+		userGateway:   userGateway,
+		events:        newTopicEvents(topicRepo, outboxRepo, transactions),
+		opts:          opts,
+	}
+}
+
+// HandleUserEvent an toàn khi cùng một event được giao nhiều lần: event đã xử lý xong bị bỏ qua, còn lần giao lại
+// của event đang xử lý dở chỉ thấy các topic chưa được chuyển vì topic đã chuyển không còn thuộc user.
+func (s *userLifecycleService) HandleUserEvent(ctx context.Context, event *model.UserLifecycleEvent) (*response.UserEventResultResponse, error) {
+	result := &response.UserEventResultResponse{}
+	if event.Type != model.UserEventDeleted && event.Type != model.UserEventDeactivated {
+		result.Ignored = true
+		return result, nil
+	}
+	if event.UserID == "" {
+		return nil, ErrInvalidUserEvent
+	}
+
+	eventID := event.EventID
+	if eventID == "" {
+		eventID = event.Type + ":" + event.UserID
+	}
+	processed, err := s.processedRepo.IsProcessed(ctx, userLifecycleConsumer, eventID)
+	if err != nil {
+		return nil, err
+	}
+	if processed {
+		result.Duplicate = true
+		return result, nil
+	}
+
+	topics, err := s.topicRepo.ListByCreator(ctx, event.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	targets := make(map[string]transferTarget)
+	for _, topic := range topics {
+		target, err := s.transferTarget(ctx, topic.OrganizationID, event.UserID, targets)
+		if err != nil {
+			return nil, err
+		}
+		if target.ownerID == "" {
+			log.Printf("user-lifecycle: no owner to take over topic %s of user %s", topic.ID.Hex(), event.UserID)
+			result.Skipped++
+			continue
+		}
+
+		err = s.transfer(ctx, topic, target, event, eventID)
+		// Topic bị xoá trong lúc đang chuyển thì không còn gì để làm
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		result.Transferred++
+	}
+
+	// Còn topic chưa chuyển được thì không đánh dấu, gửi lại event sau khi cấu hình fallback owner sẽ chuyển nốt
+	if result.Skipped == 0 {
+		if err := s.processedRepo.MarkProcessed(ctx, userLifecycleConsumer, eventID); err != nil {
+			return nil, err
+		}
+	}
+	log.Printf("user-lifecycle: %s for user %s transferred %d topic(s), skipped %d", event.Type, event.UserID, result.Transferred, result.Skipped)
+	return result, nil
+}
+
+type transferTarget struct {
+	ownerID string
+	source  string
+}
+
+// transferTarget chọn admin đầu tiên của tổ chức khác với user bị xoá, không có thì dùng fallback owner.
+// Kết quả được nhớ theo tổ chức trong một lần xử lý event.
+func (s *userLifecycleService) transferTarget(ctx context.Context, organizationID, userID string, cache map[string]transferTarget) (transferTarget, error) {
+	if target, ok := cache[organizationID]; ok {
+		return target, nil
+	}
+
+	var target transferTarget
+	if organizationID != "" {
+		admins, err := s.userGateway.GetOrganizationAdmins(ctx, organizationID)
+		if err != nil {
+			return target, err
+		}
+		for _, admin := range admins {
+			if admin.ID != "" && admin.ID != userID {
+				target = transferTarget{ownerID: admin.ID, source: transferTargetAdmin}
+				break
+			}
+		}
+	}
+	if target.ownerID == "" && s.opts.FallbackOwnerID != "" && s.opts.FallbackOwnerID != userID {
+		target = transferTarget{ownerID: s.opts.FallbackOwnerID, source: transferTargetFallback}
+	}
+
+	cache[organizationID] = target
+	return target, nil
+}
+
+// transfer đổi owner, ghi audit log và outbox event trong cùng một transaction
+func (s *userLifecycleService) transfer(ctx context.Context, topic *model.Topic, target transferTarget, event *model.UserLifecycleEvent, eventID string) error {
+	id := topic.ID.Hex()
+	return s.events.inTransaction(ctx, func(ctx context.Context) error {
+		if err := s.topicRepo.SetOwner(ctx, id, target.ownerID); err != nil {
+			return err
+		}
+		err := s.auditRepo.Create(ctx, &model.TopicAuditLog{
+			ID:      primitive.NewObjectID(),
+			Action:  model.AuditActionOwnershipTransfer,
+			TopicID: id,
+			ActorID: systemActorID,
+			Details: map[string]interface{}{
+				"from_user_id": event.UserID,
+				"to_user_id":   target.ownerID,
+				"target":       target.source,
+				"reason":       event.Type,
+				"event_id":     eventID,
+			},
+			CreatedAt: time.Now(),
+		})
+		if err != nil {
+			return err
+		}
+		return s.events.recordUpdated(ctx, id)
+	})
+}
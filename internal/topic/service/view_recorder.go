@@ -0,0 +1,256 @@
+package service
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+	"topic-service/internal/topic/model"
+	"topic-service/internal/topic/repository"
+	"topic-service/pkg/helper"
+)
+
+const (
+	defaultViewBufferSize    = 1024
+	defaultViewFlushInterval = 5 * time.Second
+	maxPendingViewCounters   = 500
+	// Số topic tối đa trong danh sách xem gần đây của mỗi user
+	recentTopicsLimit = 20
+	// Hai lượt xem của cùng user cách nhau không quá viewSessionGap được coi là cùng phiên
+	viewSessionGap = 30 * time.Minute
+	// Chỉ ghép cặp với các topic xem gần nhất trong phiên để số cặp không tăng quá nhanh
+	maxSessionTopics = 20
+)
+
+// ViewRecorder ghi nhận lượt xem topic mà không làm chậm request
+type ViewRecorder interface {
+	Record(event model.TopicViewEvent)
+	Close()
+}
+
+type viewStatKey struct {
+	topicID        string
+	organizationID string
+	date           string
+}
+
+type coViewKey struct {
+	topicA string
+	topicB string
+}
+
+// viewSession là các topic một user đã xem trong phiên hiện tại, chỉ được dùng trong goroutine run
+type viewSession struct {
+	lastViewedAt time.Time
+	topicIDs     []string
+}
+
+// asyncViewRecorder gom lượt xem vào bộ đệm và ghi xuống DB theo lô:
+// bộ đếm theo ngày, danh sách xem gần đây của từng user và số lần xem cùng phiên của từng cặp topic
+type asyncViewRecorder struct {
+	repo          repository.TopicViewStatRepository
+	recentRepo    repository.RecentTopicRepository
+	coViewRepo    repository.TopicCoViewRepository
+	events        chan model.TopicViewEvent
+	flushInterval time.Duration
+	done          chan struct{}
+
+	mu     sync.RWMutex
+	closed bool
+}
+
+func NewViewRecorder(
+	repo repository.TopicViewStatRepository,
+	recentRepo repository.RecentTopicRepository,
+	coViewRepo repository.TopicCoViewRepository,
+) ViewRecorder {
+	r := &asyncViewRecorder{
+		repo:          repo,
+		recentRepo:    recentRepo,
+		coViewRepo:    coViewRepo,
+		events:        make(chan model.TopicViewEvent, defaultViewBufferSize),
+		flushInterval: defaultViewFlushInterval,
+		done:          make(chan struct{}),
+	}
+	go r.run()
+	return r
+}
+
+// Record không bao giờ block: nếu bộ đệm đầy thì lượt xem bị bỏ qua
+func (r *asyncViewRecorder) Record(event model.TopicViewEvent) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if r.closed {
+		return
+	}
+
+	if event.ViewedAt.IsZero() {
+		event.ViewedAt = time.Now()
+	}
+
+	select {
+	case r.events <- event:
+	default:
+		log.Printf("view recorder buffer full, dropping view of topic %s", event.TopicID)
+	}
+}
+
+// Close dừng nhận lượt xem mới và ghi nốt những gì còn trong bộ đệm
+func (r *asyncViewRecorder) Close() {
+	r.mu.Lock()
+	if r.closed {
+		r.mu.Unlock()
+		return
+	}
+	r.closed = true
+	close(r.events)
+	r.mu.Unlock()
+
+	<-r.done
+}
+
+func (r *asyncViewRecorder) run() {
+	defer close(r.done)
+
+	ticker := time.NewTicker(r.flushInterval)
+	defer ticker.Stop()
+
+	pending := make(map[viewStatKey]int64)
+	// recent lưu các topic user đã xem theo thứ tự thời gian
+	recent := make(map[string][]string)
+	coViews := make(map[coViewKey]int64)
+	sessions := make(map[string]*viewSession)
+	for {
+		select {
+		case event, ok := <-r.events:
+			if !ok {
+				r.flush(pending, recent, coViews)
+				return
+			}
+			key := viewStatKey{
+				topicID:        event.TopicID,
+				organizationID: event.OrganizationID,
+				date:           helper.FormatDate(event.ViewedAt.UTC()),
+			}
+			pending[key]++
+			if event.UserID != "" {
+				recent[event.UserID] = append(recent[event.UserID], event.TopicID)
+				trackSession(sessions, coViews, event)
+			}
+			if len(pending) >= maxPendingViewCounters || len(coViews) >= maxPendingViewCounters {
+				r.flush(pending, recent, coViews)
+				pending = make(map[viewStatKey]int64)
+				recent = make(map[string][]string)
+				coViews = make(map[coViewKey]int64)
+			}
+		case now := <-ticker.C:
+			if len(pending) > 0 || len(recent) > 0 || len(coViews) > 0 {
+				r.flush(pending, recent, coViews)
+				pending = make(map[viewStatKey]int64)
+				recent = make(map[string][]string)
+				coViews = make(map[coViewKey]int64)
+			}
+			for userID, session := range sessions {
+				if now.Sub(session.lastViewedAt) > viewSessionGap {
+					delete(sessions, userID)
+				}
+			}
+		}
+	}
+}
+
+// trackSession ghép topic vừa xem với các topic khác trong cùng phiên của user
+func trackSession(sessions map[string]*viewSession, coViews map[coViewKey]int64, event model.TopicViewEvent) {
+	session, ok := sessions[event.UserID]
+	if !ok || event.ViewedAt.Sub(session.lastViewedAt) > viewSessionGap {
+		session = &viewSession{}
+		sessions[event.UserID] = session
+	}
+	session.lastViewedAt = event.ViewedAt
+
+	for _, other := range session.topicIDs {
+		if other == event.TopicID {
+			// Xem lại topic đã có trong phiên thì không tính thêm cặp
+			return
+		}
+	}
+	for _, other := range session.topicIDs {
+		key := coViewKey{topicA: other, topicB: event.TopicID}
+		if key.topicA > key.topicB {
+			key.topicA, key.topicB = key.topicB, key.topicA
+		}
+		coViews[key]++
+	}
+
+	session.topicIDs = append(session.topicIDs, event.TopicID)
+	if len(session.topicIDs) > maxSessionTopics {
+		session.topicIDs = session.topicIDs[1:]
+	}
+}
+
+func (r *asyncViewRecorder) flush(pending map[viewStatKey]int64, recent map[string][]string, coViews map[coViewKey]int64) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	r.flushCounters(ctx, pending)
+	r.flushRecent(ctx, recent)
+	r.flushCoViews(ctx, coViews)
+}
+
+func (r *asyncViewRecorder) flushCoViews(ctx context.Context, coViews map[coViewKey]int64) {
+	if len(coViews) == 0 {
+		return
+	}
+
+	pairs := make([]model.TopicCoView, 0, len(coViews))
+	for key, count := range coViews {
+		pairs = append(pairs, model.TopicCoView{
+			TopicA: key.topicA,
+			TopicB: key.topicB,
+			Count:  count,
+		})
+	}
+
+	if err := r.coViewRepo.IncrementPairs(ctx, pairs); err != nil {
+		log.Printf("failed to flush %d topic co-view counters: %v", len(pairs), err)
+	}
+}
+
+func (r *asyncViewRecorder) flushRecent(ctx context.Context, recent map[string][]string) {
+	for userID, viewed := range recent {
+		// Đảo ngược để topic xem sau cùng đứng đầu, bỏ các lần xem trùng
+		seen := make(map[string]bool, len(viewed))
+		latestFirst := make([]string, 0, len(viewed))
+		for i := len(viewed) - 1; i >= 0; i-- {
+			if seen[viewed[i]] {
+				continue
+			}
+			seen[viewed[i]] = true
+			latestFirst = append(latestFirst, viewed[i])
+		}
+
+		if err := r.recentRepo.Push(ctx, userID, latestFirst, recentTopicsLimit); err != nil {
+			log.Printf("failed to update recently viewed topics of user %s: %v", userID, err)
+		}
+	}
+}
+
+func (r *asyncViewRecorder) flushCounters(ctx context.Context, pending map[viewStatKey]int64) {
+	if len(pending) == 0 {
+		return
+	}
+
+	stats := make([]model.TopicViewStat, 0, len(pending))
+	for key, views := range pending {
+		stats = append(stats, model.TopicViewStat{
+			TopicID:        key.topicID,
+			OrganizationID: key.organizationID,
+			Date:           key.date,
+			Views:          views,
+		})
+	}
+
+	if err := r.repo.IncrementViews(ctx, stats); err != nil {
+		log.Printf("failed to flush %d topic view counters: %v", len(stats), err)
+	}
+}
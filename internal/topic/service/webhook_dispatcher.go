@@ -0,0 +1,319 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+	"topic-service/internal/topic/model"
+	"topic-service/internal/topic/repository"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const (
+	DefaultWebhookPollInterval   = time.Second
+	DefaultWebhookWorkers        = 4
+	DefaultWebhookMaxAttempts    = 8
+	DefaultWebhookInitialBackoff = 10 * time.Second
+	DefaultWebhookMaxBackoff     = time.Hour
+	DefaultWebhookRequestTimeout = 10 * time.Second
+	// DefaultWebhookDisableAfter là số lần gửi lỗi liên tiếp trước khi subscription bị tắt
+	DefaultWebhookDisableAfter = 20
+)
+
+// Header của request webhook. Bên nhận tính lại HMAC-SHA256 của "<timestamp>.<body>" bằng secret
+// để kiểm tra chữ ký, và từ chối request có timestamp quá cũ để chống gửi lại.
+const (
+	WebhookIDHeader        = "X-Webhook-Id"
+	WebhookEventHeader     = "X-Webhook-Event"
+	WebhookTimestampHeader = "X-Webhook-Timestamp"
+	WebhookSignatureHeader = "X-Webhook-Signature"
+
+	webhookUserAgent = "topic-service-webhooks/1.0"
+	// maxWebhookDrainBytes: phần body được đọc bỏ để tái sử dụng kết nối, nội dung không được lưu
+	maxWebhookDrainBytes = 4096
+)
+
+// SignWebhookPayload trả về giá trị header X-Webhook-Signature dạng "sha256=<hex>"
+func SignWebhookPayload(secret string, timestamp int64, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+type webhookEventPublisher struct {
+	next          EventPublisher
+	subscriptions repository.WebhookSubscriptionRepository
+	deliveries    repository.WebhookDeliveryRepository
+}
+
+// NewWebhookEventPublisher tạo delivery cho các subscription khớp với event rồi chuyển event cho next.
+// Relay gửi lại event thì delivery đã có được bỏ qua nhờ DedupKey.
+func NewWebhookEventPublisher(next EventPublisher, subscriptions repository.WebhookSubscriptionRepository, deliveries repository.WebhookDeliveryRepository) EventPublisher {
+	return &webhookEventPublisher{next: next, subscriptions: subscriptions, deliveries: deliveries}
+}
+
+func (p *webhookEventPublisher) Publish(ctx context.Context, event *model.OutboxEvent) error {
+	var payload model.TopicEvent
+	if err := json.Unmarshal([]byte(event.Payload), &payload); err != nil {
+		return err
+	}
+
+	if payload.OrganizationID != "" {
+		subscriptions, err := p.subscriptions.ListActive(ctx, payload.OrganizationID)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		for _, subscription := range subscriptions {
+			if !subscription.Matches(event.Type, payload.OrganizationID) {
+				continue
+			}
+			subscriptionID := subscription.ID.Hex()
+			err := p.deliveries.Create(ctx, &model.WebhookDelivery{
+				SubscriptionID: subscriptionID,
+				EventID:        payload.EventID,
+				EventType:      event.Type,
+				Payload:        event.Payload,
+				DedupKey:       model.WebhookDeliveryDedupKey(subscriptionID, payload.EventID),
+				Status:         model.WebhookDeliveryPending,
+				NextAttemptAt:  now,
+				CreatedAt:      now,
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return p.next.Publish(ctx, event)
+}
+
+type WebhookDispatcherOptions struct {
+	PollInterval time.Duration
+	// Workers là số delivery được gửi song song, một endpoint chậm không chặn các endpoint khác
+	Workers int
+	// MaxAttempts là số lần gửi lỗi trước khi delivery chuyển sang failed
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	RequestTimeout time.Duration
+	// DisableAfter là số lần gửi lỗi liên tiếp (tính mọi delivery) trước khi subscription bị tắt
+	DisableAfter int
+}
+
+func (o WebhookDispatcherOptions) withDefaults() WebhookDispatcherOptions {
+	if o.PollInterval <= 0 {
+		o.PollInterval = DefaultWebhookPollInterval
+	}
+	if o.Workers <= 0 {
+		o.Workers = DefaultWebhookWorkers
+	}
+	if o.MaxAttempts <= 0 {
+		o.MaxAttempts = DefaultWebhookMaxAttempts
+	}
+	if o.InitialBackoff <= 0 {
+		o.InitialBackoff = DefaultWebhookInitialBackoff
+	}
+	if o.MaxBackoff <= 0 {
+		o.MaxBackoff = DefaultWebhookMaxBackoff
+	}
+	if o.RequestTimeout <= 0 {
+		o.RequestTimeout = DefaultWebhookRequestTimeout
+	}
+	if o.DisableAfter <= 0 {
+		o.DisableAfter = DefaultWebhookDisableAfter
+	}
+	return o
+}
+
+// WebhookDispatcher gửi các delivery pending tới URL của subscription
+type WebhookDispatcher interface {
+	Close()
+}
+
+type webhookDispatcher struct {
+	subscriptions repository.WebhookSubscriptionRepository
+	deliveries    repository.WebhookDeliveryRepository
+	client        *http.Client
+	opts          WebhookDispatcherOptions
+	stop          chan struct{}
+	wg            sync.WaitGroup
+}
+
+// NewWebhookDispatcher chạy các worker ngay khi khởi tạo. Nhiều instance có thể cùng chạy,
+// mỗi delivery được một worker nhận bằng ClaimDue nên không bị gửi trùng khi không có lỗi.
+func NewWebhookDispatcher(subscriptions repository.WebhookSubscriptionRepository, deliveries repository.WebhookDeliveryRepository, opts WebhookDispatcherOptions) WebhookDispatcher {
+	opts = opts.withDefaults()
+	d := &webhookDispatcher{
+		subscriptions: subscriptions,
+		deliveries:    deliveries,
+		client:        newWebhookHTTPClient(opts.RequestTimeout),
+		opts:          opts,
+		stop:          make(chan struct{}),
+	}
+	for i := 0; i < opts.Workers; i++ {
+		d.wg.Add(1)
+		go d.run()
+	}
+	return d
+}
+
+// Close dừng các worker và chờ request đang gửi kết thúc
+func (d *webhookDispatcher) Close() {
+	select {
+	case <-d.stop:
+	default:
+		close(d.stop)
+	}
+	d.wg.Wait()
+}
+
+func (d *webhookDispatcher) run() {
+	defer d.wg.Done()
+
+	ticker := time.NewTicker(d.opts.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		// Còn delivery tới lượt thì gửi tiếp không đợi tick
+		for d.dispatchNext() {
+			select {
+			case <-d.stop:
+				return
+			default:
+			}
+		}
+		select {
+		case <-d.stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// dispatchNext nhận và gửi một delivery, trả về false khi không còn delivery nào tới lượt
+func (d *webhookDispatcher) dispatchNext() bool {
+	// Lease dài hơn thời gian gửi để delivery không bị worker khác nhận lại khi đang gửi
+	lease := 2 * d.opts.RequestTimeout
+	ctx, cancel := context.WithTimeout(context.Background(), lease)
+	defer cancel()
+
+	delivery, err := d.deliveries.ClaimDue(ctx, time.Now(), lease)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return false
+	}
+	if err != nil {
+		log.Printf("webhook: failed to claim delivery: %v", err)
+		return false
+	}
+
+	d.deliver(ctx, delivery)
+	return true
+}
+
+func (d *webhookDispatcher) deliver(ctx context.Context, delivery *model.WebhookDelivery) {
+	id := delivery.ID.Hex()
+
+	subscription, err := d.subscriptions.GetByID(ctx, delivery.SubscriptionID)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		d.markFailed(ctx, delivery, delivery.Attempts, 0, "subscription was deleted")
+		return
+	}
+	if err != nil {
+		log.Printf("webhook: failed to load subscription of delivery %s: %v", id, err)
+		return
+	}
+	if !subscription.Active {
+		// Delivery vẫn nằm trong nhật ký để replay sau khi subscription được bật lại
+		d.markFailed(ctx, delivery, delivery.Attempts, 0, "subscription is disabled")
+		return
+	}
+
+	attempts := delivery.Attempts + 1
+	statusCode, err := d.send(ctx, subscription, delivery)
+	if err == nil {
+		if err := d.deliveries.MarkSucceeded(ctx, delivery.ID, attempts, statusCode, time.Now()); err != nil {
+			log.Printf("webhook: failed to mark delivery %s as succeeded: %v", id, err)
+		}
+		if subscription.ConsecutiveFailures > 0 {
+			if err := d.subscriptions.RecordSuccess(ctx, delivery.SubscriptionID); err != nil {
+				log.Printf("webhook: failed to reset failures of subscription %s: %v", delivery.SubscriptionID, err)
+			}
+		}
+		return
+	}
+
+	disabled, recordErr := d.subscriptions.RecordFailure(ctx, delivery.SubscriptionID, d.opts.DisableAfter,
+		fmt.Sprintf("%d consecutive failed deliveries, last error: %v", d.opts.DisableAfter, err))
+	if recordErr != nil {
+		log.Printf("webhook: failed to record failure of subscription %s: %v", delivery.SubscriptionID, recordErr)
+	}
+	if disabled {
+		log.Printf("webhook: subscription %s disabled after %d consecutive failed deliveries", delivery.SubscriptionID, d.opts.DisableAfter)
+	}
+
+	if attempts >= d.opts.MaxAttempts || disabled {
+		log.Printf("webhook: delivery %s (%s) to subscription %s failed after %d attempts: %v",
+			id, delivery.EventType, delivery.SubscriptionID, attempts, err)
+		d.markFailed(ctx, delivery, attempts, statusCode, err.Error())
+		return
+	}
+
+	nextAttemptAt := time.Now().Add(exponentialBackoff(d.opts.InitialBackoff, d.opts.MaxBackoff, attempts))
+	log.Printf("webhook: delivery %s to subscription %s failed (attempt %d), retrying at %s: %v",
+		id, delivery.SubscriptionID, attempts, nextAttemptAt.Format(time.RFC3339), err)
+	if err := d.deliveries.MarkRetry(ctx, delivery.ID, attempts, statusCode, err.Error(), nextAttemptAt); err != nil {
+		log.Printf("webhook: failed to schedule retry for delivery %s: %v", id, err)
+	}
+}
+
+func (d *webhookDispatcher) markFailed(ctx context.Context, delivery *model.WebhookDelivery, attempts, statusCode int, reason string) {
+	if err := d.deliveries.MarkFailed(ctx, delivery.ID, attempts, statusCode, reason); err != nil {
+		log.Printf("webhook: failed to mark delivery %s as failed: %v", delivery.ID.Hex(), err)
+	}
+}
+
+// send gửi payload đã ký, mọi status ngoài 2xx (kể cả redirect) đều là lỗi.
+// Chỉ status được ghi vào nhật ký, body của bên nhận không được lưu để không lộ nội dung của URL bất kỳ.
+func (d *webhookDispatcher) send(ctx context.Context, subscription *model.WebhookSubscription, delivery *model.WebhookDelivery) (int, error) {
+	payload := []byte(delivery.Payload)
+	timestamp := time.Now().Unix()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, subscription.URL, bytes.NewReader(payload))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", webhookUserAgent)
+	req.Header.Set(WebhookIDHeader, delivery.ID.Hex())
+	req.Header.Set(WebhookEventHeader, delivery.EventType)
+	req.Header.Set(WebhookTimestampHeader, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(WebhookSignatureHeader, SignWebhookPayload(subscription.Secret, timestamp, payload))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxWebhookDrainBytes))
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
@@ -0,0 +1,236 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"time"
+	"topic-service/internal/topic/dto/request"
+	"topic-service/internal/topic/dto/response"
+	"topic-service/internal/topic/mapper"
+	"topic-service/internal/topic/model"
+	"topic-service/internal/topic/repository"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const defaultWebhookDeliveryPageSize = 20
+
+var (
+	ErrWebhookOrganizationRequired = errors.New("webhook subscriptions belong to an organization")
+	ErrWebhookSubscriptionInactive = errors.New("webhook subscription is disabled")
+)
+
+// WebhookService quản lý đăng ký webhook của tổ chức mà actor thuộc về.
+// Subscription của tổ chức khác được coi như không tồn tại.
+type WebhookService interface {
+	CreateSubscription(ctx context.Context, actor Actor, req *request.CreateWebhookSubscriptionRequest) (*response.WebhookSubscriptionResponse, error)
+	ListSubscriptions(ctx context.Context, actor Actor) ([]response.WebhookSubscriptionResponse, error)
+	GetSubscription(ctx context.Context, actor Actor, id string) (*response.WebhookSubscriptionResponse, error)
+	UpdateSubscription(ctx context.Context, actor Actor, id string, req *request.UpdateWebhookSubscriptionRequest) (*response.WebhookSubscriptionResponse, error)
+	DeleteSubscription(ctx context.Context, actor Actor, id string) error
+	ListDeliveries(ctx context.Context, actor Actor, id string, query *request.ListWebhookDeliveriesQuery) (*response.WebhookDeliveryPageResponse, error)
+	// ReplayDelivery tạo delivery mới với cùng payload để gửi lại ngay, delivery gốc được giữ nguyên trong nhật ký
+	ReplayDelivery(ctx context.Context, actor Actor, id, deliveryID string) (*response.WebhookDeliveryResponse, error)
+}
+
+type webhookService struct {
+	subscriptions repository.WebhookSubscriptionRepository
+	deliveries    repository.WebhookDeliveryRepository
+}
+
+func NewWebhookService(subscriptions repository.WebhookSubscriptionRepository, deliveries repository.WebhookDeliveryRepository) WebhookService {
+	return &webhookService{subscriptions: subscriptions, deliveries: deliveries}
+}
+
+func (s *webhookService) CreateSubscription(ctx context.Context, actor Actor, req *request.CreateWebhookSubscriptionRequest) (*response.WebhookSubscriptionResponse, error) {
+	if actor.OrganizationID == "" {
+		return nil, ErrWebhookOrganizationRequired
+	}
+	if err := validateWebhookURL(req.URL); err != nil {
+		return nil, err
+	}
+
+	secret := req.Secret
+	if secret == "" {
+		generated, err := generateWebhookSecret()
+		if err != nil {
+			return nil, err
+		}
+		secret = generated
+	}
+
+	now := time.Now()
+	subscription := &model.WebhookSubscription{
+		ID:             primitive.NewObjectID(),
+		OrganizationID: actor.OrganizationID,
+		URL:            req.URL,
+		Secret:         secret,
+		Events:         uniqueIDs(req.Events),
+		Active:         req.Active == nil || *req.Active,
+		CreatedBy:      actor.UserID,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+	created, err := s.subscriptions.Create(ctx, subscription)
+	if err != nil {
+		return nil, err
+	}
+
+	res := mapper.MapWebhookSubscriptionToResponse(created)
+	res.Secret = secret
+	return res, nil
+}
+
+func (s *webhookService) ListSubscriptions(ctx context.Context, actor Actor) ([]response.WebhookSubscriptionResponse, error) {
+	if actor.OrganizationID == "" {
+		return []response.WebhookSubscriptionResponse{}, nil
+	}
+
+	subscriptions, err := s.subscriptions.ListByOrganization(ctx, actor.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+	return mapper.MapWebhookSubscriptionsToResponse(subscriptions), nil
+}
+
+func (s *webhookService) GetSubscription(ctx context.Context, actor Actor, id string) (*response.WebhookSubscriptionResponse, error) {
+	subscription, err := s.get(ctx, actor, id)
+	if err != nil {
+		return nil, err
+	}
+	return mapper.MapWebhookSubscriptionToResponse(subscription), nil
+}
+
+func (s *webhookService) UpdateSubscription(ctx context.Context, actor Actor, id string, req *request.UpdateWebhookSubscriptionRequest) (*response.WebhookSubscriptionResponse, error) {
+	subscription, err := s.get(ctx, actor, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.URL != nil {
+		if err := validateWebhookURL(*req.URL); err != nil {
+			return nil, err
+		}
+		subscription.URL = *req.URL
+	}
+	if req.Secret != nil {
+		subscription.Secret = *req.Secret
+	}
+	if req.Events != nil {
+		subscription.Events = uniqueIDs(*req.Events)
+	}
+	if req.Active != nil {
+		subscription.Active = *req.Active
+	}
+	subscription.UpdatedAt = time.Now()
+
+	if err := s.subscriptions.Update(ctx, subscription); err != nil {
+		return nil, err
+	}
+
+	updated, err := s.subscriptions.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return mapper.MapWebhookSubscriptionToResponse(updated), nil
+}
+
+func (s *webhookService) DeleteSubscription(ctx context.Context, actor Actor, id string) error {
+	if _, err := s.get(ctx, actor, id); err != nil {
+		return err
+	}
+	if err := s.subscriptions.Delete(ctx, id); err != nil {
+		return err
+	}
+	return s.deliveries.DeleteBySubscription(ctx, id)
+}
+
+func (s *webhookService) ListDeliveries(ctx context.Context, actor Actor, id string, query *request.ListWebhookDeliveriesQuery) (*response.WebhookDeliveryPageResponse, error) {
+	if _, err := s.get(ctx, actor, id); err != nil {
+		return nil, err
+	}
+
+	page, size := query.Page, query.Size
+	if page <= 0 {
+		page = 1
+	}
+	if size <= 0 {
+		size = defaultWebhookDeliveryPageSize
+	}
+
+	total, err := s.deliveries.CountBySubscription(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	deliveries, err := s.deliveries.ListBySubscription(ctx, id, int64((page-1)*size), int64(size))
+	if err != nil {
+		return nil, err
+	}
+
+	return &response.WebhookDeliveryPageResponse{
+		Items: mapper.MapWebhookDeliveriesToResponse(deliveries),
+		Page:  page,
+		Size:  size,
+		Total: total,
+	}, nil
+}
+
+func (s *webhookService) ReplayDelivery(ctx context.Context, actor Actor, id, deliveryID string) (*response.WebhookDeliveryResponse, error) {
+	subscription, err := s.get(ctx, actor, id)
+	if err != nil {
+		return nil, err
+	}
+	if !subscription.Active {
+		return nil, ErrWebhookSubscriptionInactive
+	}
+
+	original, err := s.deliveries.GetByID(ctx, id, deliveryID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Replay của một replay vẫn trỏ về delivery gốc để dễ lần theo trong nhật ký
+	replayOf := original.ReplayOf
+	if replayOf == "" {
+		replayOf = original.ID.Hex()
+	}
+
+	now := time.Now()
+	replay := &model.WebhookDelivery{
+		ID:             primitive.NewObjectID(),
+		SubscriptionID: id,
+		EventID:        original.EventID,
+		EventType:      original.EventType,
+		Payload:        original.Payload,
+		ReplayOf:       replayOf,
+		Status:         model.WebhookDeliveryPending,
+		NextAttemptAt:  now,
+		CreatedAt:      now,
+	}
+	if err := s.deliveries.Create(ctx, replay); err != nil {
+		return nil, err
+	}
+	return mapper.MapWebhookDeliveryToResponse(replay), nil
+}
+
+func (s *webhookService) get(ctx context.Context, actor Actor, id string) (*model.WebhookSubscription, error) {
+	subscription, err := s.subscriptions.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if subscription.OrganizationID != actor.OrganizationID {
+		return nil, mongo.ErrNoDocuments
+	}
+	return subscription, nil
+}
+
+func generateWebhookSecret() (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(secret), nil
+}
@@ -0,0 +1,83 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// ErrWebhookURLNotAllowed: URL của webhook trỏ vào mạng nội bộ hoặc không phải http/https
+var ErrWebhookURLNotAllowed = errors.New("webhook URL must be a public http or https address")
+
+// cgnatPrefix (100.64.0.0/10) không nằm trong IsPrivate nhưng vẫn là địa chỉ nội bộ của nhà mạng/cloud
+var cgnatPrefix = netip.MustParsePrefix("100.64.0.0/10")
+
+// isPublicAddr trả về false với loopback, private, link-local (gồm metadata 169.254.169.254),
+// multicast và địa chỉ chưa xác định
+func isPublicAddr(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	return addr.IsValid() &&
+		!addr.IsLoopback() &&
+		!addr.IsPrivate() &&
+		!addr.IsLinkLocalUnicast() &&
+		!addr.IsLinkLocalMulticast() &&
+		!addr.IsInterfaceLocalMulticast() &&
+		!addr.IsMulticast() &&
+		!addr.IsUnspecified() &&
+		!cgnatPrefix.Contains(addr)
+}
+
+// validateWebhookURL chặn sớm URL rõ ràng là nội bộ khi tạo/sửa subscription.
+// Tên miền được kiểm tra lại sau khi phân giải DNS lúc gửi, xem newWebhookHTTPClient.
+func validateWebhookURL(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Hostname() == "" {
+		return ErrWebhookURLNotAllowed
+	}
+	host := strings.ToLower(strings.TrimSuffix(u.Hostname(), "."))
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return ErrWebhookURLNotAllowed
+	}
+	if addr, err := netip.ParseAddr(host); err == nil && !isPublicAddr(addr) {
+		return ErrWebhookURLNotAllowed
+	}
+	return nil
+}
+
+// newWebhookHTTPClient chỉ kết nối tới địa chỉ public: IP được kiểm tra ngay trước khi dial,
+// sau khi DNS đã phân giải, nên tên miền trỏ về mạng nội bộ (kể cả DNS rebinding) cũng bị chặn.
+// Redirect không được theo và proxy từ biến môi trường bị bỏ qua để không đi vòng qua bước kiểm tra.
+func newWebhookHTTPClient(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{
+		Timeout: timeout,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			addr, err := netip.ParseAddr(host)
+			if err != nil || !isPublicAddr(addr) {
+				return fmt.Errorf("%w: %s", ErrWebhookURLNotAllowed, host)
+			}
+			return nil
+		},
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
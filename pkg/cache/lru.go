@@ -0,0 +1,53 @@
+package cache
+
+import (
+	"time"
+
+	lru "github.com/hashicorp/golang-lru"
+)
+
+type lruEntry struct {
+	value     []byte
+	expiresAt time.Time
+}
+
+// LRU là cache trong process giới hạn số phần tử, mỗi phần tử hết hạn sau ttl
+type LRU struct {
+	cache *lru.Cache
+	ttl   time.Duration
+	now   func() time.Time
+}
+
+func NewLRU(size int, ttl time.Duration) (*LRU, error) {
+	c, err := lru.New(size)
+	if err != nil {
+		return nil, err
+	}
+	return &LRU{cache: c, ttl: ttl, now: time.Now}, nil
+}
+
+func (c *LRU) Get(key string) ([]byte, bool) {
+	value, ok := c.cache.Get(key)
+	if !ok {
+		return nil, false
+	}
+
+	entry := value.(lruEntry)
+	if c.now().After(entry.expiresAt) {
+		c.cache.Remove(key)
+		return nil, false
+	}
+	return entry.value, true
+}
+
+func (c *LRU) Set(key string, value []byte) {
+	c.cache.Add(key, lruEntry{value: value, expiresAt: c.now().Add(c.ttl)})
+}
+
+func (c *LRU) Delete(key string) {
+	c.cache.Remove(key)
+}
+
+func (c *LRU) Purge() {
+	c.cache.Purge()
+}
@@ -0,0 +1,225 @@
+package cache
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"log"
+	"net"
+	"strconv"
+	"time"
+)
+
+const (
+	redisDialTimeout = 3 * time.Second
+	redisIOTimeout   = 3 * time.Second
+	redisPoolSize    = 16
+)
+
+type redisConn struct {
+	conn net.Conn
+	r    *bufio.Reader
+	w    *bufio.Writer
+}
+
+// Redis là client tối giản cho một Redis server: GET/SET/DEL/SCAN và pub/sub
+type Redis struct {
+	addr string
+	pool chan *redisConn
+}
+
+func NewRedis(addr string) *Redis {
+	return &Redis{addr: addr, pool: make(chan *redisConn, redisPoolSize)}
+}
+
+// Ping kiểm tra kết nối, dùng khi khởi động để báo lỗi cấu hình sớm
+func (c *Redis) Ping(ctx context.Context) error {
+	_, err := c.do(ctx, "PING")
+	return err
+}
+
+// Get trả về ErrNil nếu key không tồn tại
+func (c *Redis) Get(ctx context.Context, key string) ([]byte, error) {
+	reply, err := c.do(ctx, "GET", key)
+	if err != nil {
+		return nil, err
+	}
+	if reply == nil {
+		return nil, ErrNil
+	}
+	value, ok := reply.([]byte)
+	if !ok {
+		return nil, fmt.Errorf("redis: unexpected GET reply %T", reply)
+	}
+	return value, nil
+}
+
+func (c *Redis) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	_, err := c.do(ctx, "SET", key, string(value), "PX", strconv.FormatInt(ttl.Milliseconds(), 10))
+	return err
+}
+
+func (c *Redis) Del(ctx context.Context, keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	_, err := c.do(ctx, append([]string{"DEL"}, keys...)...)
+	return err
+}
+
+// DelPattern xoá các key khớp pattern bằng SCAN để không chặn server như KEYS
+func (c *Redis) DelPattern(ctx context.Context, pattern string) error {
+	cursor := "0"
+	for {
+		reply, err := c.do(ctx, "SCAN", cursor, "MATCH", pattern, "COUNT", "500")
+		if err != nil {
+			return err
+		}
+		parts, ok := reply.([]interface{})
+		if !ok || len(parts) != 2 {
+			return fmt.Errorf("redis: unexpected SCAN reply %v", reply)
+		}
+		next, _ := parts[0].([]byte)
+		rawKeys, _ := parts[1].([]interface{})
+
+		keys := make([]string, 0, len(rawKeys))
+		for _, k := range rawKeys {
+			if b, ok := k.([]byte); ok {
+				keys = append(keys, string(b))
+			}
+		}
+		if err := c.Del(ctx, keys...); err != nil {
+			return err
+		}
+
+		cursor = string(next)
+		if cursor == "0" || cursor == "" {
+			return nil
+		}
+	}
+}
+
+func (c *Redis) Publish(ctx context.Context, channel, message string) error {
+	_, err := c.do(ctx, "PUBLISH", channel, message)
+	return err
+}
+
+// Subscribe nhận message của channel cho tới khi ctx bị huỷ, tự kết nối lại khi mất kết nối.
+// onReconnect được gọi sau mỗi lần kết nối lại vì các message trong lúc mất kết nối đã bị bỏ lỡ.
+func (c *Redis) Subscribe(ctx context.Context, channel string, handler func(message string), onReconnect func()) {
+	backoff := time.Second
+	first := true
+	for ctx.Err() == nil {
+		err := c.subscribeOnce(ctx, channel, handler, func() {
+			if !first && onReconnect != nil {
+				onReconnect()
+			}
+			first = false
+			backoff = time.Second
+		})
+		if ctx.Err() != nil {
+			return
+		}
+		log.Printf("redis subscribe %s: %v, retrying in %s", channel, err, backoff)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		if backoff < 30*time.Second {
+			backoff *= 2
+		}
+	}
+}
+
+func (c *Redis) subscribeOnce(ctx context.Context, channel string, handler func(message string), subscribed func()) error {
+	rc, err := c.dial(ctx)
+	if err != nil {
+		return err
+	}
+	defer rc.conn.Close()
+
+	// Đóng kết nối khi ctx bị huỷ để giải phóng lần đọc đang chờ
+	stop := context.AfterFunc(ctx, func() { rc.conn.Close() })
+	defer stop()
+
+	rc.conn.SetDeadline(time.Now().Add(redisIOTimeout))
+	if err := writeCommand(rc.w, "SUBSCRIBE", channel); err != nil {
+		return err
+	}
+	if _, err := readReply(rc.r); err != nil {
+		return err
+	}
+	rc.conn.SetDeadline(time.Time{})
+	subscribed()
+
+	for {
+		reply, err := readReply(rc.r)
+		if err != nil {
+			return err
+		}
+		parts, ok := reply.([]interface{})
+		if !ok || len(parts) != 3 {
+			continue
+		}
+		if kind, _ := parts[0].([]byte); string(kind) != "message" {
+			continue
+		}
+		if payload, ok := parts[2].([]byte); ok {
+			handler(string(payload))
+		}
+	}
+}
+
+func (c *Redis) do(ctx context.Context, args ...string) (interface{}, error) {
+	rc, err := c.get(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	deadline := time.Now().Add(redisIOTimeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	rc.conn.SetDeadline(deadline)
+
+	if err := writeCommand(rc.w, args...); err != nil {
+		rc.conn.Close()
+		return nil, err
+	}
+	reply, err := readReply(rc.r)
+	if _, isRedisErr := err.(RedisError); err != nil && !isRedisErr {
+		// Lỗi mạng hoặc reply hỏng: kết nối không còn đồng bộ, bỏ luôn
+		rc.conn.Close()
+		return nil, err
+	}
+	c.put(rc)
+	return reply, err
+}
+
+func (c *Redis) get(ctx context.Context) (*redisConn, error) {
+	select {
+	case rc := <-c.pool:
+		return rc, nil
+	default:
+		return c.dial(ctx)
+	}
+}
+
+func (c *Redis) put(rc *redisConn) {
+	select {
+	case c.pool <- rc:
+	default:
+		rc.conn.Close()
+	}
+}
+
+func (c *Redis) dial(ctx context.Context) (*redisConn, error) {
+	dialer := net.Dialer{Timeout: redisDialTimeout}
+	conn, err := dialer.DialContext(ctx, "tcp", c.addr)
+	if err != nil {
+		return nil, err
+	}
+	return &redisConn{conn: conn, r: bufio.NewReader(conn), w: bufio.NewWriter(conn)}, nil
+}
@@ -3,12 +3,25 @@ package config
 import (
 	"log"
 	"os"
+	"time"
 
 	"gopkg.in/yaml.v3"
 )
 
+// ServerConfig: GrpcPort (hoặc biến môi trường GRPC_PORT) để trống thì không mở API gRPC
 type ServerConfig struct {
-	Port string `yaml:"port"`
+	Port     string `yaml:"port"`
+	GrpcPort string `yaml:"grpc_port"`
+	// ValidateRequests bật middleware kiểm tra request theo /openapi.json
+	ValidateRequests bool `yaml:"validate_requests"`
+}
+
+// AuthConfig là khoá kiểm tra chữ ký JWT của API gRPC. JWTSecret (hoặc biến môi trường JWT_SECRET) dùng cho
+// token HS*, JWTPublicKeyFile là file PEM khoá công khai RSA/ECDSA cho token RS*/PS*/ES*.
+// Không có khoá nào thì API gRPC không được mở.
+type AuthConfig struct {
+	JWTSecret        string `yaml:"jwt_secret"`
+	JWTPublicKeyFile string `yaml:"jwt_public_key_file"`
 }
 
 type DatabaseConfig struct {
@@ -25,12 +38,104 @@ type MySQLConfig struct {
 	Name     string `yaml:"name"`
 }
 
+// MongoDBConfig: URI (hoặc biến môi trường MONGO_URI) được ưu tiên, không có thì ghép từ host/port/user/password.
+// Các field còn lại để trống thì dùng giá trị trong URI hoặc mặc định của driver.
 type MongoDBConfig struct {
-	Host     string `yaml:"host"`
-	Port     string `yaml:"port"`
-	User     string `yaml:"user"`
-	Password string `yaml:"password"`
-	Name     string `yaml:"name"`
+	URI        string `yaml:"uri"`
+	Host       string `yaml:"host"`
+	Port       string `yaml:"port"`
+	User       string `yaml:"user"`
+	Password   string `yaml:"password"`
+	Name       string `yaml:"name"`
+	AuthSource string `yaml:"auth_source"`
+	ReplicaSet string `yaml:"replica_set"`
+	AppName    string `yaml:"app_name"`
+
+	TLS MongoTLSConfig `yaml:"tls"`
+
+	MinPoolSize            uint64        `yaml:"min_pool_size"`
+	MaxPoolSize            uint64        `yaml:"max_pool_size"`
+	MaxConnIdleTime        time.Duration `yaml:"max_conn_idle_time"`
+	ConnectTimeout         time.Duration `yaml:"connect_timeout"`
+	ServerSelectionTimeout time.Duration `yaml:"server_selection_timeout"`
+	SocketTimeout          time.Duration `yaml:"socket_timeout"`
+
+	// ReadPreference: primary, primaryPreferred, secondary, secondaryPreferred, nearest
+	ReadPreference string                  `yaml:"read_preference"`
+	WriteConcern   MongoWriteConcernConfig `yaml:"write_concern"`
+
+	// Khi khởi động, kết nối lỗi được thử lại với backoff luỹ thừa từ RetryInitialDelay tới RetryMaxDelay cho tới hết StartupTimeout
+	StartupTimeout    time.Duration `yaml:"startup_timeout"`
+	RetryInitialDelay time.Duration `yaml:"retry_initial_delay"`
+	RetryMaxDelay     time.Duration `yaml:"retry_max_delay"`
+
+	// AllowStandalone cho phép chạy với MongoDB không có transaction (standalone), khi đó ghi topic và outbox không nguyên tử
+	AllowStandalone bool `yaml:"allow_standalone"`
+}
+
+type MongoTLSConfig struct {
+	Enabled            bool   `yaml:"enabled"`
+	CAFile             string `yaml:"ca_file"`
+	CertFile           string `yaml:"cert_file"`
+	KeyFile            string `yaml:"key_file"`
+	InsecureSkipVerify bool   `yaml:"insecure_skip_verify"`
+}
+
+// MongoWriteConcernConfig: W là "majority", số node hoặc tên tag set
+type MongoWriteConcernConfig struct {
+	W       string `yaml:"w"`
+	Journal *bool  `yaml:"journal"`
+}
+
+// CacheConfig cấu hình cache topic trong process, Redis được bật qua biến môi trường REDIS_ADDR
+type CacheConfig struct {
+	Size int           `yaml:"size"`
+	TTL  time.Duration `yaml:"ttl"`
+}
+
+// KafkaConfig: không có broker nào thì event chỉ được ghi ra log. Biến môi trường KAFKA_BROKERS
+// (các địa chỉ cách nhau bởi dấu phẩy) ghi đè brokers trong file config.
+type KafkaConfig struct {
+	Brokers  []string `yaml:"brokers"`
+	ClientID string   `yaml:"client_id"`
+	// TopicEventsTopic là Kafka topic nhận TopicCreated/TopicUpdated/TopicDeleted
+	TopicEventsTopic string `yaml:"topic_events_topic"`
+}
+
+// EventStoreConfig bật event sourcing cho topic khi có connection string (vd. "esdb://localhost:2113?tls=false").
+// Biến môi trường EVENT_STORE_CONNECTION_STRING ghi đè giá trị trong file config.
+type EventStoreConfig struct {
+	ConnectionString string `yaml:"connection_string"`
+	// ProjectionWait là thời gian tối đa lệnh ghi chờ projection cập nhật MongoDB
+	ProjectionWait time.Duration `yaml:"projection_wait"`
+}
+
+// UserEventsConfig cấu hình xử lý sự kiện user bị xoá/vô hiệu hoá từ go-main-service, nhận qua Kafka hoặc webhook
+type UserEventsConfig struct {
+	Topic   string `yaml:"topic"`
+	GroupID string `yaml:"group_id"`
+	// FallbackOwnerID nhận topic khi tổ chức của user không có admin nào khác
+	FallbackOwnerID string `yaml:"fallback_owner_id"`
+	// WebhookSecret là khoá HMAC kiểm tra header X-Signature của webhook, để trống thì webhook bị tắt
+	WebhookSecret string `yaml:"webhook_secret"`
+}
+
+// WebhooksConfig cấu hình gửi webhook cho đối tác, để trống thì dùng giá trị mặc định của service
+type WebhooksConfig struct {
+	Workers        int           `yaml:"workers"`
+	MaxAttempts    int           `yaml:"max_attempts"`
+	RequestTimeout time.Duration `yaml:"request_timeout"`
+	// DisableAfter là số lần gửi lỗi liên tiếp trước khi subscription bị tắt
+	DisableAfter int `yaml:"disable_after"`
+}
+
+// StreamConfig cấu hình GET /api/v1/topic/stream
+type StreamConfig struct {
+	// ResumeLimit là số event tối đa được đọc lại từ outbox khi client nối lại bằng Last-Event-ID
+	ResumeLimit int           `yaml:"resume_limit"`
+	Heartbeat   time.Duration `yaml:"heartbeat"`
+	// ShutdownTimeout là thời gian chờ request đang xử lý khi tắt server
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout"`
 }
 
 type ConsulConfig struct {
@@ -78,12 +183,19 @@ type Registry struct {
 }
 
 type AppConfigStruct struct {
-	Server   ServerConfig     `yaml:"server"`
-	Database DatabaseConfig   `yaml:"database"`
-	Consul   ConsulConfig     `yaml:"consul"`
-	Zap      ZapConfig        `mapstructure:"zap"`
-	Registry Registry         `mapstructure:"registry" validate:"required"`
-	App      AppConfiguration `mapstructure:"app"`
+	Server     ServerConfig     `yaml:"server"`
+	Auth       AuthConfig       `yaml:"auth"`
+	Database   DatabaseConfig   `yaml:"database"`
+	Consul     ConsulConfig     `yaml:"consul"`
+	Cache      CacheConfig      `yaml:"cache"`
+	Kafka      KafkaConfig      `yaml:"kafka"`
+	EventStore EventStoreConfig `yaml:"event_store"`
+	UserEvents UserEventsConfig `yaml:"user_events"`
+	Webhooks   WebhooksConfig   `yaml:"webhooks"`
+	Stream     StreamConfig     `yaml:"stream"`
+	Zap        ZapConfig        `mapstructure:"zap"`
+	Registry   Registry         `mapstructure:"registry" validate:"required"`
+	App        AppConfiguration `mapstructure:"app"`
 }
 
 var AppConfig *AppConfigStruct
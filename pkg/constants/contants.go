@@ -9,6 +9,7 @@ const (
 	RedisAddr                  = "REDIS_ADDR"
 	MongoDbURI                 = "MONGO_URI"
 	EventStoreConnectionString = "EVENT_STORE_CONNECTION_STRING"
+	JWTSecret                  = "JWT_SECRET"
 	ElasticUrl                 = "ELASTIC_URL"
 
 	ReaderServicePort = "READER_SERVICE"
@@ -105,9 +106,11 @@ const (
 	MinimumUsageTime = "minimum_usage_time"
 	MaximumUsageTime = "maximum_usage_time"
 
-	UserID    = "user_id"
-	UserName  = "user_name"
-	UserRoles = "user_roles"
+	UserID         = "user_id"
+	UserName       = "user_name"
+	UserRoles      = "user_roles"
+	OrganizationID = "organization_id"
+	Locale         = "locale"
 )
 
 type contextKey string
@@ -18,22 +18,28 @@ const (
 	serviceName = "topic-service"
 	ttl         = time.Second * 15
 	checkId     = "topic-service-health-check"
+	// grpcServiceName tách riêng để service discovery theo serviceName luôn trả về port HTTP
+	grpcServiceName   = "topic-service-grpc"
+	grpcCheckInterval = time.Second * 10
 )
 
 var (
 	serviceId     = fmt.Sprintf("%s-%d", serviceName, rand.Intn(100))
+	grpcServiceId = fmt.Sprintf("%s-grpc", serviceId)
 	defaultConfig *api.Config
 )
 
 type Client interface {
 	Connect() *api.Client
+	RegisterGrpc(port string)
 	Deregister()
 }
 
 type service struct {
-	client *api.Client
-	log    zap.Logger
-	cfg    *config.AppConfigStruct
+	client         *api.Client
+	log            zap.Logger
+	cfg            *config.AppConfigStruct
+	grpcRegistered bool
 }
 
 func NewConsulConn(log zap.Logger, cfg *config.AppConfigStruct) *service {
@@ -72,6 +78,12 @@ func (c *service) Connect() *api.Client {
 }
 
 func (c *service) Deregister() {
+	if c.grpcRegistered {
+		if err := c.client.Agent().ServiceDeregister(grpcServiceId); err != nil {
+			c.log.Errorf("Failed to deregister gRPC service: %v", err)
+		}
+	}
+
 	// Deregister service
 	err := c.client.Agent().ServiceDeregister(serviceId)
 	if err != nil {
@@ -79,6 +91,35 @@ func (c *service) Deregister() {
 	}
 }
 
+// RegisterGrpc đăng ký port gRPC thành service riêng, Consul tự gọi grpc.health.v1 để kiểm tra
+func (c *service) RegisterGrpc(port string) {
+	hostname := c.cfg.Registry.Host
+	grpcPort, err := strconv.Atoi(port)
+	if err != nil {
+		c.log.Fatalf("Invalid gRPC port %q: %v", port, err)
+	}
+
+	registration := &api.AgentServiceRegistration{
+		ID:      grpcServiceId,
+		Name:    grpcServiceName,
+		Port:    grpcPort,
+		Address: hostname,
+		Tags:    []string{"go", "topic-service", "grpc"},
+		Check: &api.AgentServiceCheck{
+			GRPC:                           fmt.Sprintf("%s:%d", hostname, grpcPort),
+			Interval:                       grpcCheckInterval.String(),
+			DeregisterCriticalServiceAfter: ttl.String(),
+		},
+	}
+
+	if err := c.client.Agent().ServiceRegister(registration); err != nil {
+		c.log.Fatalf("Failed to register gRPC service %s:%d: %v", hostname, grpcPort, err)
+	}
+	c.grpcRegistered = true
+
+	c.log.Printf("successfully register gRPC service: %s:%d", hostname, grpcPort)
+}
+
 func (c *service) updateHealthCheck() {
 	ticker := time.NewTicker(time.Second * 5)
 
@@ -2,43 +2,243 @@ package db
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
 	"fmt"
 	"log"
+	"math/rand"
+	"net"
+	"net/url"
+	"os"
+	"strconv"
 	"time"
 	"topic-service/pkg/config"
 
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+	"go.mongodb.org/mongo-driver/mongo/writeconcern"
+	"go.mongodb.org/mongo-driver/x/mongo/driver/connstring"
 )
 
-var MongoClient *mongo.Client
-var TopicCollection *mongo.Collection
+const (
+	defaultMongoStartupTimeout    = 2 * time.Minute
+	defaultMongoRetryInitialDelay = time.Second
+	defaultMongoRetryMaxDelay     = 30 * time.Second
+	defaultMongoPingTimeout       = 10 * time.Second
+)
 
-func ConnectMongoDB() {
-	d := config.AppConfig.Database.Mongo
+// Mongo giữ kết nối MongoDB đã sẵn sàng, được truyền vào nơi cần dùng thay cho biến toàn cục
+type Mongo struct {
+	Client   *mongo.Client
+	Database *mongo.Database
+}
 
-	var uri string
-	if d.User != "" && d.Password != "" {
-		uri = fmt.Sprintf("mongodb://%s:%s@%s:%s", d.User, d.Password, d.Host, d.Port)
-	} else {
-		uri = fmt.Sprintf("mongodb://%s:%s", d.Host, d.Port)
+// NewMongo kết nối MongoDB và ping thử, thất bại thì thử lại với backoff luỹ thừa tới khi hết StartupTimeout
+func NewMongo(ctx context.Context, cfg config.MongoDBConfig) (*Mongo, error) {
+	uri, err := MongoURI(cfg)
+	if err != nil {
+		return nil, err
 	}
 
-	clientOptions := options.Client().ApplyURI(uri)
+	dbName := cfg.Name
+	if dbName == "" {
+		cs, err := connstring.ParseAndValidate(uri)
+		if err != nil {
+			return nil, fmt.Errorf("invalid MongoDB URI: %w", err)
+		}
+		dbName = cs.Database
+	}
+	if dbName == "" {
+		return nil, errors.New("MongoDB database name is not configured")
+	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
+	clientOptions, err := mongoClientOptions(uri, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	startupTimeout := durationOr(cfg.StartupTimeout, defaultMongoStartupTimeout)
+	deadline := time.Now().Add(startupTimeout)
+	delay := durationOr(cfg.RetryInitialDelay, defaultMongoRetryInitialDelay)
+	maxDelay := durationOr(cfg.RetryMaxDelay, defaultMongoRetryMaxDelay)
+
+	for attempt := 1; ; attempt++ {
+		client, err := connectMongo(ctx, clientOptions, durationOr(cfg.ServerSelectionTimeout, defaultMongoPingTimeout))
+		if err == nil {
+			log.Printf("Connected to MongoDB database %q", dbName)
+			return &Mongo{Client: client, Database: client.Database(dbName)}, nil
+		}
+
+		// Thêm jitter để nhiều instance khởi động cùng lúc không dồn vào cùng một thời điểm
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			return nil, fmt.Errorf("connect to MongoDB: giving up after %d attempt(s) in %s: %w", attempt, startupTimeout, err)
+		}
+		wait := min(delay/2+time.Duration(rand.Int63n(int64(delay/2)+1)), remaining)
+		log.Printf("MongoDB connection attempt %d failed: %v, retrying in %s", attempt, err, wait.Round(time.Millisecond))
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(wait):
+		}
+		delay = min(delay*2, maxDelay)
+	}
+}
 
-	var err error
-	MongoClient, err = mongo.Connect(ctx, clientOptions)
+// Close đóng kết nối, dùng khi tắt service
+func (m *Mongo) Close(ctx context.Context) error {
+	return m.Client.Disconnect(ctx)
+}
+
+func connectMongo(ctx context.Context, clientOptions *options.ClientOptions, pingTimeout time.Duration) (*mongo.Client, error) {
+	client, err := mongo.Connect(ctx, clientOptions)
 	if err != nil {
-		log.Fatalf("Failed to connect to MongoDB: %v", err)
+		return nil, err
+	}
+
+	pingCtx, cancel := context.WithTimeout(ctx, pingTimeout)
+	defer cancel()
+	if err := client.Ping(pingCtx, readpref.PrimaryPreferred()); err != nil {
+		_ = client.Disconnect(context.Background())
+		return nil, err
+	}
+	return client, nil
+}
+
+// MongoURI trả về URI đã cấu hình hoặc ghép từ host/port/user/password, user và password được escape
+func MongoURI(cfg config.MongoDBConfig) (string, error) {
+	if cfg.URI != "" {
+		return cfg.URI, nil
+	}
+	if cfg.Host == "" {
+		return "", errors.New("MongoDB uri or host must be configured")
+	}
+
+	host := cfg.Host
+	if cfg.Port != "" {
+		host = net.JoinHostPort(cfg.Host, cfg.Port)
 	}
 
-	if err := MongoClient.Ping(ctx, nil); err != nil {
-		log.Fatalf("MongoDB ping failed: %v", err)
+	u := url.URL{Scheme: "mongodb", Host: host, Path: "/"}
+	if cfg.User != "" {
+		u.User = url.UserPassword(cfg.User, cfg.Password)
 	}
 
-	TopicCollection = MongoClient.Database(d.Name).Collection("topics")
-	log.Println("Connected to MongoDB and loaded 'topics' collection")
+	query := url.Values{}
+	if cfg.AuthSource != "" {
+		query.Set("authSource", cfg.AuthSource)
+	}
+	if cfg.ReplicaSet != "" {
+		query.Set("replicaSet", cfg.ReplicaSet)
+	}
+	u.RawQuery = query.Encode()
+	return u.String(), nil
+}
+
+// mongoClientOptions áp dụng các tuỳ chọn trong config lên URI, giá trị trong config được ưu tiên hơn URI
+func mongoClientOptions(uri string, cfg config.MongoDBConfig) (*options.ClientOptions, error) {
+	opts := options.Client().ApplyURI(uri)
+	if cfg.AppName != "" {
+		opts.SetAppName(cfg.AppName)
+	}
+
+	if cfg.MinPoolSize > 0 {
+		opts.SetMinPoolSize(cfg.MinPoolSize)
+	}
+	if cfg.MaxPoolSize > 0 {
+		opts.SetMaxPoolSize(cfg.MaxPoolSize)
+	}
+	if cfg.MaxConnIdleTime > 0 {
+		opts.SetMaxConnIdleTime(cfg.MaxConnIdleTime)
+	}
+	if cfg.ConnectTimeout > 0 {
+		opts.SetConnectTimeout(cfg.ConnectTimeout)
+	}
+	if cfg.ServerSelectionTimeout > 0 {
+		opts.SetServerSelectionTimeout(cfg.ServerSelectionTimeout)
+	}
+	if cfg.SocketTimeout > 0 {
+		opts.SetSocketTimeout(cfg.SocketTimeout)
+	}
+
+	if cfg.ReadPreference != "" {
+		mode, err := readpref.ModeFromString(cfg.ReadPreference)
+		if err != nil {
+			return nil, fmt.Errorf("invalid MongoDB read preference: %w", err)
+		}
+		rp, err := readpref.New(mode)
+		if err != nil {
+			return nil, fmt.Errorf("invalid MongoDB read preference: %w", err)
+		}
+		opts.SetReadPreference(rp)
+	}
+
+	if cfg.WriteConcern.W != "" || cfg.WriteConcern.Journal != nil {
+		wc := &writeconcern.WriteConcern{Journal: cfg.WriteConcern.Journal}
+		switch w := cfg.WriteConcern.W; {
+		case w == "":
+		case w == "majority":
+			wc.W = "majority"
+		default:
+			n, err := strconv.Atoi(w)
+			if err != nil {
+				// Tag set tuỳ chỉnh của replica set
+				wc.W = w
+			} else {
+				wc.W = n
+			}
+		}
+		opts.SetWriteConcern(wc)
+	}
+
+	if cfg.TLS.Enabled {
+		tlsConfig, err := mongoTLSConfig(cfg.TLS)
+		if err != nil {
+			return nil, err
+		}
+		opts.SetTLSConfig(tlsConfig)
+	}
+
+	if err := opts.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid MongoDB options: %w", err)
+	}
+	return opts, nil
+}
+
+func mongoTLSConfig(cfg config.MongoTLSConfig) (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: cfg.InsecureSkipVerify,
+	}
+
+	if cfg.CAFile != "" {
+		pem, err := os.ReadFile(cfg.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("read MongoDB CA file: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in MongoDB CA file %s", cfg.CAFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if cfg.CertFile != "" || cfg.KeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(cfg.CertFile, cfg.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("load MongoDB client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	return tlsConfig, nil
+}
+
+func durationOr(value, fallback time.Duration) time.Duration {
+	if value > 0 {
+		return value
+	}
+	return fallback
 }
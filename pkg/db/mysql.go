@@ -3,7 +3,6 @@ package db
 import (
 	"fmt"
 	"log"
-	"topic-service/internal/topic/model"
 	"topic-service/pkg/config"
 
 	"gorm.io/driver/mysql"
@@ -23,10 +22,7 @@ func ConnectMySQL() {
 		log.Fatalf("Failed to connect to MySQL: %v", err)
 	}
 
-	err = MySqlDB.AutoMigrate(&model.Topic{})
-	if err != nil {
-		log.Fatalf("AutoMigrate failed: %v", err)
-	}
-
-	log.Println("Connected to MySQL and migrated schema")
+	// Schema được quản lý bởi migration, chạy bằng lệnh "migrate -db mysql up" hoặc cờ --migrate
+	// của server khi database.active là mysql.
+	log.Println("Connected to MySQL")
 }
@@ -0,0 +1,81 @@
+package grpcserver
+
+import (
+	"log"
+	"time"
+	"topic-service/internal/gateway"
+	"topic-service/internal/topic/grpchandler"
+	"topic-service/internal/topic/middleware"
+	"topic-service/internal/topic/repository"
+	"topic-service/internal/topic/service"
+	"topic-service/pkg/i18n"
+	topicv1 "topic-service/pkg/pb/topic/v1"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// healthMethodPrefix: health check được gọi không kèm token
+const healthMethodPrefix = "/grpc.health.v1.Health/"
+
+// Options gom các phụ thuộc tuỳ chọn của gRPC server
+type Options struct {
+	// Logger ghi access log cho mỗi RPC, nil thì không ghi
+	Logger middleware.GrpcAccessLogger
+	// TokenVerifier kiểm tra chữ ký JWT, nil thì mọi RPC trừ health check bị từ chối
+	TokenVerifier *middleware.TokenVerifier
+}
+
+// Server là gRPC server của topic-service kèm health service chuẩn grpc.health.v1
+type Server struct {
+	*grpc.Server
+	health *health.Server
+}
+
+// NewServer đăng ký TopicService trên cùng repository với router HTTP. Thứ tự interceptor:
+// access log ngoài cùng để ghi cả lỗi do panic, sau đó recovery rồi mới tới auth.
+func NewServer(repos *repository.Repositories, userGateway gateway.UserGateway, opts Options) *Server {
+	if err := i18n.RegisterValidator(); err != nil {
+		log.Printf("failed to register validation translations: %v", err)
+	}
+
+	topicSvc := service.NewTopicService(repos.Topic, repos.TopicItem, repos.Curriculum, repos.Redirect, repos.Favorite, repos.Comment, repos.Rating, repos.Outbox, repos.Transactions, userGateway)
+
+	var interceptors []grpc.UnaryServerInterceptor
+	if opts.Logger != nil {
+		interceptors = append(interceptors, middleware.UnaryAccessLogInterceptor(opts.Logger))
+	}
+	interceptors = append(interceptors,
+		middleware.UnaryRecoveryInterceptor(),
+		middleware.UnaryAuthInterceptor(opts.TokenVerifier, healthMethodPrefix),
+	)
+
+	srv := grpc.NewServer(grpc.ChainUnaryInterceptor(interceptors...))
+	topicv1.RegisterTopicServiceServer(srv, grpchandler.NewTopicServer(topicSvc))
+
+	healthSrv := health.NewServer()
+	healthpb.RegisterHealthServer(srv, healthSrv)
+	healthSrv.SetServingStatus(topicv1.TopicService_ServiceDesc.ServiceName, healthpb.HealthCheckResponse_SERVING)
+
+	return &Server{Server: srv, health: healthSrv}
+}
+
+// Shutdown báo NOT_SERVING cho health check để Consul ngừng điều hướng request tới,
+// chờ các RPC đang chạy tối đa timeout rồi đóng hẳn server
+func (s *Server) Shutdown(timeout time.Duration) {
+	s.health.Shutdown()
+
+	done := make(chan struct{})
+	go func() {
+		s.GracefulStop()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(timeout):
+		log.Printf("gRPC server did not stop within %s, closing remaining connections", timeout)
+		s.Stop()
+	}
+}
@@ -0,0 +1,157 @@
+package i18n
+
+import (
+	"context"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"topic-service/pkg/constants"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	LocaleEN      = "en"
+	LocaleVI      = "vi"
+	DefaultLocale = LocaleEN
+)
+
+//go:embed locales/*.json
+var localeFiles embed.FS
+
+// localeContextKey giữ locale trong context.Context của request để tầng service cũng đọc được
+type localeContextKey struct{}
+
+// bundles: locale -> message key -> nội dung
+var bundles = map[string]map[string]string{}
+
+func init() {
+	for _, locale := range []string{LocaleEN, LocaleVI} {
+		data, err := localeFiles.ReadFile("locales/" + locale + ".json")
+		if err != nil {
+			panic(fmt.Sprintf("i18n: missing bundle %s: %v", locale, err))
+		}
+		messages := map[string]string{}
+		if err := json.Unmarshal(data, &messages); err != nil {
+			panic(fmt.Sprintf("i18n: invalid bundle %s: %v", locale, err))
+		}
+		bundles[locale] = messages
+	}
+}
+
+// IsSupported kiểm tra locale có bundle hay không
+func IsSupported(locale string) bool {
+	_, ok := bundles[locale]
+	return ok
+}
+
+// Locale đọc locale đã được middleware gắn vào context, mặc định là DefaultLocale
+func Locale(ctx context.Context) string {
+	if ctx != nil {
+		if locale, ok := ctx.Value(constants.Locale).(string); ok && IsSupported(locale) {
+			return locale
+		}
+		if locale, ok := ctx.Value(localeContextKey{}).(string); ok && IsSupported(locale) {
+			return locale
+		}
+	}
+	return DefaultLocale
+}
+
+// T dịch message key theo locale của request. Key không có trong bundle của locale thì dùng
+// bundle mặc định, không có nữa thì trả lại chính key. args được định dạng theo fmt.Sprintf.
+func T(ctx context.Context, key string, args ...interface{}) string {
+	message, ok := bundles[Locale(ctx)][key]
+	if !ok {
+		if message, ok = bundles[DefaultLocale][key]; !ok {
+			message = key
+		}
+	}
+	if len(args) > 0 {
+		return fmt.Sprintf(message, args...)
+	}
+	return message
+}
+
+// Negotiate chọn locale được hỗ trợ phù hợp nhất từ header Accept-Language (RFC 9110),
+// ví dụ "vi-VN,vi;q=0.9,en;q=0.8" -> "vi"
+func Negotiate(acceptLanguage string) string {
+	type candidate struct {
+		locale string
+		q      float64
+		index  int
+	}
+
+	var candidates []candidate
+	for i, part := range strings.Split(acceptLanguage, ",") {
+		fields := strings.Split(strings.TrimSpace(part), ";")
+		tag := strings.ToLower(strings.TrimSpace(fields[0]))
+		if tag == "" {
+			continue
+		}
+
+		q := 1.0
+		for _, param := range fields[1:] {
+			param = strings.TrimSpace(param)
+			if strings.HasPrefix(param, "q=") {
+				if v, err := strconv.ParseFloat(param[2:], 64); err == nil {
+					q = v
+				}
+			}
+		}
+		if q <= 0 {
+			continue
+		}
+
+		// Chỉ so khớp phần ngôn ngữ chính: "vi-VN" -> "vi"
+		locale := strings.SplitN(tag, "-", 2)[0]
+		if tag == "*" {
+			locale = DefaultLocale
+		}
+		if IsSupported(locale) {
+			candidates = append(candidates, candidate{locale: locale, q: q, index: i})
+		}
+	}
+
+	if len(candidates) == 0 {
+		return DefaultLocale
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].q > candidates[j].q
+	})
+	return candidates[0].locale
+}
+
+// Middleware gắn locale lấy từ Accept-Language vào context của request
+func Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		setLocale(c, Negotiate(c.GetHeader("Accept-Language")))
+		c.Next()
+	}
+}
+
+// SetPreferredLocale ghi đè locale của request bằng ngôn ngữ user đã chọn, bỏ qua nếu không hỗ trợ
+func SetPreferredLocale(c *gin.Context, locale string) {
+	locale = strings.ToLower(strings.TrimSpace(locale))
+	if IsSupported(locale) {
+		setLocale(c, locale)
+	}
+}
+
+// WithLocale gắn locale vào context ngoài gin (ví dụ request gRPC), bỏ qua nếu không hỗ trợ
+func WithLocale(ctx context.Context, locale string) context.Context {
+	locale = strings.ToLower(strings.TrimSpace(locale))
+	if !IsSupported(locale) {
+		return ctx
+	}
+	return context.WithValue(ctx, localeContextKey{}, locale)
+}
+
+func setLocale(c *gin.Context, locale string) {
+	c.Set(constants.Locale, locale)
+	c.Request = c.Request.WithContext(context.WithValue(c.Request.Context(), localeContextKey{}, locale))
+	c.Header("Content-Language", locale)
+}
@@ -0,0 +1,87 @@
+package i18n
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/locales/en"
+	"github.com/go-playground/locales/vi"
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+	enTranslations "github.com/go-playground/validator/v10/translations/en"
+	viTranslations "github.com/go-playground/validator/v10/translations/vi"
+)
+
+var universalTranslator = ut.New(en.New(), en.New(), vi.New())
+
+// RegisterValidator đăng ký bản dịch lỗi validate cho validator của gin
+// và dùng tên field trong json/form thay cho tên field của struct
+func RegisterValidator() error {
+	validate, ok := binding.Validator.Engine().(*validator.Validate)
+	if !ok {
+		return errors.New("i18n: unsupported validator engine")
+	}
+
+	validate.RegisterTagNameFunc(func(field reflect.StructField) string {
+		for _, tag := range []string{"json", "form"} {
+			name := strings.SplitN(field.Tag.Get(tag), ",", 2)[0]
+			if name == "-" {
+				return ""
+			}
+			if name != "" {
+				return name
+			}
+		}
+		return field.Name
+	})
+
+	enTrans, _ := universalTranslator.GetTranslator(LocaleEN)
+	if err := enTranslations.RegisterDefaultTranslations(validate, enTrans); err != nil {
+		return err
+	}
+	viTrans, _ := universalTranslator.GetTranslator(LocaleVI)
+	return viTranslations.RegisterDefaultTranslations(validate, viTrans)
+}
+
+// ValidationErrors dịch lỗi binding theo từng field (field -> thông báo).
+// Trả về nil nếu err không phải lỗi validate, ví dụ body không phải JSON hợp lệ.
+func ValidationErrors(ctx context.Context, err error) map[string]string {
+	var validationErrs validator.ValidationErrors
+	if !errors.As(err, &validationErrs) {
+		return nil
+	}
+
+	trans, _ := universalTranslator.GetTranslator(Locale(ctx))
+	fields := make(map[string]string, len(validationErrs))
+	for _, fe := range validationErrs {
+		fields[fieldPath(fe)] = fe.Translate(trans)
+	}
+	return fields
+}
+
+// TranslateError gộp các lỗi validate đã dịch thành một chuỗi, lỗi khác giữ nguyên nội dung
+func TranslateError(ctx context.Context, err error) string {
+	var validationErrs validator.ValidationErrors
+	if !errors.As(err, &validationErrs) {
+		return err.Error()
+	}
+
+	trans, _ := universalTranslator.GetTranslator(Locale(ctx))
+	messages := make([]string, 0, len(validationErrs))
+	for _, fe := range validationErrs {
+		messages = append(messages, fe.Translate(trans))
+	}
+	return strings.Join(messages, "; ")
+}
+
+// fieldPath bỏ tên struct ở đầu namespace: "CreateTopicRequest.title" -> "title"
+func fieldPath(fe validator.FieldError) string {
+	namespace := fe.Namespace()
+	if i := strings.Index(namespace, "."); i >= 0 {
+		return namespace[i+1:]
+	}
+	return fe.Field()
+}
@@ -0,0 +1,506 @@
+package kafka
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	defaultClientID       = "topic-service"
+	defaultDialTimeout    = 10 * time.Second
+	defaultRequestTimeout = 30 * time.Second
+	defaultFetchMaxBytes  = 1 << 20
+	maxIdleConnsPerBroker = 4
+	// Số lần thử lại khi leader hoặc coordinator đổi
+	metadataRetries = 3
+)
+
+type ClientConfig struct {
+	Brokers        []string
+	ClientID       string
+	DialTimeout    time.Duration
+	RequestTimeout time.Duration
+	// FetchMaxBytes giới hạn dữ liệu mỗi lần fetch một partition
+	FetchMaxBytes int
+}
+
+// Client nói chuyện với Kafka bằng wire protocol, chỉ hỗ trợ những gì Producer và ConsumerGroup cần:
+// không nén khi gửi, đọc được batch không nén hoặc gzip, không có SASL/TLS.
+type Client struct {
+	cfg         ClientConfig
+	correlation atomic.Int32
+
+	mu           sync.Mutex
+	closed       bool
+	brokers      map[int32]string
+	leaders      map[string][]int32
+	coordinators map[string]string
+	idle         map[string][]*brokerConn
+}
+
+type brokerConn struct {
+	net.Conn
+	reader *bufio.Reader
+}
+
+func NewClient(cfg ClientConfig) (*Client, error) {
+	if len(cfg.Brokers) == 0 {
+		return nil, errors.New("kafka: no brokers configured")
+	}
+	if cfg.ClientID == "" {
+		cfg.ClientID = defaultClientID
+	}
+	if cfg.DialTimeout <= 0 {
+		cfg.DialTimeout = defaultDialTimeout
+	}
+	if cfg.RequestTimeout <= 0 {
+		cfg.RequestTimeout = defaultRequestTimeout
+	}
+	if cfg.FetchMaxBytes <= 0 {
+		cfg.FetchMaxBytes = defaultFetchMaxBytes
+	}
+
+	return &Client{
+		cfg:          cfg,
+		brokers:      make(map[int32]string),
+		leaders:      make(map[string][]int32),
+		coordinators: make(map[string]string),
+		idle:         make(map[string][]*brokerConn),
+	}, nil
+}
+
+func (c *Client) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.closed = true
+	for addr, conns := range c.idle {
+		for _, conn := range conns {
+			conn.Close()
+		}
+		delete(c.idle, addr)
+	}
+	return nil
+}
+
+func (c *Client) Partitions(ctx context.Context, topic string) (int, error) {
+	var count int
+	err := c.retryStaleMetadata(ctx, topic, func() error {
+		leaders, err := c.topicLeaders(ctx, topic)
+		count = len(leaders)
+		return err
+	})
+	return count, err
+}
+
+func (c *Client) Produce(ctx context.Context, topic string, partition int, messages []Message) error {
+	if len(messages) == 0 {
+		return nil
+	}
+	records := encodeRecordBatch(messages)
+
+	return c.withLeader(ctx, topic, partition, func(addr string) error {
+		var code int16
+		err := c.roundTrip(ctx, addr, apiProduce, 3, func(e *encoder) {
+			e.nullableString(nil)
+			e.int16(-1) // acks=all
+			e.int32(int32(c.cfg.RequestTimeout / time.Millisecond))
+			e.arrayLen(1)
+			e.string(topic)
+			e.arrayLen(1)
+			e.int32(int32(partition))
+			e.bytes(records)
+		}, func(d *decoder) error {
+			for i := d.arrayLen(); i > 0; i-- {
+				d.string()
+				for p := d.arrayLen(); p > 0; p-- {
+					d.int32()
+					code = d.int16()
+					d.int64()
+					d.int64()
+				}
+			}
+			d.int32()
+			return d.err
+		})
+		if err != nil {
+			return err
+		}
+		return errorFromCode(code)
+	})
+}
+
+func (c *Client) Fetch(ctx context.Context, topic string, partition int, offset int64, maxWait time.Duration) ([]Message, error) {
+	var messages []Message
+	err := c.withLeader(ctx, topic, partition, func(addr string) error {
+		var (
+			code    int16
+			records []byte
+		)
+		fetchCtx, cancel := context.WithTimeout(ctx, maxWait+c.cfg.RequestTimeout)
+		defer cancel()
+
+		err := c.roundTrip(fetchCtx, addr, apiFetch, 4, func(e *encoder) {
+			e.int32(-1)
+			e.int32(int32(maxWait / time.Millisecond))
+			e.int32(1)
+			e.int32(int32(c.cfg.FetchMaxBytes))
+			e.int8(0) // read_uncommitted
+			e.arrayLen(1)
+			e.string(topic)
+			e.arrayLen(1)
+			e.int32(int32(partition))
+			e.int64(offset)
+			e.int32(int32(c.cfg.FetchMaxBytes))
+		}, func(d *decoder) error {
+			d.int32()
+			for i := d.arrayLen(); i > 0; i-- {
+				d.string()
+				for p := d.arrayLen(); p > 0; p-- {
+					d.int32()
+					code = d.int16()
+					d.int64()
+					d.int64()
+					for a := d.arrayLen(); a > 0; a-- {
+						d.int64()
+						d.int64()
+					}
+					records = d.bytes()
+				}
+			}
+			return d.err
+		})
+		if err != nil {
+			return err
+		}
+		if err := errorFromCode(code); err != nil {
+			return err
+		}
+
+		messages, err = decodeRecordBatches(topic, partition, records, offset)
+		return err
+	})
+	return messages, err
+}
+
+func (c *Client) ListOffset(ctx context.Context, topic string, partition int, first bool) (int64, error) {
+	timestamp := int64(-1)
+	if first {
+		timestamp = -2
+	}
+
+	var offset int64
+	err := c.withLeader(ctx, topic, partition, func(addr string) error {
+		var code int16
+		err := c.roundTrip(ctx, addr, apiListOffsets, 1, func(e *encoder) {
+			e.int32(-1)
+			e.arrayLen(1)
+			e.string(topic)
+			e.arrayLen(1)
+			e.int32(int32(partition))
+			e.int64(timestamp)
+		}, func(d *decoder) error {
+			for i := d.arrayLen(); i > 0; i-- {
+				d.string()
+				for p := d.arrayLen(); p > 0; p-- {
+					d.int32()
+					code = d.int16()
+					d.int64()
+					offset = d.int64()
+				}
+			}
+			return d.err
+		})
+		if err != nil {
+			return err
+		}
+		return errorFromCode(code)
+	})
+	return offset, err
+}
+
+// withLeader gọi fn với địa chỉ leader của partition
+func (c *Client) withLeader(ctx context.Context, topic string, partition int, fn func(addr string) error) error {
+	return c.retryStaleMetadata(ctx, topic, func() error {
+		addr, err := c.leader(ctx, topic, partition)
+		if err != nil {
+			return err
+		}
+		return fn(addr)
+	})
+}
+
+// retryStaleMetadata đọc lại metadata của topic và thử lại fn khi leader đã đổi hoặc topic đang được tạo
+func (c *Client) retryStaleMetadata(ctx context.Context, topic string, fn func() error) error {
+	var err error
+	for attempt := 0; attempt < metadataRetries; attempt++ {
+		if err = fn(); err == nil || !isStaleMetadata(err) {
+			return err
+		}
+
+		c.forgetTopic(topic)
+		if err := sleepContext(ctx, time.Duration(attempt+1)*200*time.Millisecond); err != nil {
+			return err
+		}
+	}
+	return err
+}
+
+func (c *Client) leader(ctx context.Context, topic string, partition int) (string, error) {
+	leaders, err := c.topicLeaders(ctx, topic)
+	if err != nil {
+		return "", err
+	}
+	if partition < 0 || partition >= len(leaders) {
+		return "", ErrUnknownTopic
+	}
+
+	c.mu.Lock()
+	addr, ok := c.brokers[leaders[partition]]
+	c.mu.Unlock()
+	if !ok {
+		return "", kafkaError{Code: errLeaderNotAvailable}
+	}
+	return addr, nil
+}
+
+func (c *Client) topicLeaders(ctx context.Context, topic string) ([]int32, error) {
+	c.mu.Lock()
+	leaders, ok := c.leaders[topic]
+	c.mu.Unlock()
+	if ok {
+		return leaders, nil
+	}
+
+	if err := c.refreshMetadata(ctx, topic); err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	leaders, ok = c.leaders[topic]
+	if !ok {
+		return nil, ErrUnknownTopic
+	}
+	return leaders, nil
+}
+
+func (c *Client) forgetTopic(topic string) {
+	c.mu.Lock()
+	delete(c.leaders, topic)
+	c.mu.Unlock()
+}
+
+// refreshMetadata hỏi lần lượt các broker cho tới khi có một broker trả lời
+func (c *Client) refreshMetadata(ctx context.Context, topic string) error {
+	type partitionMeta struct {
+		index  int32
+		leader int32
+	}
+
+	var lastErr error
+	for _, addr := range c.knownBrokers() {
+		brokers := make(map[int32]string)
+		var (
+			topicCode  int16
+			partitions []partitionMeta
+		)
+		err := c.roundTrip(ctx, addr, apiMetadata, 1, func(e *encoder) {
+			e.arrayLen(1)
+			e.string(topic)
+		}, func(d *decoder) error {
+			for i := d.arrayLen(); i > 0; i-- {
+				nodeID := d.int32()
+				host := d.string()
+				port := d.int32()
+				d.string() // rack
+				brokers[nodeID] = net.JoinHostPort(host, strconv.Itoa(int(port)))
+			}
+			d.int32()
+			for i := d.arrayLen(); i > 0; i-- {
+				code := d.int16()
+				name := d.string()
+				d.bool()
+				var parts []partitionMeta
+				for p := d.arrayLen(); p > 0; p-- {
+					d.int16()
+					index := d.int32()
+					leader := d.int32()
+					for r := d.arrayLen(); r > 0; r-- {
+						d.int32()
+					}
+					for r := d.arrayLen(); r > 0; r-- {
+						d.int32()
+					}
+					parts = append(parts, partitionMeta{index: index, leader: leader})
+				}
+				if name == topic {
+					topicCode = code
+					partitions = parts
+				}
+			}
+			return d.err
+		})
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		if err := errorFromCode(topicCode); err != nil {
+			return err
+		}
+		if len(partitions) == 0 {
+			return ErrUnknownTopic
+		}
+
+		leaders := make([]int32, len(partitions))
+		for _, p := range partitions {
+			if int(p.index) < len(leaders) {
+				leaders[p.index] = p.leader
+			}
+		}
+
+		c.mu.Lock()
+		for id, brokerAddr := range brokers {
+			c.brokers[id] = brokerAddr
+		}
+		c.leaders[topic] = leaders
+		c.mu.Unlock()
+		return nil
+	}
+	return fmt.Errorf("kafka: no broker reachable: %w", lastErr)
+}
+
+// knownBrokers gồm các broker trong metadata đã biết và danh sách bootstrap
+func (c *Client) knownBrokers() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	seen := make(map[string]bool)
+	var addrs []string
+	for _, addr := range c.brokers {
+		if !seen[addr] {
+			seen[addr] = true
+			addrs = append(addrs, addr)
+		}
+	}
+	for _, addr := range c.cfg.Brokers {
+		if !seen[addr] {
+			seen[addr] = true
+			addrs = append(addrs, addr)
+		}
+	}
+	return addrs
+}
+
+// roundTrip gửi một request và đọc response trên một kết nối riêng lấy từ pool
+func (c *Client) roundTrip(ctx context.Context, addr string, apiKey, version int16, encode func(*encoder), decode func(*decoder) error) error {
+	conn, err := c.acquire(ctx, addr)
+	if err != nil {
+		return err
+	}
+
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(c.cfg.RequestTimeout)
+	}
+	conn.SetDeadline(deadline)
+	// Huỷ ctx thì ngắt ngay thao tác đọc/ghi đang chờ
+	stop := context.AfterFunc(ctx, func() { conn.SetDeadline(time.Unix(1, 0)) })
+
+	correlationID := c.correlation.Add(1)
+	var req encoder
+	req.int32(0)
+	req.int16(apiKey)
+	req.int16(version)
+	req.int32(correlationID)
+	req.string(c.cfg.ClientID)
+	encode(&req)
+	data := req.buf.Bytes()
+	binary.BigEndian.PutUint32(data[:4], uint32(len(data)-4))
+
+	body, err := c.exchange(conn, data, correlationID)
+	stop()
+	if err != nil {
+		conn.Close()
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return err
+	}
+	c.release(addr, conn)
+
+	return decode(&decoder{data: body})
+}
+
+func (c *Client) exchange(conn *brokerConn, request []byte, correlationID int32) ([]byte, error) {
+	if _, err := conn.Write(request); err != nil {
+		return nil, err
+	}
+
+	var size [4]byte
+	if _, err := io.ReadFull(conn.reader, size[:]); err != nil {
+		return nil, err
+	}
+	body := make([]byte, binary.BigEndian.Uint32(size[:]))
+	if _, err := io.ReadFull(conn.reader, body); err != nil {
+		return nil, err
+	}
+	if len(body) < 4 || int32(binary.BigEndian.Uint32(body[:4])) != correlationID {
+		return nil, errors.New("kafka: response correlation id mismatch")
+	}
+	return body[4:], nil
+}
+
+func (c *Client) acquire(ctx context.Context, addr string) (*brokerConn, error) {
+	c.mu.Lock()
+	if c.closed {
+		c.mu.Unlock()
+		return nil, ErrClosed
+	}
+	if conns := c.idle[addr]; len(conns) > 0 {
+		conn := conns[len(conns)-1]
+		c.idle[addr] = conns[:len(conns)-1]
+		c.mu.Unlock()
+		return conn, nil
+	}
+	c.mu.Unlock()
+
+	dialer := net.Dialer{Timeout: c.cfg.DialTimeout}
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+	return &brokerConn{Conn: conn, reader: bufio.NewReader(conn)}, nil
+}
+
+func (c *Client) release(addr string, conn *brokerConn) {
+	conn.SetDeadline(time.Time{})
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed || len(c.idle[addr]) >= maxIdleConnsPerBroker {
+		conn.Close()
+		return
+	}
+	c.idle[addr] = append(c.idle[addr], conn)
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,405 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sort"
+	"strconv"
+	"time"
+)
+
+const (
+	consumerProtocolType = "consumer"
+	rangeAssignor        = "range"
+)
+
+// errUnknownMember: coordinator đã quên member, thường do member không heartbeat kịp trong session timeout
+var errUnknownMember = errors.New("kafka: unknown member id")
+
+func (c *Client) JoinGroup(ctx context.Context, req JoinGroupRequest) (*GroupGeneration, error) {
+	gen, err := c.joinGroup(ctx, req)
+	if errors.Is(err, errUnknownMember) {
+		// Join lại với member ID mới do coordinator cấp
+		req.MemberID = ""
+		gen, err = c.joinGroup(ctx, req)
+	}
+	if errors.Is(err, errUnknownMember) {
+		return nil, ErrRebalanceInProgress
+	}
+	return gen, err
+}
+
+func (c *Client) joinGroup(ctx context.Context, req JoinGroupRequest) (*GroupGeneration, error) {
+	type member struct {
+		id       string
+		metadata []byte
+	}
+
+	var (
+		code         int16
+		generationID int32
+		leaderID     string
+		memberID     string
+		members      []member
+	)
+	subscription := encodeSubscription(req.Topics)
+
+	err := c.withCoordinator(ctx, req.GroupID, func(addr string) error {
+		// JoinGroup chờ các member khác join lại, có thể mất tới RebalanceTimeout
+		joinCtx, cancel := context.WithTimeout(ctx, req.RebalanceTimeout+c.cfg.RequestTimeout)
+		defer cancel()
+
+		members = nil
+		err := c.roundTrip(joinCtx, addr, apiJoinGroup, 2, func(e *encoder) {
+			e.string(req.GroupID)
+			e.int32(int32(req.SessionTimeout / time.Millisecond))
+			e.int32(int32(req.RebalanceTimeout / time.Millisecond))
+			e.string(req.MemberID)
+			e.string(consumerProtocolType)
+			e.arrayLen(1)
+			e.string(rangeAssignor)
+			e.bytes(subscription)
+		}, func(d *decoder) error {
+			d.int32()
+			code = d.int16()
+			generationID = d.int32()
+			d.string()
+			leaderID = d.string()
+			memberID = d.string()
+			for i := d.arrayLen(); i > 0; i-- {
+				members = append(members, member{id: d.string(), metadata: d.bytes()})
+			}
+			return d.err
+		})
+		if err != nil {
+			return err
+		}
+		if code == errUnknownMemberID {
+			return errUnknownMember
+		}
+		return errorFromCode(code)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Leader của group chia partition cho mọi member rồi gửi kết quả qua SyncGroup
+	var assignments map[string][]byte
+	if leaderID == memberID {
+		subscriptions := make(map[string][]string, len(members))
+		for _, m := range members {
+			subscriptions[m.id] = decodeSubscription(m.metadata)
+		}
+		assignments, err = c.assignRange(ctx, subscriptions)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var assignment []byte
+	err = c.withCoordinator(ctx, req.GroupID, func(addr string) error {
+		err := c.roundTrip(ctx, addr, apiSyncGroup, 1, func(e *encoder) {
+			e.string(req.GroupID)
+			e.int32(generationID)
+			e.string(memberID)
+			e.arrayLen(len(assignments))
+			for id, data := range assignments {
+				e.string(id)
+				e.bytes(data)
+			}
+		}, func(d *decoder) error {
+			d.int32()
+			code = d.int16()
+			assignment = d.bytes()
+			return d.err
+		})
+		if err != nil {
+			return err
+		}
+		return errorFromCode(code)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &GroupGeneration{
+		GroupID:      req.GroupID,
+		MemberID:     memberID,
+		GenerationID: generationID,
+		Assignments:  decodeAssignment(assignment),
+	}, nil
+}
+
+func (c *Client) Heartbeat(ctx context.Context, gen *GroupGeneration) error {
+	return c.withCoordinator(ctx, gen.GroupID, func(addr string) error {
+		var code int16
+		err := c.roundTrip(ctx, addr, apiHeartbeat, 1, func(e *encoder) {
+			e.string(gen.GroupID)
+			e.int32(gen.GenerationID)
+			e.string(gen.MemberID)
+		}, func(d *decoder) error {
+			d.int32()
+			code = d.int16()
+			return d.err
+		})
+		if err != nil {
+			return err
+		}
+		return errorFromCode(code)
+	})
+}
+
+func (c *Client) LeaveGroup(ctx context.Context, gen *GroupGeneration) error {
+	return c.withCoordinator(ctx, gen.GroupID, func(addr string) error {
+		var code int16
+		err := c.roundTrip(ctx, addr, apiLeaveGroup, 1, func(e *encoder) {
+			e.string(gen.GroupID)
+			e.string(gen.MemberID)
+		}, func(d *decoder) error {
+			d.int32()
+			code = d.int16()
+			return d.err
+		})
+		if err != nil {
+			return err
+		}
+		return errorFromCode(code)
+	})
+}
+
+func (c *Client) CommittedOffset(ctx context.Context, groupID, topic string, partition int) (int64, error) {
+	offset := int64(-1)
+	err := c.withCoordinator(ctx, groupID, func(addr string) error {
+		var code int16
+		err := c.roundTrip(ctx, addr, apiOffsetFetch, 1, func(e *encoder) {
+			e.string(groupID)
+			e.arrayLen(1)
+			e.string(topic)
+			e.arrayLen(1)
+			e.int32(int32(partition))
+		}, func(d *decoder) error {
+			for i := d.arrayLen(); i > 0; i-- {
+				d.string()
+				for p := d.arrayLen(); p > 0; p-- {
+					d.int32()
+					offset = d.int64()
+					d.string()
+					code = d.int16()
+				}
+			}
+			return d.err
+		})
+		if err != nil {
+			return err
+		}
+		return errorFromCode(code)
+	})
+	return offset, err
+}
+
+func (c *Client) CommitOffset(ctx context.Context, gen *GroupGeneration, topic string, partition int, offset int64) error {
+	return c.withCoordinator(ctx, gen.GroupID, func(addr string) error {
+		var code int16
+		err := c.roundTrip(ctx, addr, apiOffsetCommit, 2, func(e *encoder) {
+			e.string(gen.GroupID)
+			e.int32(gen.GenerationID)
+			e.string(gen.MemberID)
+			e.int64(-1) // giữ offset theo cấu hình retention của broker
+			e.arrayLen(1)
+			e.string(topic)
+			e.arrayLen(1)
+			e.int32(int32(partition))
+			e.int64(offset)
+			e.nullableString(nil)
+		}, func(d *decoder) error {
+			for i := d.arrayLen(); i > 0; i-- {
+				d.string()
+				for p := d.arrayLen(); p > 0; p-- {
+					d.int32()
+					code = d.int16()
+				}
+			}
+			return d.err
+		})
+		if err != nil {
+			return err
+		}
+		return errorFromCode(code)
+	})
+}
+
+// withCoordinator gọi fn với địa chỉ coordinator của group, tìm lại coordinator khi nó đã đổi
+func (c *Client) withCoordinator(ctx context.Context, groupID string, fn func(addr string) error) error {
+	var err error
+	for attempt := 0; attempt < metadataRetries; attempt++ {
+		var addr string
+		addr, err = c.coordinator(ctx, groupID)
+		if err == nil {
+			err = fn(addr)
+		}
+		if err == nil || !isStaleMetadata(err) {
+			return err
+		}
+
+		c.mu.Lock()
+		delete(c.coordinators, groupID)
+		c.mu.Unlock()
+		if err := sleepContext(ctx, time.Duration(attempt+1)*200*time.Millisecond); err != nil {
+			return err
+		}
+	}
+	return err
+}
+
+func (c *Client) coordinator(ctx context.Context, groupID string) (string, error) {
+	c.mu.Lock()
+	addr, ok := c.coordinators[groupID]
+	c.mu.Unlock()
+	if ok {
+		return addr, nil
+	}
+
+	var lastErr error
+	for _, broker := range c.knownBrokers() {
+		var (
+			code int16
+			host string
+			port int32
+		)
+		err := c.roundTrip(ctx, broker, apiFindCoordinator, 1, func(e *encoder) {
+			e.string(groupID)
+			e.int8(0) // key type: group
+		}, func(d *decoder) error {
+			d.int32()
+			code = d.int16()
+			d.string()
+			d.int32()
+			host = d.string()
+			port = d.int32()
+			return d.err
+		})
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		if err := errorFromCode(code); err != nil {
+			return "", err
+		}
+
+		addr = net.JoinHostPort(host, strconv.Itoa(int(port)))
+		c.mu.Lock()
+		c.coordinators[groupID] = addr
+		c.mu.Unlock()
+		return addr, nil
+	}
+	return "", lastErr
+}
+
+// assignRange chia partition của từng topic thành các khoảng liên tiếp cho các member đăng ký topic đó,
+// giống range assignor mặc định của Kafka
+func (c *Client) assignRange(ctx context.Context, subscriptions map[string][]string) (map[string][]byte, error) {
+	membersByTopic := make(map[string][]string)
+	for memberID, topics := range subscriptions {
+		for _, topic := range topics {
+			membersByTopic[topic] = append(membersByTopic[topic], memberID)
+		}
+	}
+
+	assigned := make(map[string]map[string][]int, len(subscriptions))
+	for memberID := range subscriptions {
+		assigned[memberID] = make(map[string][]int)
+	}
+	for topic, members := range membersByTopic {
+		partitions, err := c.Partitions(ctx, topic)
+		if err != nil {
+			return nil, err
+		}
+		for memberID, parts := range rangeAssign(members, partitions) {
+			assigned[memberID][topic] = parts
+		}
+	}
+
+	result := make(map[string][]byte, len(assigned))
+	for memberID, topics := range assigned {
+		result[memberID] = encodeAssignment(topics)
+	}
+	return result, nil
+}
+
+func rangeAssign(members []string, partitions int) map[string][]int {
+	sorted := append([]string(nil), members...)
+	sort.Strings(sorted)
+
+	result := make(map[string][]int, len(sorted))
+	per, extra := partitions/len(sorted), partitions%len(sorted)
+	next := 0
+	for i, memberID := range sorted {
+		count := per
+		if i < extra {
+			count++
+		}
+		for p := next; p < next+count; p++ {
+			result[memberID] = append(result[memberID], p)
+		}
+		next += count
+	}
+	return result
+}
+
+func encodeSubscription(topics []string) []byte {
+	var e encoder
+	e.int16(0)
+	e.arrayLen(len(topics))
+	for _, topic := range topics {
+		e.string(topic)
+	}
+	e.bytes(nil)
+	return e.buf.Bytes()
+}
+
+func decodeSubscription(data []byte) []string {
+	d := decoder{data: data}
+	d.int16()
+	var topics []string
+	for i := d.arrayLen(); i > 0; i-- {
+		topics = append(topics, d.string())
+	}
+	return topics
+}
+
+func encodeAssignment(topics map[string][]int) []byte {
+	var e encoder
+	e.int16(0)
+	e.arrayLen(len(topics))
+	for topic, partitions := range topics {
+		e.string(topic)
+		e.arrayLen(len(partitions))
+		for _, p := range partitions {
+			e.int32(int32(p))
+		}
+	}
+	e.bytes(nil)
+	return e.buf.Bytes()
+}
+
+func decodeAssignment(data []byte) map[string][]int {
+	result := make(map[string][]int)
+	if len(data) == 0 {
+		return result
+	}
+
+	d := decoder{data: data}
+	d.int16()
+	for i := d.arrayLen(); i > 0; i-- {
+		topic := d.string()
+		var partitions []int
+		for p := d.arrayLen(); p > 0; p-- {
+			partitions = append(partitions, int(d.int32()))
+		}
+		if d.err == nil {
+			result[topic] = partitions
+		}
+	}
+	return result
+}
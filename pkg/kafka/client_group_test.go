@@ -0,0 +1,258 @@
+package kafka
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+// scriptedBroker là server Kafka tối giản trả lời theo handler của test, dùng để kiểm tra cách Client xử lý
+// mã lỗi của coordinator
+type scriptedBroker struct {
+	t        *testing.T
+	listener net.Listener
+	handler  func(apiKey int16, req *decoder, resp *encoder)
+
+	mu    sync.Mutex
+	calls map[int16]int
+}
+
+func newScriptedBroker(t *testing.T, handler func(apiKey int16, req *decoder, resp *encoder)) *scriptedBroker {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	b := &scriptedBroker{t: t, listener: listener, handler: handler, calls: make(map[int16]int)}
+	t.Cleanup(func() { listener.Close() })
+	go b.serve()
+	return b
+}
+
+func (b *scriptedBroker) serve() {
+	for {
+		conn, err := b.listener.Accept()
+		if err != nil {
+			return
+		}
+		go b.handle(conn)
+	}
+}
+
+func (b *scriptedBroker) handle(conn net.Conn) {
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+	for {
+		var size [4]byte
+		if _, err := io.ReadFull(reader, size[:]); err != nil {
+			return
+		}
+		body := make([]byte, binary.BigEndian.Uint32(size[:]))
+		if _, err := io.ReadFull(reader, body); err != nil {
+			return
+		}
+
+		req := &decoder{data: body}
+		apiKey := req.int16()
+		req.int16()
+		correlationID := req.int32()
+		req.string()
+
+		b.mu.Lock()
+		b.calls[apiKey]++
+		b.mu.Unlock()
+
+		var resp encoder
+		resp.int32(0)
+		resp.int32(correlationID)
+		b.handler(apiKey, req, &resp)
+		data := resp.buf.Bytes()
+		binary.BigEndian.PutUint32(data[:4], uint32(len(data)-4))
+		if _, err := conn.Write(data); err != nil {
+			return
+		}
+	}
+}
+
+func (b *scriptedBroker) callCount(apiKey int16) int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.calls[apiKey]
+}
+
+// writeCoordinator trả lời FindCoordinator v1, coordinator là chính broker này
+func (b *scriptedBroker) writeCoordinator(resp *encoder, code int16) {
+	host, port, _ := net.SplitHostPort(b.listener.Addr().String())
+	portNumber, _ := strconv.Atoi(port)
+	resp.int32(0)
+	resp.int16(code)
+	resp.nullableString(nil)
+	resp.int32(1)
+	resp.string(host)
+	resp.int32(int32(portNumber))
+}
+
+func writeOffsetCommit(resp *encoder, code int16) {
+	resp.arrayLen(1)
+	resp.string("events")
+	resp.arrayLen(1)
+	resp.int32(0)
+	resp.int16(code)
+}
+
+func newTestClient(t *testing.T, b *scriptedBroker) *Client {
+	t.Helper()
+	client, err := NewClient(ClientConfig{Brokers: []string{b.listener.Addr().String()}})
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	t.Cleanup(func() { client.Close() })
+	return client
+}
+
+var testGeneration = &GroupGeneration{GroupID: "group", MemberID: "member-1", GenerationID: 3}
+
+func TestClientCommitOffsetFindsNewCoordinator(t *testing.T) {
+	var b *scriptedBroker
+	commits := 0
+	b = newScriptedBroker(t, func(apiKey int16, req *decoder, resp *encoder) {
+		switch apiKey {
+		case apiFindCoordinator:
+			b.writeCoordinator(resp, errNone)
+		case apiOffsetCommit:
+			commits++
+			// Lần đầu coordinator đã chuyển sang broker khác
+			if commits == 1 {
+				writeOffsetCommit(resp, errNotCoordinator)
+				return
+			}
+			writeOffsetCommit(resp, errNone)
+		}
+	})
+	client := newTestClient(t, b)
+
+	if err := client.CommitOffset(context.Background(), testGeneration, "events", 0, 10); err != nil {
+		t.Fatalf("CommitOffset: %v", err)
+	}
+	if got := b.callCount(apiOffsetCommit); got != 2 {
+		t.Fatalf("OffsetCommit requests = %d, want 2", got)
+	}
+	// Coordinator cũ bị bỏ khỏi cache và được tìm lại trước lần thử sau
+	if got := b.callCount(apiFindCoordinator); got != 2 {
+		t.Fatalf("FindCoordinator requests = %d, want 2", got)
+	}
+}
+
+func TestClientGivesUpWhenCoordinatorNotAvailable(t *testing.T) {
+	var b *scriptedBroker
+	b = newScriptedBroker(t, func(apiKey int16, req *decoder, resp *encoder) {
+		if apiKey == apiFindCoordinator {
+			b.writeCoordinator(resp, errCoordinatorNotAvailable)
+		}
+	})
+	client := newTestClient(t, b)
+
+	err := client.CommitOffset(context.Background(), testGeneration, "events", 0, 10)
+	var kerr kafkaError
+	if !errors.As(err, &kerr) || kerr.Code != errCoordinatorNotAvailable {
+		t.Fatalf("CommitOffset error = %v, want coordinator not available", err)
+	}
+	if got := b.callCount(apiFindCoordinator); got != metadataRetries {
+		t.Fatalf("FindCoordinator requests = %d, want %d", got, metadataRetries)
+	}
+	if got := b.callCount(apiOffsetCommit); got != 0 {
+		t.Fatalf("OffsetCommit sent %d times without a coordinator", got)
+	}
+}
+
+func TestClientCommitOffsetReportsRebalance(t *testing.T) {
+	for _, code := range []int16{errIllegalGeneration, errUnknownMemberID, errRebalanceInProgress} {
+		var b *scriptedBroker
+		b = newScriptedBroker(t, func(apiKey int16, req *decoder, resp *encoder) {
+			switch apiKey {
+			case apiFindCoordinator:
+				b.writeCoordinator(resp, errNone)
+			case apiOffsetCommit:
+				writeOffsetCommit(resp, code)
+			case apiHeartbeat:
+				resp.int32(0)
+				resp.int16(code)
+			}
+		})
+		client := newTestClient(t, b)
+
+		// Generation đã cũ thì báo rebalance ngay, không thử lại với coordinator
+		if err := client.CommitOffset(context.Background(), testGeneration, "events", 0, 10); !errors.Is(err, ErrRebalanceInProgress) {
+			t.Fatalf("code %d: CommitOffset error = %v, want ErrRebalanceInProgress", code, err)
+		}
+		if got := b.callCount(apiOffsetCommit); got != 1 {
+			t.Fatalf("code %d: OffsetCommit requests = %d, want 1", code, got)
+		}
+		if err := client.Heartbeat(context.Background(), testGeneration); !errors.Is(err, ErrRebalanceInProgress) {
+			t.Fatalf("code %d: Heartbeat error = %v, want ErrRebalanceInProgress", code, err)
+		}
+	}
+}
+
+func TestClientJoinGroupRejoinsWithNewMemberID(t *testing.T) {
+	var (
+		b         *scriptedBroker
+		mu        sync.Mutex
+		memberIDs []string
+	)
+	b = newScriptedBroker(t, func(apiKey int16, req *decoder, resp *encoder) {
+		switch apiKey {
+		case apiFindCoordinator:
+			b.writeCoordinator(resp, errNone)
+		case apiJoinGroup:
+			req.string()
+			req.int32()
+			req.int32()
+			memberID := req.string()
+			mu.Lock()
+			memberIDs = append(memberIDs, memberID)
+			mu.Unlock()
+
+			code := errNone
+			// Coordinator đã quên member cũ sau session timeout
+			if memberID != "" {
+				code = errUnknownMemberID
+			}
+			resp.int32(0)
+			resp.int16(code)
+			resp.int32(7)
+			resp.string(rangeAssignor)
+			resp.string("leader")
+			resp.string("member-2")
+			resp.arrayLen(0)
+		case apiSyncGroup:
+			resp.int32(0)
+			resp.int16(errNone)
+			resp.bytes(encodeAssignment(map[string][]int{"events": {1}}))
+		}
+	})
+	client := newTestClient(t, b)
+
+	gen, err := client.JoinGroup(context.Background(), JoinGroupRequest{GroupID: "group", MemberID: "member-1", Topics: []string{"events"}})
+	if err != nil {
+		t.Fatalf("JoinGroup: %v", err)
+	}
+	if gen.MemberID != "member-2" || gen.GenerationID != 7 {
+		t.Fatalf("joined as %s in generation %d, want member-2 in generation 7", gen.MemberID, gen.GenerationID)
+	}
+	if got := gen.Assignments["events"]; len(got) != 1 || got[0] != 1 {
+		t.Fatalf("assignments = %v, want partition 1", gen.Assignments)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(memberIDs) != 2 || memberIDs[0] != "member-1" || memberIDs[1] != "" {
+		t.Fatalf("JoinGroup member ids = %q, want member-1 then empty", memberIDs)
+	}
+}
@@ -0,0 +1,354 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	defaultPoolSize          = 4
+	defaultWorkerQueueSize   = 64
+	defaultSessionTimeout    = 10 * time.Second
+	defaultRebalanceTimeout  = 30 * time.Second
+	defaultHeartbeatInterval = 3 * time.Second
+	defaultFetchMaxWait      = 500 * time.Millisecond
+	defaultRetryBackoff      = time.Second
+	defaultMaxRetryBackoff   = 30 * time.Second
+	leaveGroupTimeout        = 5 * time.Second
+)
+
+// Logger là các hàm log ConsumerGroup dùng, zap.Logger của service thoả mãn interface này
+type Logger interface {
+	KafkaProcessMessage(topic string, partition int, message string, workerID int, offset int64, time time.Time)
+	KafkaLogCommittedMessage(topic string, partition int, offset int64)
+	Warnf(template string, args ...interface{})
+	Errorf(template string, args ...interface{})
+}
+
+// Handler xử lý một message. Trả về lỗi thì message được xử lý lại sau một khoảng backoff,
+// offset chỉ được commit khi handler thành công.
+type Handler func(ctx context.Context, msg *Message) error
+
+type ConsumerGroupConfig struct {
+	GroupID string
+	Topics  []string
+	// PoolSize là số worker. Mỗi partition luôn do một worker xử lý để giữ thứ tự message trong partition.
+	PoolSize int
+	// FromBeginning: partition chưa có offset đã commit được đọc từ đầu thay vì chỉ đọc message mới
+	FromBeginning bool
+	// MaxAttempts là số lần xử lý lỗi trước khi bỏ qua message, 0 là thử lại tới khi thành công
+	MaxAttempts int
+
+	SessionTimeout    time.Duration
+	RebalanceTimeout  time.Duration
+	HeartbeatInterval time.Duration
+	FetchMaxWait      time.Duration
+	RetryBackoff      time.Duration
+	MaxRetryBackoff   time.Duration
+}
+
+func (c ConsumerGroupConfig) withDefaults() ConsumerGroupConfig {
+	if c.PoolSize <= 0 {
+		c.PoolSize = defaultPoolSize
+	}
+	if c.SessionTimeout <= 0 {
+		c.SessionTimeout = defaultSessionTimeout
+	}
+	if c.RebalanceTimeout <= 0 {
+		c.RebalanceTimeout = defaultRebalanceTimeout
+	}
+	if c.HeartbeatInterval <= 0 {
+		c.HeartbeatInterval = defaultHeartbeatInterval
+	}
+	if c.FetchMaxWait <= 0 {
+		c.FetchMaxWait = defaultFetchMaxWait
+	}
+	if c.RetryBackoff <= 0 {
+		c.RetryBackoff = defaultRetryBackoff
+	}
+	if c.MaxRetryBackoff <= 0 {
+		c.MaxRetryBackoff = defaultMaxRetryBackoff
+	}
+	return c
+}
+
+// ConsumerGroup đọc các topic theo consumer group và chia message cho một pool worker
+type ConsumerGroup struct {
+	broker  Broker
+	logger  Logger
+	cfg     ConsumerGroupConfig
+	handler Handler
+	cancel  context.CancelFunc
+	done    chan struct{}
+}
+
+// NewConsumerGroup join group và bắt đầu đọc ngay khi khởi tạo
+func NewConsumerGroup(broker Broker, logger Logger, cfg ConsumerGroupConfig, handler Handler) (*ConsumerGroup, error) {
+	if cfg.GroupID == "" || len(cfg.Topics) == 0 {
+		return nil, errors.New("kafka: consumer group requires a group ID and at least one topic")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	g := &ConsumerGroup{
+		broker:  broker,
+		logger:  logger,
+		cfg:     cfg.withDefaults(),
+		handler: handler,
+		cancel:  cancel,
+		done:    make(chan struct{}),
+	}
+	go g.run(ctx)
+	return g, nil
+}
+
+// Close rời group và chờ các worker dừng. Message đang xử lý dở chưa được commit nên sẽ được đọc lại.
+func (g *ConsumerGroup) Close() {
+	g.cancel()
+	<-g.done
+}
+
+func (g *ConsumerGroup) run(ctx context.Context) {
+	defer close(g.done)
+
+	memberID := ""
+	failures := 0
+	for ctx.Err() == nil {
+		gen, err := g.broker.JoinGroup(ctx, JoinGroupRequest{
+			GroupID:          g.cfg.GroupID,
+			MemberID:         memberID,
+			Topics:           g.cfg.Topics,
+			SessionTimeout:   g.cfg.SessionTimeout,
+			RebalanceTimeout: g.cfg.RebalanceTimeout,
+		})
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			failures++
+			g.logger.Warnf("kafka: group %s failed to join: %v", g.cfg.GroupID, err)
+			sleepContext(ctx, g.backoff(failures))
+			continue
+		}
+		failures = 0
+		memberID = gen.MemberID
+
+		err = g.consume(ctx, gen)
+		if ctx.Err() != nil {
+			leaveCtx, cancel := context.WithTimeout(context.Background(), leaveGroupTimeout)
+			if err := g.broker.LeaveGroup(leaveCtx, gen); err != nil {
+				g.logger.Warnf("kafka: group %s failed to leave: %v", g.cfg.GroupID, err)
+			}
+			cancel()
+			return
+		}
+		if !errors.Is(err, ErrRebalanceInProgress) {
+			g.logger.Warnf("kafka: group %s session ended: %v", g.cfg.GroupID, err)
+			sleepContext(ctx, g.cfg.RetryBackoff)
+		}
+	}
+}
+
+// consume xử lý các partition của một generation cho tới khi group rebalance hoặc ctx bị huỷ
+func (g *ConsumerGroup) consume(ctx context.Context, gen *GroupGeneration) error {
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+
+	var wg sync.WaitGroup
+	queues := make([]chan Message, g.cfg.PoolSize)
+	for i := range queues {
+		queues[i] = make(chan Message, defaultWorkerQueueSize)
+		wg.Add(1)
+		go func(workerID int, queue <-chan Message) {
+			defer wg.Done()
+			g.work(ctx, cancel, gen, workerID, queue)
+		}(i+1, queues[i])
+	}
+
+	next := 0
+	for topic, partitions := range gen.Assignments {
+		for _, partition := range partitions {
+			queue := queues[next%len(queues)]
+			next++
+			wg.Add(1)
+			go func(topic string, partition int) {
+				defer wg.Done()
+				g.fetch(ctx, gen, topic, partition, queue)
+			}(topic, partition)
+		}
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		g.heartbeat(ctx, cancel, gen)
+	}()
+
+	<-ctx.Done()
+	wg.Wait()
+	return context.Cause(ctx)
+}
+
+func (g *ConsumerGroup) heartbeat(ctx context.Context, cancel context.CancelCauseFunc, gen *GroupGeneration) {
+	ticker := time.NewTicker(g.cfg.HeartbeatInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		if err := g.broker.Heartbeat(ctx, gen); err != nil {
+			if ctx.Err() == nil {
+				cancel(err)
+			}
+			return
+		}
+	}
+}
+
+// fetch đọc một partition từ offset đã commit và đẩy message vào hàng đợi của worker phụ trách
+func (g *ConsumerGroup) fetch(ctx context.Context, gen *GroupGeneration, topic string, partition int, queue chan<- Message) {
+	offset, err := g.startOffset(ctx, topic, partition)
+	if err != nil {
+		return
+	}
+
+	failures := 0
+	for ctx.Err() == nil {
+		messages, err := g.broker.Fetch(ctx, topic, partition, offset, g.cfg.FetchMaxWait)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			if errors.Is(err, ErrOffsetOutOfRange) {
+				// Message ở offset đã commit bị xoá theo retention, đọc lại từ vị trí mặc định
+				g.logger.Warnf("kafka: %s/%d offset %d out of range, resetting", topic, partition, offset)
+				if offset, err = g.resetOffset(ctx, topic, partition); err != nil {
+					return
+				}
+				continue
+			}
+			failures++
+			g.logger.Warnf("kafka: %s/%d fetch failed: %v", topic, partition, err)
+			sleepContext(ctx, g.backoff(failures))
+			continue
+		}
+		failures = 0
+
+		for _, msg := range messages {
+			select {
+			case <-ctx.Done():
+				return
+			case queue <- msg:
+			}
+			offset = msg.Offset + 1
+		}
+	}
+}
+
+func (g *ConsumerGroup) startOffset(ctx context.Context, topic string, partition int) (int64, error) {
+	for failures := 1; ; failures++ {
+		offset, err := g.broker.CommittedOffset(ctx, g.cfg.GroupID, topic, partition)
+		if err == nil && offset < 0 {
+			offset, err = g.broker.ListOffset(ctx, topic, partition, g.cfg.FromBeginning)
+		}
+		if err == nil {
+			return offset, nil
+		}
+		if ctx.Err() != nil {
+			return 0, ctx.Err()
+		}
+		g.logger.Warnf("kafka: %s/%d failed to load offset: %v", topic, partition, err)
+		if err := sleepContext(ctx, g.backoff(failures)); err != nil {
+			return 0, err
+		}
+	}
+}
+
+func (g *ConsumerGroup) resetOffset(ctx context.Context, topic string, partition int) (int64, error) {
+	for failures := 1; ; failures++ {
+		offset, err := g.broker.ListOffset(ctx, topic, partition, g.cfg.FromBeginning)
+		if err == nil {
+			return offset, nil
+		}
+		if err := sleepContext(ctx, g.backoff(failures)); err != nil {
+			return 0, err
+		}
+	}
+}
+
+// work xử lý tuần tự message trong hàng đợi rồi commit offset của từng message
+func (g *ConsumerGroup) work(ctx context.Context, cancel context.CancelCauseFunc, gen *GroupGeneration, workerID int, queue <-chan Message) {
+	for {
+		var msg Message
+		select {
+		case <-ctx.Done():
+			return
+		case msg = <-queue:
+		}
+
+		g.logger.KafkaProcessMessage(msg.Topic, msg.Partition, string(msg.Value), workerID, msg.Offset, msg.Time)
+		if !g.process(ctx, workerID, &msg) {
+			return
+		}
+
+		if err := g.broker.CommitOffset(ctx, gen, msg.Topic, msg.Partition, msg.Offset+1); err != nil {
+			if ctx.Err() == nil {
+				// Không commit được thì partition có thể đã thuộc member khác, dừng generation này và join lại
+				cancel(fmt.Errorf("commit %s/%d offset %d: %w", msg.Topic, msg.Partition, msg.Offset, err))
+			}
+			return
+		}
+		g.logger.KafkaLogCommittedMessage(msg.Topic, msg.Partition, msg.Offset)
+	}
+}
+
+// process gọi handler cho tới khi thành công hoặc hết số lần thử, trả về false nếu ctx bị huỷ giữa chừng
+func (g *ConsumerGroup) process(ctx context.Context, workerID int, msg *Message) bool {
+	for attempt := 1; ; attempt++ {
+		err := g.callHandler(ctx, msg)
+		if err == nil {
+			return true
+		}
+		if ctx.Err() != nil {
+			return false
+		}
+
+		if g.cfg.MaxAttempts > 0 && attempt >= g.cfg.MaxAttempts {
+			g.logger.Errorf("kafka: worker %d skipping %s/%d offset %d after %d attempts: %v",
+				workerID, msg.Topic, msg.Partition, msg.Offset, attempt, err)
+			return true
+		}
+		g.logger.Warnf("kafka: worker %d failed to handle %s/%d offset %d (attempt %d): %v",
+			workerID, msg.Topic, msg.Partition, msg.Offset, attempt, err)
+		if sleepContext(ctx, g.backoff(attempt)) != nil {
+			return false
+		}
+	}
+}
+
+// callHandler biến panic trong handler thành lỗi để một message lỗi không làm sập service
+func (g *ConsumerGroup) callHandler(ctx context.Context, msg *Message) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("handler panic: %v", r)
+		}
+	}()
+	return g.handler(ctx, msg)
+}
+
+// backoff tăng gấp đôi sau mỗi lần lỗi, tối đa MaxRetryBackoff
+func (g *ConsumerGroup) backoff(failures int) time.Duration {
+	delay := g.cfg.RetryBackoff
+	for i := 1; i < failures && delay < g.cfg.MaxRetryBackoff; i++ {
+		delay *= 2
+	}
+	if delay > g.cfg.MaxRetryBackoff {
+		delay = g.cfg.MaxRetryBackoff
+	}
+	return delay
+}
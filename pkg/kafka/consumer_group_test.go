@@ -0,0 +1,466 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+const testTimeout = 5 * time.Second
+
+type testLogger struct{ t *testing.T }
+
+func (l testLogger) KafkaProcessMessage(string, int, string, int, int64, time.Time) {}
+func (l testLogger) KafkaLogCommittedMessage(string, int, int64)                    {}
+func (l testLogger) Warnf(template string, args ...interface{})                     { l.t.Logf(template, args...) }
+func (l testLogger) Errorf(template string, args ...interface{})                    { l.t.Logf(template, args...) }
+
+func testConfig(groupID string) ConsumerGroupConfig {
+	return ConsumerGroupConfig{
+		GroupID:           groupID,
+		Topics:            []string{"events"},
+		FromBeginning:     true,
+		HeartbeatInterval: 10 * time.Millisecond,
+		FetchMaxWait:      20 * time.Millisecond,
+		RetryBackoff:      time.Millisecond,
+		MaxRetryBackoff:   5 * time.Millisecond,
+	}
+}
+
+func startGroup(t *testing.T, broker Broker, cfg ConsumerGroupConfig, handler Handler) *ConsumerGroup {
+	t.Helper()
+	group, err := NewConsumerGroup(broker, testLogger{t}, cfg, handler)
+	if err != nil {
+		t.Fatalf("NewConsumerGroup: %v", err)
+	}
+	t.Cleanup(group.Close)
+	return group
+}
+
+func produce(t *testing.T, broker *FakeBroker, partition int, values ...string) {
+	t.Helper()
+	messages := make([]Message, 0, len(values))
+	for _, v := range values {
+		messages = append(messages, Message{Value: []byte(v)})
+	}
+	if err := broker.Produce(context.Background(), "events", partition, messages); err != nil {
+		t.Fatalf("Produce: %v", err)
+	}
+}
+
+func committed(t *testing.T, broker *FakeBroker, groupID string, partition int) int64 {
+	t.Helper()
+	offset, err := broker.CommittedOffset(context.Background(), groupID, "events", partition)
+	if err != nil {
+		t.Fatalf("CommittedOffset: %v", err)
+	}
+	return offset
+}
+
+// waitFor chờ cond đúng, hết testTimeout thì test thất bại
+func waitFor(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(testTimeout)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestConsumerGroupCommitsOnlyAfterHandlerSucceeds(t *testing.T) {
+	broker := NewFakeBroker(1)
+	produce(t, broker, 0, "first", "second")
+
+	started := make(chan struct{}, 1)
+	release := make(chan struct{})
+	var (
+		mu       sync.Mutex
+		attempts int
+	)
+	startGroup(t, broker, testConfig("commit"), func(ctx context.Context, msg *Message) error {
+		if msg.Offset != 0 {
+			return nil
+		}
+		select {
+		case started <- struct{}{}:
+		default:
+		}
+		<-release
+
+		mu.Lock()
+		defer mu.Unlock()
+		attempts++
+		// Hai lần đầu lỗi, message phải được xử lý lại chứ chưa được commit
+		if attempts <= 2 {
+			return errors.New("temporary failure")
+		}
+		return nil
+	})
+
+	select {
+	case <-started:
+	case <-time.After(testTimeout):
+		t.Fatal("handler was not called")
+	}
+	if offset := committed(t, broker, "commit", 0); offset != -1 {
+		t.Fatalf("offset committed while the handler is running: %d", offset)
+	}
+
+	close(release)
+	waitFor(t, "offset 2 to be committed", func() bool { return committed(t, broker, "commit", 0) == 2 })
+
+	mu.Lock()
+	defer mu.Unlock()
+	if attempts != 3 {
+		t.Fatalf("handler attempts = %d, want 3", attempts)
+	}
+}
+
+func TestConsumerGroupDoesNotCommitFailingMessage(t *testing.T) {
+	broker := NewFakeBroker(1)
+	produce(t, broker, 0, "poison", "next")
+
+	var (
+		mu    sync.Mutex
+		calls int
+	)
+	startGroup(t, broker, testConfig("no-skip"), func(ctx context.Context, msg *Message) error {
+		mu.Lock()
+		defer mu.Unlock()
+		calls++
+		return errors.New("always fails")
+	})
+
+	// MaxAttempts 0 thì thử lại mãi, offset không được commit
+	waitFor(t, "several attempts", func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return calls >= 5
+	})
+	if offset := committed(t, broker, "no-skip", 0); offset != -1 {
+		t.Fatalf("failing message was committed: offset %d", offset)
+	}
+}
+
+func TestConsumerGroupSkipsMessageAfterMaxAttempts(t *testing.T) {
+	broker := NewFakeBroker(1)
+	produce(t, broker, 0, "poison", "next")
+
+	var (
+		mu     sync.Mutex
+		calls  = make(map[int64]int)
+		poison = int64(0)
+	)
+	cfg := testConfig("skip")
+	cfg.MaxAttempts = 3
+	startGroup(t, broker, cfg, func(ctx context.Context, msg *Message) error {
+		mu.Lock()
+		defer mu.Unlock()
+		calls[msg.Offset]++
+		if msg.Offset == poison {
+			return errors.New("cannot handle")
+		}
+		return nil
+	})
+
+	waitFor(t, "both messages to be committed", func() bool { return committed(t, broker, "skip", 0) == 2 })
+
+	mu.Lock()
+	defer mu.Unlock()
+	if calls[poison] != 3 {
+		t.Fatalf("poison message handled %d times, want 3", calls[poison])
+	}
+	if calls[1] != 1 {
+		t.Fatalf("next message handled %d times, want 1", calls[1])
+	}
+}
+
+func TestConsumerGroupRebalancesWhenMemberJoins(t *testing.T) {
+	const partitions = 4
+	fake := NewFakeBroker(partitions)
+	broker := &joinRecorder{FakeBroker: fake, joined: make(map[string]*GroupGeneration)}
+
+	var (
+		mu      sync.Mutex
+		handled = make(map[string]string)
+	)
+	handlerFor := func(member string) Handler {
+		return func(ctx context.Context, msg *Message) error {
+			mu.Lock()
+			defer mu.Unlock()
+			handled[string(msg.Value)] = member
+			return nil
+		}
+	}
+	produceRound := func(round string) []string {
+		var values []string
+		for p := range partitions {
+			value := fmt.Sprintf("%s-%d", round, p)
+			produce(t, fake, p, value)
+			values = append(values, value)
+		}
+		return values
+	}
+	waitHandled := func(values []string) {
+		waitFor(t, "messages to be handled", func() bool {
+			mu.Lock()
+			defer mu.Unlock()
+			for _, v := range values {
+				if _, ok := handled[v]; !ok {
+					return false
+				}
+			}
+			return true
+		})
+	}
+
+	first := produceRound("before")
+	startGroup(t, broker, testConfig("rebalance"), handlerFor("a"))
+	waitHandled(first)
+
+	// Member thứ hai join: member đầu nhận ErrRebalanceInProgress, join lại và chỉ còn một nửa số partition.
+	// Chờ cả hai join generation mới, trước đó member đầu vẫn có thể đọc partition cũ rồi commit thất bại.
+	startGroup(t, broker, testConfig("rebalance"), handlerFor("b"))
+	waitFor(t, "both members to join the new generation", func() bool {
+		broker.mu.Lock()
+		defer broker.mu.Unlock()
+		a, b := broker.joined["rebalance-member-1"], broker.joined["rebalance-member-2"]
+		return a != nil && b != nil && a.GenerationID == b.GenerationID &&
+			len(a.Assignments["events"]) == partitions/2 && len(b.Assignments["events"]) == partitions/2
+	})
+
+	second := produceRound("after")
+	waitHandled(second)
+
+	mu.Lock()
+	defer mu.Unlock()
+	byMember := make(map[string]int)
+	for _, v := range second {
+		byMember[handled[v]]++
+	}
+	if byMember["a"] != partitions/2 || byMember["b"] != partitions/2 {
+		t.Fatalf("messages after rebalance handled by %v, want %d each", byMember, partitions/2)
+	}
+	for _, v := range first {
+		if handled[v] != "a" {
+			t.Fatalf("message %s was handled again by %s after rebalance", v, handled[v])
+		}
+	}
+}
+
+// joinRecorder giữ generation mới nhất mà từng member đã join
+type joinRecorder struct {
+	*FakeBroker
+
+	mu     sync.Mutex
+	joined map[string]*GroupGeneration
+}
+
+func (b *joinRecorder) JoinGroup(ctx context.Context, req JoinGroupRequest) (*GroupGeneration, error) {
+	gen, err := b.FakeBroker.JoinGroup(ctx, req)
+	if err == nil {
+		b.mu.Lock()
+		b.joined[gen.MemberID] = gen
+		b.mu.Unlock()
+	}
+	return gen, err
+}
+
+func TestFakeBrokerRejectsOldGenerationAfterJoin(t *testing.T) {
+	broker := NewFakeBroker(2)
+	ctx := context.Background()
+
+	first, err := broker.JoinGroup(ctx, JoinGroupRequest{GroupID: "g", Topics: []string{"events"}})
+	if err != nil {
+		t.Fatalf("JoinGroup: %v", err)
+	}
+	if got := first.Assignments["events"]; len(got) != 2 {
+		t.Fatalf("single member assigned %v, want both partitions", got)
+	}
+
+	second, err := broker.JoinGroup(ctx, JoinGroupRequest{GroupID: "g", Topics: []string{"events"}})
+	if err != nil {
+		t.Fatalf("JoinGroup: %v", err)
+	}
+	if err := broker.Heartbeat(ctx, first); !errors.Is(err, ErrRebalanceInProgress) {
+		t.Fatalf("Heartbeat of old generation = %v, want ErrRebalanceInProgress", err)
+	}
+	if err := broker.CommitOffset(ctx, first, "events", 0, 1); !errors.Is(err, ErrRebalanceInProgress) {
+		t.Fatalf("CommitOffset of old generation = %v, want ErrRebalanceInProgress", err)
+	}
+
+	rejoined, err := broker.JoinGroup(ctx, JoinGroupRequest{GroupID: "g", MemberID: first.MemberID, Topics: []string{"events"}})
+	if err != nil {
+		t.Fatalf("JoinGroup: %v", err)
+	}
+	if rejoined.GenerationID != second.GenerationID {
+		t.Fatalf("rejoin moved to generation %d, want %d", rejoined.GenerationID, second.GenerationID)
+	}
+	if a, b := rejoined.Assignments["events"], second.Assignments["events"]; len(a) != 1 || len(b) != 1 || a[0] == b[0] {
+		t.Fatalf("partitions not split between members: %v and %v", a, b)
+	}
+}
+
+// flakyBroker trả lỗi coordinator cho vài lần gọi đầu tiên của từng thao tác group rồi chuyển cho FakeBroker
+type flakyBroker struct {
+	*FakeBroker
+
+	mu              sync.Mutex
+	joinFailures    int
+	offsetFailures  int
+	commitFailures  int
+	commitsRejected int
+}
+
+var errCoordinatorGone = kafkaError{Code: errCoordinatorNotAvailable}
+
+func (b *flakyBroker) fail(remaining *int) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if *remaining == 0 {
+		return false
+	}
+	*remaining--
+	return true
+}
+
+func (b *flakyBroker) JoinGroup(ctx context.Context, req JoinGroupRequest) (*GroupGeneration, error) {
+	if b.fail(&b.joinFailures) {
+		return nil, errCoordinatorGone
+	}
+	return b.FakeBroker.JoinGroup(ctx, req)
+}
+
+func (b *flakyBroker) CommittedOffset(ctx context.Context, groupID, topic string, partition int) (int64, error) {
+	if b.fail(&b.offsetFailures) {
+		return 0, errCoordinatorGone
+	}
+	return b.FakeBroker.CommittedOffset(ctx, groupID, topic, partition)
+}
+
+func (b *flakyBroker) CommitOffset(ctx context.Context, gen *GroupGeneration, topic string, partition int, offset int64) error {
+	if b.fail(&b.commitFailures) {
+		b.mu.Lock()
+		b.commitsRejected++
+		b.mu.Unlock()
+		return errCoordinatorGone
+	}
+	return b.FakeBroker.CommitOffset(ctx, gen, topic, partition, offset)
+}
+
+func TestConsumerGroupRetriesCoordinatorErrors(t *testing.T) {
+	fake := NewFakeBroker(1)
+	produce(t, fake, 0, "first")
+	broker := &flakyBroker{FakeBroker: fake, joinFailures: 2, offsetFailures: 2}
+
+	var (
+		mu    sync.Mutex
+		calls int
+	)
+	startGroup(t, broker, testConfig("coordinator"), func(ctx context.Context, msg *Message) error {
+		mu.Lock()
+		defer mu.Unlock()
+		calls++
+		return nil
+	})
+
+	// Join và đọc offset lỗi vài lần không được bỏ qua message hay commit sai offset
+	waitFor(t, "offset 1 to be committed", func() bool { return committed(t, fake, "coordinator", 0) == 1 })
+
+	mu.Lock()
+	defer mu.Unlock()
+	if calls != 1 {
+		t.Fatalf("handler calls = %d, want 1", calls)
+	}
+	broker.mu.Lock()
+	defer broker.mu.Unlock()
+	if broker.joinFailures != 0 || broker.offsetFailures != 0 {
+		t.Fatalf("injected failures not consumed: join %d, offset fetch %d", broker.joinFailures, broker.offsetFailures)
+	}
+}
+
+func TestConsumerGroupRedeliversAfterCommitFailure(t *testing.T) {
+	fake := NewFakeBroker(1)
+	produce(t, fake, 0, "first", "second")
+	broker := &flakyBroker{FakeBroker: fake, commitFailures: 1}
+
+	var (
+		mu    sync.Mutex
+		calls = make(map[int64]int)
+	)
+	startGroup(t, broker, testConfig("commit-failure"), func(ctx context.Context, msg *Message) error {
+		mu.Lock()
+		defer mu.Unlock()
+		calls[msg.Offset]++
+		return nil
+	})
+
+	waitFor(t, "offset 2 to be committed", func() bool { return committed(t, fake, "commit-failure", 0) == 2 })
+
+	// Commit lỗi thì generation dừng, member join lại và đọc lại từ offset đã commit nên message được xử lý lại
+	mu.Lock()
+	defer mu.Unlock()
+	broker.mu.Lock()
+	defer broker.mu.Unlock()
+	if broker.commitsRejected != 1 {
+		t.Fatalf("rejected commits = %d, want 1", broker.commitsRejected)
+	}
+	if calls[0] != 2 {
+		t.Fatalf("message with failed commit handled %d times, want 2", calls[0])
+	}
+	if calls[1] != 1 {
+		t.Fatalf("next message handled %d times, want 1", calls[1])
+	}
+}
+
+func TestConsumerGroupStopsCommittingAfterRebalance(t *testing.T) {
+	broker := NewFakeBroker(1)
+	produce(t, broker, 0, "first", "second")
+
+	started := make(chan struct{}, 1)
+	release := make(chan struct{})
+	var (
+		mu    sync.Mutex
+		calls = make(map[int64]int)
+	)
+	startGroup(t, broker, testConfig("stale"), func(ctx context.Context, msg *Message) error {
+		mu.Lock()
+		calls[msg.Offset]++
+		first := calls[msg.Offset] == 1 && msg.Offset == 0
+		mu.Unlock()
+		if first {
+			started <- struct{}{}
+			<-release
+		}
+		return nil
+	})
+
+	select {
+	case <-started:
+	case <-time.After(testTimeout):
+		t.Fatal("handler was not called")
+	}
+
+	// Một member khác join rồi rời group khi message đầu còn đang xử lý: generation cũ bị từ chối khi commit
+	other, err := broker.JoinGroup(context.Background(), JoinGroupRequest{GroupID: "stale", Topics: []string{"events"}})
+	if err != nil {
+		t.Fatalf("JoinGroup: %v", err)
+	}
+	if err := broker.LeaveGroup(context.Background(), other); err != nil {
+		t.Fatalf("LeaveGroup: %v", err)
+	}
+	close(release)
+
+	waitFor(t, "offset 2 to be committed", func() bool { return committed(t, broker, "stale", 0) == 2 })
+
+	mu.Lock()
+	defer mu.Unlock()
+	if calls[0] != 2 {
+		t.Fatalf("message from the old generation handled %d times, want 2", calls[0])
+	}
+}
@@ -0,0 +1,280 @@
+package kafka
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+const defaultFakePartitions = 3
+
+// FakeBroker là Kafka trong bộ nhớ để chạy thử và test Producer, ConsumerGroup mà không cần cluster.
+// Topic được tạo khi dùng lần đầu. Rebalance đơn giản hơn Kafka thật: member mới join thì group sang
+// generation mới ngay, các member cũ nhận ErrRebalanceInProgress ở heartbeat hoặc commit tiếp theo.
+type FakeBroker struct {
+	partitions int
+
+	mu      sync.Mutex
+	closed  bool
+	topics  map[string][][]Message
+	groups  map[string]*fakeGroup
+	changed chan struct{}
+}
+
+type fakeGroup struct {
+	generation  int32
+	nextMember  int
+	members     map[string][]string
+	assignments map[string]map[string][]int
+	offsets     map[string]map[int]int64
+}
+
+// NewFakeBroker tạo broker mà mỗi topic có partitions partition, partitions <= 0 thì dùng 3
+func NewFakeBroker(partitions int) *FakeBroker {
+	if partitions <= 0 {
+		partitions = defaultFakePartitions
+	}
+	return &FakeBroker{
+		partitions: partitions,
+		topics:     make(map[string][][]Message),
+		groups:     make(map[string]*fakeGroup),
+		changed:    make(chan struct{}),
+	}
+}
+
+// Messages trả về mọi message đã ghi vào topic, sắp theo partition rồi offset
+func (b *FakeBroker) Messages(topic string) []Message {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var messages []Message
+	for _, log := range b.topics[topic] {
+		messages = append(messages, log...)
+	}
+	return messages
+}
+
+func (b *FakeBroker) Close() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.closed = true
+	return nil
+}
+
+func (b *FakeBroker) Partitions(ctx context.Context, topic string) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return len(b.topic(topic)), nil
+}
+
+func (b *FakeBroker) Produce(ctx context.Context, topic string, partition int, messages []Message) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return ErrClosed
+	}
+	logs := b.topic(topic)
+	if partition < 0 || partition >= len(logs) {
+		return ErrUnknownTopic
+	}
+	for _, m := range messages {
+		m.Topic = topic
+		m.Partition = partition
+		m.Offset = int64(len(logs[partition]))
+		if m.Time.IsZero() {
+			m.Time = time.Now()
+		}
+		logs[partition] = append(logs[partition], m)
+	}
+
+	close(b.changed)
+	b.changed = make(chan struct{})
+	return nil
+}
+
+func (b *FakeBroker) Fetch(ctx context.Context, topic string, partition int, offset int64, maxWait time.Duration) ([]Message, error) {
+	timer := time.NewTimer(maxWait)
+	defer timer.Stop()
+
+	for {
+		b.mu.Lock()
+		if b.closed {
+			b.mu.Unlock()
+			return nil, ErrClosed
+		}
+		logs := b.topic(topic)
+		if partition < 0 || partition >= len(logs) {
+			b.mu.Unlock()
+			return nil, ErrUnknownTopic
+		}
+		log := logs[partition]
+		if offset < 0 || offset > int64(len(log)) {
+			b.mu.Unlock()
+			return nil, ErrOffsetOutOfRange
+		}
+		if offset < int64(len(log)) {
+			messages := append([]Message(nil), log[offset:]...)
+			b.mu.Unlock()
+			return messages, nil
+		}
+		changed := b.changed
+		b.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-timer.C:
+			return nil, nil
+		case <-changed:
+		}
+	}
+}
+
+func (b *FakeBroker) ListOffset(ctx context.Context, topic string, partition int, first bool) (int64, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	logs := b.topic(topic)
+	if partition < 0 || partition >= len(logs) {
+		return 0, ErrUnknownTopic
+	}
+	if first {
+		return 0, nil
+	}
+	return int64(len(logs[partition])), nil
+}
+
+func (b *FakeBroker) JoinGroup(ctx context.Context, req JoinGroupRequest) (*GroupGeneration, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return nil, ErrClosed
+	}
+	group := b.group(req.GroupID)
+
+	memberID := req.MemberID
+	if _, ok := group.members[memberID]; !ok {
+		if memberID == "" {
+			group.nextMember++
+			memberID = fmt.Sprintf("%s-member-%d", req.GroupID, group.nextMember)
+		}
+		group.members[memberID] = append([]string(nil), req.Topics...)
+		b.rebalance(group)
+	}
+
+	return &GroupGeneration{
+		GroupID:      req.GroupID,
+		MemberID:     memberID,
+		GenerationID: group.generation,
+		Assignments:  copyAssignments(group.assignments[memberID]),
+	}, nil
+}
+
+func (b *FakeBroker) Heartbeat(ctx context.Context, gen *GroupGeneration) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.checkGeneration(gen)
+}
+
+func (b *FakeBroker) LeaveGroup(ctx context.Context, gen *GroupGeneration) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	group := b.group(gen.GroupID)
+	if _, ok := group.members[gen.MemberID]; ok {
+		delete(group.members, gen.MemberID)
+		b.rebalance(group)
+	}
+	return nil
+}
+
+func (b *FakeBroker) CommittedOffset(ctx context.Context, groupID, topic string, partition int) (int64, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	offset, ok := b.group(groupID).offsets[topic][partition]
+	if !ok {
+		return -1, nil
+	}
+	return offset, nil
+}
+
+func (b *FakeBroker) CommitOffset(ctx context.Context, gen *GroupGeneration, topic string, partition int, offset int64) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if err := b.checkGeneration(gen); err != nil {
+		return err
+	}
+	group := b.group(gen.GroupID)
+	if group.offsets[topic] == nil {
+		group.offsets[topic] = make(map[int]int64)
+	}
+	group.offsets[topic][partition] = offset
+	return nil
+}
+
+func (b *FakeBroker) checkGeneration(gen *GroupGeneration) error {
+	if b.closed {
+		return ErrClosed
+	}
+	group := b.group(gen.GroupID)
+	if _, ok := group.members[gen.MemberID]; !ok || group.generation != gen.GenerationID {
+		return ErrRebalanceInProgress
+	}
+	return nil
+}
+
+// rebalance chia lại partition cho các member hiện có bằng range assignor
+func (b *FakeBroker) rebalance(group *fakeGroup) {
+	group.generation++
+	group.assignments = make(map[string]map[string][]int, len(group.members))
+
+	membersByTopic := make(map[string][]string)
+	for memberID, topics := range group.members {
+		group.assignments[memberID] = make(map[string][]int)
+		for _, topic := range topics {
+			membersByTopic[topic] = append(membersByTopic[topic], memberID)
+		}
+	}
+	for topic, members := range membersByTopic {
+		sort.Strings(members)
+		for memberID, partitions := range rangeAssign(members, len(b.topic(topic))) {
+			group.assignments[memberID][topic] = partitions
+		}
+	}
+}
+
+func (b *FakeBroker) topic(name string) [][]Message {
+	logs, ok := b.topics[name]
+	if !ok {
+		logs = make([][]Message, b.partitions)
+		b.topics[name] = logs
+	}
+	return logs
+}
+
+func (b *FakeBroker) group(id string) *fakeGroup {
+	group, ok := b.groups[id]
+	if !ok {
+		group = &fakeGroup{
+			members:     make(map[string][]string),
+			assignments: make(map[string]map[string][]int),
+			offsets:     make(map[string]map[int]int64),
+		}
+		b.groups[id] = group
+	}
+	return group
+}
+
+func copyAssignments(assignments map[string][]int) map[string][]int {
+	result := make(map[string][]int, len(assignments))
+	for topic, partitions := range assignments {
+		result[topic] = append([]int(nil), partitions...)
+	}
+	return result
+}
@@ -0,0 +1,81 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var (
+	// ErrRebalanceInProgress báo member phải join lại group, các partition đang xử lý có thể đã được giao cho member khác
+	ErrRebalanceInProgress = errors.New("kafka: consumer group is rebalancing")
+	ErrOffsetOutOfRange    = errors.New("kafka: offset out of range")
+	ErrUnknownTopic        = errors.New("kafka: unknown topic or partition")
+	ErrClosed              = errors.New("kafka: client is closed")
+)
+
+type Header struct {
+	Key   string
+	Value []byte
+}
+
+type Message struct {
+	Topic     string
+	Partition int
+	Offset    int64
+	Key       []byte
+	Value     []byte
+	Headers   []Header
+	Time      time.Time
+}
+
+// Header trả về giá trị header đầu tiên có key, nil nếu không có
+func (m *Message) Header(key string) []byte {
+	for _, h := range m.Headers {
+		if h.Key == key {
+			return h.Value
+		}
+	}
+	return nil
+}
+
+type JoinGroupRequest struct {
+	GroupID  string
+	MemberID string
+	Topics   []string
+	// SessionTimeout là thời gian coordinator chờ heartbeat trước khi loại member khỏi group
+	SessionTimeout time.Duration
+	// RebalanceTimeout là thời gian tối đa coordinator chờ các member join lại khi rebalance
+	RebalanceTimeout time.Duration
+}
+
+// GroupGeneration là một lần phân chia partition của consumer group, hết hiệu lực khi group rebalance
+type GroupGeneration struct {
+	GroupID      string
+	MemberID     string
+	GenerationID int32
+	// Assignments là các partition của từng topic được giao cho member này
+	Assignments map[string][]int
+}
+
+// Broker là các thao tác Producer và ConsumerGroup cần từ Kafka. Client nói chuyện với Kafka thật qua TCP,
+// FakeBroker giữ mọi thứ trong bộ nhớ để chạy thử và test.
+type Broker interface {
+	Partitions(ctx context.Context, topic string) (int, error)
+	Produce(ctx context.Context, topic string, partition int, messages []Message) error
+	// Fetch đọc message từ offset, chờ tối đa maxWait khi partition chưa có message mới
+	Fetch(ctx context.Context, topic string, partition int, offset int64, maxWait time.Duration) ([]Message, error)
+	// ListOffset trả về offset đầu tiên còn giữ (first) hoặc offset sẽ được ghi tiếp theo của partition
+	ListOffset(ctx context.Context, topic string, partition int, first bool) (int64, error)
+
+	// JoinGroup chặn tới khi group rebalance xong và trả về partition được giao cho member
+	JoinGroup(ctx context.Context, req JoinGroupRequest) (*GroupGeneration, error)
+	Heartbeat(ctx context.Context, gen *GroupGeneration) error
+	LeaveGroup(ctx context.Context, gen *GroupGeneration) error
+	// CommittedOffset trả về offset group đã commit, -1 nếu chưa commit lần nào
+	CommittedOffset(ctx context.Context, groupID, topic string, partition int) (int64, error)
+	// CommitOffset ghi offset của message tiếp theo cần đọc
+	CommitOffset(ctx context.Context, gen *GroupGeneration, topic string, partition int, offset int64) error
+
+	Close() error
+}
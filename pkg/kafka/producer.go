@@ -0,0 +1,112 @@
+package kafka
+
+import (
+	"context"
+	"math/rand/v2"
+	"sync"
+	"time"
+)
+
+const defaultPartitionCacheTTL = time.Minute
+
+// Producer gửi message vào topic. Message cùng key luôn vào cùng partition (cùng cách chia với client Java
+// mặc định) nên được đọc theo đúng thứ tự gửi. Broker do bên gọi quản lý và đóng.
+type Producer struct {
+	broker Broker
+
+	mu         sync.Mutex
+	partitions map[string]partitionCount
+}
+
+type partitionCount struct {
+	count     int
+	expiresAt time.Time
+}
+
+func NewProducer(broker Broker) *Producer {
+	return &Producer{broker: broker, partitions: make(map[string]partitionCount)}
+}
+
+// Send gửi một message và chờ broker xác nhận. Message không có key được gửi vào partition ngẫu nhiên.
+func (p *Producer) Send(ctx context.Context, topic string, key, value []byte, headers ...Header) error {
+	count, err := p.partitionCount(ctx, topic)
+	if err != nil {
+		return err
+	}
+
+	partition := rand.IntN(count)
+	if key != nil {
+		partition = int(murmur2(key)&0x7fffffff) % count
+	}
+
+	return p.broker.Produce(ctx, topic, partition, []Message{{
+		Topic:     topic,
+		Partition: partition,
+		Key:       key,
+		Value:     value,
+		Headers:   headers,
+		Time:      time.Now(),
+	}})
+}
+
+// partitionCount được cache một thời gian để không hỏi metadata ở mỗi lần gửi
+func (p *Producer) partitionCount(ctx context.Context, topic string) (int, error) {
+	p.mu.Lock()
+	cached, ok := p.partitions[topic]
+	p.mu.Unlock()
+	if ok && time.Now().Before(cached.expiresAt) {
+		return cached.count, nil
+	}
+
+	count, err := p.broker.Partitions(ctx, topic)
+	if err != nil {
+		return 0, err
+	}
+	if count == 0 {
+		return 0, ErrUnknownTopic
+	}
+
+	p.mu.Lock()
+	p.partitions[topic] = partitionCount{count: count, expiresAt: time.Now().Add(defaultPartitionCacheTTL)}
+	p.mu.Unlock()
+	return count, nil
+}
+
+// murmur2 là hàm băm key của DefaultPartitioner trong client Java
+func murmur2(data []byte) int32 {
+	const (
+		seed uint32 = 0x9747b28c
+		m    uint32 = 0x5bd1e995
+		r           = 24
+	)
+
+	length := len(data)
+	h := seed ^ uint32(length)
+
+	for i := 0; i+4 <= length; i += 4 {
+		k := uint32(data[i]) | uint32(data[i+1])<<8 | uint32(data[i+2])<<16 | uint32(data[i+3])<<24
+		k *= m
+		k ^= k >> r
+		k *= m
+		h *= m
+		h ^= k
+	}
+
+	tail := length &^ 3
+	switch length % 4 {
+	case 3:
+		h ^= uint32(data[tail+2]) << 16
+		fallthrough
+	case 2:
+		h ^= uint32(data[tail+1]) << 8
+		fallthrough
+	case 1:
+		h ^= uint32(data[tail])
+		h *= m
+	}
+
+	h ^= h >> 13
+	h *= m
+	h ^= h >> 15
+	return int32(h)
+}
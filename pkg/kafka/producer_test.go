@@ -0,0 +1,73 @@
+package kafka
+
+import (
+	"context"
+	"testing"
+)
+
+// Giá trị lấy từ UtilsTest.testMurmur2 của client Java
+var javaMurmur2Vectors = []struct {
+	key  string
+	hash int32
+}{
+	{"21", -973932308},
+	{"foobar", -790332482},
+	{"a-little-bit-long-string", -985981536},
+	{"a-little-bit-longer-string", -1486304829},
+	{"lkjh234lh9fiuh90y23oiuhsafujhadof229phr9h19h89h8", -58897971},
+	{"abc", 479470107},
+}
+
+func TestMurmur2MatchesJavaClient(t *testing.T) {
+	for _, tc := range javaMurmur2Vectors {
+		if got := murmur2([]byte(tc.key)); got != tc.hash {
+			t.Errorf("murmur2(%q) = %d, want %d", tc.key, got, tc.hash)
+		}
+	}
+}
+
+func TestProducerPartitionsByKey(t *testing.T) {
+	// Partition theo DefaultPartitioner của Java: toPositive(murmur2(key)) % 12
+	want := map[string]int{
+		"21":                         0,
+		"foobar":                     6,
+		"a-little-bit-long-string":   8,
+		"a-little-bit-longer-string": 11,
+		"lkjh234lh9fiuh90y23oiuhsafujhadof229phr9h19h89h8": 5,
+		"abc": 3,
+	}
+
+	broker := NewFakeBroker(12)
+	producer := NewProducer(broker)
+	for key := range want {
+		// Gửi hai lần để chắc cùng key luôn vào cùng partition
+		for range 2 {
+			if err := producer.Send(context.Background(), "events", []byte(key), []byte("v")); err != nil {
+				t.Fatalf("Send(%q): %v", key, err)
+			}
+		}
+	}
+
+	messages := broker.Messages("events")
+	if len(messages) != 2*len(want) {
+		t.Fatalf("got %d messages, want %d", len(messages), 2*len(want))
+	}
+	for _, msg := range messages {
+		if partition := want[string(msg.Key)]; msg.Partition != partition {
+			t.Errorf("key %q went to partition %d, want %d", msg.Key, msg.Partition, partition)
+		}
+	}
+}
+
+func TestProducerWithoutKeyUsesValidPartition(t *testing.T) {
+	broker := NewFakeBroker(4)
+	producer := NewProducer(broker)
+	for range 20 {
+		if err := producer.Send(context.Background(), "events", nil, []byte("v")); err != nil {
+			t.Fatalf("Send: %v", err)
+		}
+	}
+	if got := len(broker.Messages("events")); got != 20 {
+		t.Fatalf("got %d messages, want 20", got)
+	}
+}
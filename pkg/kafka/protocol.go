@@ -0,0 +1,390 @@
+package kafka
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"time"
+)
+
+// Các API Kafka được dùng, đều ở version chưa có tagged field (không phải flexible version)
+const (
+	apiProduce         int16 = 0
+	apiFetch           int16 = 1
+	apiListOffsets     int16 = 2
+	apiMetadata        int16 = 3
+	apiOffsetCommit    int16 = 8
+	apiOffsetFetch     int16 = 9
+	apiFindCoordinator int16 = 10
+	apiJoinGroup       int16 = 11
+	apiHeartbeat       int16 = 12
+	apiLeaveGroup      int16 = 13
+	apiSyncGroup       int16 = 14
+)
+
+// Mã lỗi Kafka được xử lý riêng
+const (
+	errNone                      int16 = 0
+	errOffsetOutOfRange          int16 = 1
+	errUnknownTopicOrPartition   int16 = 3
+	errLeaderNotAvailable        int16 = 5
+	errNotLeaderForPartition     int16 = 6
+	errCoordinatorLoadInProgress int16 = 14
+	errCoordinatorNotAvailable   int16 = 15
+	errNotCoordinator            int16 = 16
+	errIllegalGeneration         int16 = 22
+	errUnknownMemberID           int16 = 25
+	errRebalanceInProgress       int16 = 27
+)
+
+// kafkaError là lỗi broker trả về trong response
+type kafkaError struct {
+	Code int16
+}
+
+func (e kafkaError) Error() string {
+	return fmt.Sprintf("kafka: broker returned error code %d", e.Code)
+}
+
+func errorFromCode(code int16) error {
+	switch code {
+	case errNone:
+		return nil
+	case errOffsetOutOfRange:
+		return ErrOffsetOutOfRange
+	case errUnknownTopicOrPartition:
+		return ErrUnknownTopic
+	case errIllegalGeneration, errUnknownMemberID, errRebalanceInProgress:
+		return ErrRebalanceInProgress
+	default:
+		return kafkaError{Code: code}
+	}
+}
+
+// isStaleMetadata cho biết lỗi do leader hoặc coordinator đã đổi, cần đọc lại metadata rồi thử lại
+func isStaleMetadata(err error) bool {
+	var kerr kafkaError
+	if errors.As(err, &kerr) {
+		switch kerr.Code {
+		case errLeaderNotAvailable, errNotLeaderForPartition,
+			errCoordinatorLoadInProgress, errCoordinatorNotAvailable, errNotCoordinator:
+			return true
+		}
+	}
+	return errors.Is(err, ErrUnknownTopic)
+}
+
+type encoder struct {
+	buf bytes.Buffer
+}
+
+func (e *encoder) int8(v int8)    { e.buf.WriteByte(byte(v)) }
+func (e *encoder) int16(v int16)  { e.buf.Write(binary.BigEndian.AppendUint16(nil, uint16(v))) }
+func (e *encoder) int32(v int32)  { e.buf.Write(binary.BigEndian.AppendUint32(nil, uint32(v))) }
+func (e *encoder) int64(v int64)  { e.buf.Write(binary.BigEndian.AppendUint64(nil, uint64(v))) }
+func (e *encoder) arrayLen(n int) { e.int32(int32(n)) }
+
+func (e *encoder) string(s string) {
+	e.int16(int16(len(s)))
+	e.buf.WriteString(s)
+}
+
+func (e *encoder) nullableString(s *string) {
+	if s == nil {
+		e.int16(-1)
+		return
+	}
+	e.string(*s)
+}
+
+func (e *encoder) bytes(b []byte) {
+	if b == nil {
+		e.int32(-1)
+		return
+	}
+	e.int32(int32(len(b)))
+	e.buf.Write(b)
+}
+
+func (e *encoder) varint(v int64) {
+	e.buf.Write(binary.AppendVarint(nil, v))
+}
+
+func (e *encoder) varintBytes(b []byte) {
+	if b == nil {
+		e.varint(-1)
+		return
+	}
+	e.varint(int64(len(b)))
+	e.buf.Write(b)
+}
+
+// decoder đọc response, lỗi đầu tiên được giữ lại và các lần đọc sau trả về giá trị rỗng
+type decoder struct {
+	data []byte
+	err  error
+}
+
+var errShortBuffer = errors.New("kafka: malformed response")
+
+func (d *decoder) take(n int) []byte {
+	if d.err != nil {
+		return nil
+	}
+	if n < 0 || n > len(d.data) {
+		d.err = errShortBuffer
+		return nil
+	}
+	b := d.data[:n]
+	d.data = d.data[n:]
+	return b
+}
+
+func (d *decoder) int8() int8 {
+	b := d.take(1)
+	if b == nil {
+		return 0
+	}
+	return int8(b[0])
+}
+
+func (d *decoder) int16() int16 {
+	b := d.take(2)
+	if b == nil {
+		return 0
+	}
+	return int16(binary.BigEndian.Uint16(b))
+}
+
+func (d *decoder) int32() int32 {
+	b := d.take(4)
+	if b == nil {
+		return 0
+	}
+	return int32(binary.BigEndian.Uint32(b))
+}
+
+func (d *decoder) int64() int64 {
+	b := d.take(8)
+	if b == nil {
+		return 0
+	}
+	return int64(binary.BigEndian.Uint64(b))
+}
+
+func (d *decoder) bool() bool { return d.int8() != 0 }
+
+// arrayLen trả về 0 với mảng null
+func (d *decoder) arrayLen() int {
+	n := d.int32()
+	if n < 0 {
+		return 0
+	}
+	if int(n) > len(d.data) {
+		d.err = errShortBuffer
+		return 0
+	}
+	return int(n)
+}
+
+func (d *decoder) string() string {
+	n := d.int16()
+	if n < 0 {
+		return ""
+	}
+	return string(d.take(int(n)))
+}
+
+func (d *decoder) bytes() []byte {
+	n := d.int32()
+	if n < 0 {
+		return nil
+	}
+	return d.take(int(n))
+}
+
+func (d *decoder) varint() int64 {
+	if d.err != nil {
+		return 0
+	}
+	v, n := binary.Varint(d.data)
+	if n <= 0 {
+		d.err = errShortBuffer
+		return 0
+	}
+	d.data = d.data[n:]
+	return v
+}
+
+func (d *decoder) varintBytes() []byte {
+	n := d.varint()
+	if n < 0 {
+		return nil
+	}
+	return d.take(int(n))
+}
+
+const (
+	recordBatchMagic      = 2
+	recordBatchHeaderSize = 61
+	// Vị trí field attributes, CRC được tính từ đây tới hết batch
+	recordBatchCRCStart = 21
+
+	compressionMask = 0x07
+	compressionNone = 0
+	compressionGzip = 1
+	controlBatchBit = 0x20
+)
+
+var crc32c = crc32.MakeTable(crc32.Castagnoli)
+
+// encodeRecordBatch đóng gói message thành một record batch v2 không nén
+func encodeRecordBatch(messages []Message) []byte {
+	now := time.Now()
+	firstTimestamp := timestampOf(messages[0], now)
+	maxTimestamp := firstTimestamp
+
+	var records encoder
+	for i, m := range messages {
+		ts := timestampOf(m, now)
+		if ts > maxTimestamp {
+			maxTimestamp = ts
+		}
+
+		var r encoder
+		r.int8(0)
+		r.varint(ts - firstTimestamp)
+		r.varint(int64(i))
+		r.varintBytes(m.Key)
+		r.varintBytes(m.Value)
+		r.varint(int64(len(m.Headers)))
+		for _, h := range m.Headers {
+			r.varintBytes([]byte(h.Key))
+			r.varintBytes(h.Value)
+		}
+
+		records.varint(int64(r.buf.Len()))
+		records.buf.Write(r.buf.Bytes())
+	}
+
+	var batch encoder
+	batch.int64(0)
+	batch.int32(int32(recordBatchHeaderSize - 12 + records.buf.Len()))
+	batch.int32(-1)
+	batch.int8(recordBatchMagic)
+	batch.int32(0)
+	batch.int16(0)
+	batch.int32(int32(len(messages) - 1))
+	batch.int64(firstTimestamp)
+	batch.int64(maxTimestamp)
+	batch.int64(-1)
+	batch.int16(-1)
+	batch.int32(-1)
+	batch.int32(int32(len(messages)))
+	batch.buf.Write(records.buf.Bytes())
+
+	data := batch.buf.Bytes()
+	binary.BigEndian.PutUint32(data[17:21], crc32.Checksum(data[recordBatchCRCStart:], crc32c))
+	return data
+}
+
+func timestampOf(m Message, now time.Time) int64 {
+	if m.Time.IsZero() {
+		return now.UnixMilli()
+	}
+	return m.Time.UnixMilli()
+}
+
+// decodeRecordBatches đọc các record batch trong fetch response. Batch cuối có thể bị broker cắt ngang
+// do giới hạn kích thước, phần đó được bỏ qua và sẽ được đọc lại ở lần fetch sau.
+func decodeRecordBatches(topic string, partition int, data []byte, fromOffset int64) ([]Message, error) {
+	var messages []Message
+	for len(data) >= 17 {
+		batchLength := int(binary.BigEndian.Uint32(data[8:12]))
+		if len(data) < 12+batchLength {
+			break
+		}
+		batch := data[:12+batchLength]
+		data = data[12+batchLength:]
+
+		if magic := batch[16]; magic != recordBatchMagic {
+			return nil, fmt.Errorf("kafka: unsupported message format v%d", magic)
+		}
+		if len(batch) < recordBatchHeaderSize {
+			return nil, errShortBuffer
+		}
+		if crc32.Checksum(batch[recordBatchCRCStart:], crc32c) != binary.BigEndian.Uint32(batch[17:21]) {
+			return nil, errors.New("kafka: record batch CRC mismatch")
+		}
+
+		d := decoder{data: batch}
+		baseOffset := d.int64()
+		d.take(4 + 4 + 1 + 4)
+		attributes := d.int16()
+		d.int32()
+		firstTimestamp := d.int64()
+		d.take(8 + 8 + 2 + 4)
+		count := int(d.int32())
+
+		// Batch điều khiển của transaction không chứa dữ liệu
+		if attributes&controlBatchBit != 0 {
+			continue
+		}
+
+		records := d.data
+		switch attributes & compressionMask {
+		case compressionNone:
+		case compressionGzip:
+			reader, err := gzip.NewReader(bytes.NewReader(records))
+			if err != nil {
+				return nil, err
+			}
+			records, err = io.ReadAll(reader)
+			if err != nil {
+				return nil, err
+			}
+		default:
+			return nil, fmt.Errorf("kafka: unsupported compression codec %d", attributes&compressionMask)
+		}
+
+		rd := decoder{data: records}
+		for i := 0; i < count; i++ {
+			length := rd.varint()
+			record := decoder{data: rd.take(int(length))}
+			record.int8()
+			timestampDelta := record.varint()
+			offsetDelta := record.varint()
+			key := record.varintBytes()
+			value := record.varintBytes()
+			headerCount := int(record.varint())
+
+			var headers []Header
+			for h := 0; h < headerCount && record.err == nil; h++ {
+				headers = append(headers, Header{Key: string(record.varintBytes()), Value: record.varintBytes()})
+			}
+			if rd.err != nil || record.err != nil {
+				return nil, errShortBuffer
+			}
+
+			offset := baseOffset + offsetDelta
+			// Broker trả nguyên batch nên có thể chứa message trước offset cần đọc
+			if offset < fromOffset {
+				continue
+			}
+			messages = append(messages, Message{
+				Topic:     topic,
+				Partition: partition,
+				Offset:    offset,
+				Key:       key,
+				Value:     value,
+				Headers:   headers,
+				Time:      time.UnixMilli(firstTimestamp + timestampDelta),
+			})
+		}
+	}
+	return messages, nil
+}
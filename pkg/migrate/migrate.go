@@ -0,0 +1,241 @@
+package migrate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"time"
+)
+
+// ErrIrreversible trả về khi rollback một migration không có Down
+var ErrIrreversible = errors.New("migration has no down step")
+
+// ErrLockTimeout trả về khi chờ quá lâu mà instance khác vẫn đang giữ lock migrate
+var ErrLockTimeout = errors.New("timed out waiting for migration lock")
+
+// Migration là một bước thay đổi schema có version tăng dần, DB là handle của backend (*mongo.Database, *gorm.DB)
+type Migration[DB any] struct {
+	Version int64
+	Name    string
+	Up      func(ctx context.Context, db DB) error
+	Down    func(ctx context.Context, db DB) error
+}
+
+// Record là một migration đã được áp dụng, lưu trong collection/bảng schema_migrations
+type Record struct {
+	Version   int64
+	Name      string
+	AppliedAt time.Time
+}
+
+// Status là trạng thái của một migration đã khai báo
+type Status struct {
+	Version   int64
+	Name      string
+	Applied   bool
+	AppliedAt *time.Time
+}
+
+// Store lưu lịch sử migration và cung cấp lock để chỉ một instance migrate tại một thời điểm
+type Store interface {
+	// TryLock trả về false nếu instance khác đang giữ lock
+	TryLock(ctx context.Context, owner string) (bool, error)
+	Unlock(ctx context.Context, owner string) error
+	Applied(ctx context.Context) ([]Record, error)
+	Save(ctx context.Context, record Record) error
+	Remove(ctx context.Context, version int64) error
+}
+
+const (
+	DefaultLockTimeout = 2 * time.Minute
+	lockRetryInterval  = 2 * time.Second
+)
+
+type Runner[DB any] struct {
+	db          DB
+	store       Store
+	migrations  []Migration[DB]
+	owner       string
+	lockTimeout time.Duration
+}
+
+// NewRunner kiểm tra version không trùng và sắp xếp migration theo version
+func NewRunner[DB any](db DB, store Store, migrations []Migration[DB]) (*Runner[DB], error) {
+	sorted := append([]Migration[DB](nil), migrations...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Version < sorted[j].Version })
+
+	for i, m := range sorted {
+		if m.Version <= 0 {
+			return nil, fmt.Errorf("migration %q: version must be positive", m.Name)
+		}
+		if m.Up == nil {
+			return nil, fmt.Errorf("migration %d_%s: missing up step", m.Version, m.Name)
+		}
+		if i > 0 && sorted[i-1].Version == m.Version {
+			return nil, fmt.Errorf("duplicate migration version %d", m.Version)
+		}
+	}
+
+	return &Runner[DB]{
+		db:          db,
+		store:       store,
+		migrations:  sorted,
+		owner:       lockOwner(),
+		lockTimeout: DefaultLockTimeout,
+	}, nil
+}
+
+// SetLockTimeout đổi thời gian tối đa chờ instance khác migrate xong
+func (r *Runner[DB]) SetLockTimeout(timeout time.Duration) {
+	r.lockTimeout = timeout
+}
+
+// Up áp dụng các migration chưa chạy có version <= target, target <= 0 nghĩa là tới bản mới nhất.
+// Trả về số migration đã áp dụng.
+func (r *Runner[DB]) Up(ctx context.Context, target int64) (int, error) {
+	applied := 0
+	err := r.withLock(ctx, func() error {
+		done, err := r.appliedVersions(ctx)
+		if err != nil {
+			return err
+		}
+
+		for _, m := range r.migrations {
+			if target > 0 && m.Version > target {
+				break
+			}
+			if _, ok := done[m.Version]; ok {
+				continue
+			}
+
+			log.Printf("migrate: applying %d_%s", m.Version, m.Name)
+			if err := m.Up(ctx, r.db); err != nil {
+				return fmt.Errorf("migration %d_%s up: %w", m.Version, m.Name, err)
+			}
+			if err := r.store.Save(ctx, Record{Version: m.Version, Name: m.Name, AppliedAt: time.Now()}); err != nil {
+				return fmt.Errorf("record migration %d_%s: %w", m.Version, m.Name, err)
+			}
+			applied++
+		}
+		return nil
+	})
+	return applied, err
+}
+
+// Down rollback steps migration đã áp dụng gần nhất. Trả về số migration đã rollback.
+func (r *Runner[DB]) Down(ctx context.Context, steps int) (int, error) {
+	rolledBack := 0
+	err := r.withLock(ctx, func() error {
+		done, err := r.appliedVersions(ctx)
+		if err != nil {
+			return err
+		}
+
+		for i := len(r.migrations) - 1; i >= 0 && rolledBack < steps; i-- {
+			m := r.migrations[i]
+			if _, ok := done[m.Version]; !ok {
+				continue
+			}
+			if m.Down == nil {
+				return fmt.Errorf("migration %d_%s: %w", m.Version, m.Name, ErrIrreversible)
+			}
+
+			log.Printf("migrate: rolling back %d_%s", m.Version, m.Name)
+			if err := m.Down(ctx, r.db); err != nil {
+				return fmt.Errorf("migration %d_%s down: %w", m.Version, m.Name, err)
+			}
+			if err := r.store.Remove(ctx, m.Version); err != nil {
+				return fmt.Errorf("unrecord migration %d_%s: %w", m.Version, m.Name, err)
+			}
+			rolledBack++
+		}
+		return nil
+	})
+	return rolledBack, err
+}
+
+// Status liệt kê mọi migration đã khai báo cùng thời điểm áp dụng
+func (r *Runner[DB]) Status(ctx context.Context) ([]Status, error) {
+	done, err := r.appliedVersions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]Status, 0, len(r.migrations))
+	for _, m := range r.migrations {
+		status := Status{Version: m.Version, Name: m.Name}
+		if record, ok := done[m.Version]; ok {
+			appliedAt := record.AppliedAt
+			status.Applied = true
+			status.AppliedAt = &appliedAt
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+func (r *Runner[DB]) appliedVersions(ctx context.Context) (map[int64]Record, error) {
+	records, err := r.store.Applied(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("load applied migrations: %w", err)
+	}
+
+	done := make(map[int64]Record, len(records))
+	for _, record := range records {
+		done[record.Version] = record
+	}
+	return done, nil
+}
+
+// withLock chờ lấy lock rồi chạy fn, instance khác đang migrate thì thử lại tới khi hết lockTimeout
+func (r *Runner[DB]) withLock(ctx context.Context, fn func() error) error {
+	deadline := time.Now().Add(r.lockTimeout)
+	for {
+		ok, err := r.store.TryLock(ctx, r.owner)
+		if err != nil {
+			return fmt.Errorf("acquire migration lock: %w", err)
+		}
+		if ok {
+			break
+		}
+		if time.Now().After(deadline) {
+			return ErrLockTimeout
+		}
+
+		log.Printf("migrate: another instance holds the lock, waiting")
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(lockRetryInterval):
+		}
+	}
+
+	defer func() {
+		// Dùng context riêng để vẫn nhả lock khi ctx đã bị huỷ
+		unlockCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		if err := r.store.Unlock(unlockCtx, r.owner); err != nil {
+			log.Printf("migrate: failed to release lock: %v", err)
+		}
+	}()
+	return fn()
+}
+
+// lockOwner định danh instance giữ lock, chỉ chủ lock mới nhả được lock
+func lockOwner() string {
+	host, err := os.Hostname()
+	if err != nil {
+		host = "unknown"
+	}
+	return fmt.Sprintf("%s-%d-%d", host, os.Getpid(), time.Now().UnixNano())
+}
+
+// Migrator là phần không phụ thuộc backend của Runner, dùng cho lệnh migrate
+type Migrator interface {
+	Up(ctx context.Context, target int64) (int, error)
+	Down(ctx context.Context, steps int) (int, error)
+	Status(ctx context.Context) ([]Status, error)
+}
@@ -0,0 +1,151 @@
+package migrate
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	MongoMigrationsCollection = "schema_migrations"
+	mongoLockCollection       = "schema_migrations_lock"
+	mongoLockID               = "migrate"
+	// Lock hết hạn sau mongoLockTTL nếu instance giữ lock chết giữa chừng, được gia hạn định kỳ khi còn chạy
+	mongoLockTTL = time.Minute
+)
+
+type mongoRecord struct {
+	Version   int64     `bson:"_id"`
+	Name      string    `bson:"name"`
+	AppliedAt time.Time `bson:"applied_at"`
+}
+
+type mongoStore struct {
+	migrations *mongo.Collection
+	lock       *mongo.Collection
+
+	mu         sync.Mutex
+	stopRenew  chan struct{}
+	renewGroup sync.WaitGroup
+}
+
+// NewMongoStore lưu lịch sử migration trong collection schema_migrations
+func NewMongoStore(db *mongo.Database) Store {
+	return &mongoStore{
+		migrations: db.Collection(MongoMigrationsCollection),
+		lock:       db.Collection(mongoLockCollection),
+	}
+}
+
+// TryLock upsert document lock khi chưa có hoặc đã hết hạn. Instance khác đang giữ lock
+// thì upsert đụng _id đã tồn tại và trả về duplicate key.
+func (s *mongoStore) TryLock(ctx context.Context, owner string) (bool, error) {
+	now := time.Now()
+	filter := bson.M{
+		"_id": mongoLockID,
+		"$or": bson.A{
+			bson.M{"expires_at": bson.M{"$lt": now}},
+			bson.M{"owner": owner},
+		},
+	}
+	update := bson.M{"$set": bson.M{
+		"owner":      owner,
+		"locked_at":  now,
+		"expires_at": now.Add(mongoLockTTL),
+	}}
+
+	_, err := s.lock.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true))
+	if mongo.IsDuplicateKeyError(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	s.startRenew(owner)
+	return true, nil
+}
+
+func (s *mongoStore) Unlock(ctx context.Context, owner string) error {
+	s.stopRenewing()
+	_, err := s.lock.DeleteOne(ctx, bson.M{"_id": mongoLockID, "owner": owner})
+	return err
+}
+
+func (s *mongoStore) Applied(ctx context.Context) ([]Record, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "_id", Value: 1}})
+	cursor, err := s.migrations.Find(ctx, bson.M{}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var docs []mongoRecord
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, err
+	}
+
+	records := make([]Record, 0, len(docs))
+	for _, doc := range docs {
+		records = append(records, Record(doc))
+	}
+	return records, nil
+}
+
+func (s *mongoStore) Save(ctx context.Context, record Record) error {
+	_, err := s.migrations.InsertOne(ctx, mongoRecord(record))
+	return err
+}
+
+func (s *mongoStore) Remove(ctx context.Context, version int64) error {
+	_, err := s.migrations.DeleteOne(ctx, bson.M{"_id": version})
+	return err
+}
+
+// startRenew gia hạn lock trong lúc migration chạy, ví dụ khi build index trên collection lớn
+func (s *mongoStore) startRenew(owner string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.stopRenew != nil {
+		return
+	}
+
+	stop := make(chan struct{})
+	s.stopRenew = stop
+	s.renewGroup.Add(1)
+	go func() {
+		defer s.renewGroup.Done()
+		ticker := time.NewTicker(mongoLockTTL / 3)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+				_, _ = s.lock.UpdateOne(ctx,
+					bson.M{"_id": mongoLockID, "owner": owner},
+					bson.M{"$set": bson.M{"expires_at": time.Now().Add(mongoLockTTL)}},
+				)
+				cancel()
+			}
+		}
+	}()
+}
+
+func (s *mongoStore) stopRenewing() {
+	s.mu.Lock()
+	stop := s.stopRenew
+	s.stopRenew = nil
+	s.mu.Unlock()
+
+	if stop != nil {
+		close(stop)
+		s.renewGroup.Wait()
+	}
+}
@@ -0,0 +1,117 @@
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	MySQLMigrationsTable = "schema_migrations"
+	mysqlLockName        = "topic_service_migrate"
+)
+
+type mysqlStore struct {
+	db *gorm.DB
+
+	mu sync.Mutex
+	// conn giữ kết nối đã lấy GET_LOCK, lock của MySQL gắn với session nên phải nhả trên cùng kết nối
+	conn *sql.Conn
+}
+
+// NewMySQLStore lưu lịch sử migration trong bảng schema_migrations và dùng GET_LOCK để khoá
+func NewMySQLStore(db *gorm.DB) Store {
+	return &mysqlStore{db: db}
+}
+
+func (s *mysqlStore) TryLock(ctx context.Context, owner string) (bool, error) {
+	if err := s.ensureTable(ctx); err != nil {
+		return false, err
+	}
+
+	sqlDB, err := s.db.DB()
+	if err != nil {
+		return false, err
+	}
+	conn, err := sqlDB.Conn(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	var acquired sql.NullInt64
+	if err := conn.QueryRowContext(ctx, "SELECT GET_LOCK(?, 0)", mysqlLockName).Scan(&acquired); err != nil {
+		conn.Close()
+		return false, err
+	}
+	if !acquired.Valid || acquired.Int64 != 1 {
+		conn.Close()
+		return false, nil
+	}
+
+	s.mu.Lock()
+	s.conn = conn
+	s.mu.Unlock()
+	return true, nil
+}
+
+func (s *mysqlStore) Unlock(ctx context.Context, owner string) error {
+	s.mu.Lock()
+	conn := s.conn
+	s.conn = nil
+	s.mu.Unlock()
+
+	if conn == nil {
+		return nil
+	}
+	defer conn.Close()
+	_, err := conn.ExecContext(ctx, "SELECT RELEASE_LOCK(?)", mysqlLockName)
+	return err
+}
+
+func (s *mysqlStore) Applied(ctx context.Context) ([]Record, error) {
+	if err := s.ensureTable(ctx); err != nil {
+		return nil, err
+	}
+
+	rows, err := s.db.WithContext(ctx).
+		Raw("SELECT version, name, applied_at FROM " + MySQLMigrationsTable + " ORDER BY version").
+		Rows()
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var records []Record
+	for rows.Next() {
+		var record Record
+		if err := rows.Scan(&record.Version, &record.Name, &record.AppliedAt); err != nil {
+			return nil, err
+		}
+		records = append(records, record)
+	}
+	return records, rows.Err()
+}
+
+func (s *mysqlStore) Save(ctx context.Context, record Record) error {
+	return s.db.WithContext(ctx).
+		Exec("INSERT INTO "+MySQLMigrationsTable+" (version, name, applied_at) VALUES (?, ?, ?)",
+			record.Version, record.Name, record.AppliedAt.UTC().Truncate(time.Second)).
+		Error
+}
+
+func (s *mysqlStore) Remove(ctx context.Context, version int64) error {
+	return s.db.WithContext(ctx).
+		Exec("DELETE FROM "+MySQLMigrationsTable+" WHERE version = ?", version).
+		Error
+}
+
+func (s *mysqlStore) ensureTable(ctx context.Context) error {
+	return s.db.WithContext(ctx).Exec(`CREATE TABLE IF NOT EXISTS ` + MySQLMigrationsTable + ` (
+		version BIGINT NOT NULL PRIMARY KEY,
+		name VARCHAR(255) NOT NULL,
+		applied_at DATETIME NOT NULL
+	)`).Error
+}
@@ -0,0 +1,451 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3gen"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/getkin/kin-openapi/routers/gorillamux"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const bearerAuth = "bearerAuth"
+
+// Operation mô tả một route trong spec bằng chính các DTO mà handler bind và trả về
+type Operation struct {
+	Summary string
+	// Body là DTO của request body, nil nếu route không đọc body
+	Body any
+	// OptionalBody: handler chỉ bind body khi client gửi lên
+	OptionalBody bool
+	// Query là DTO được bind bằng ShouldBindQuery, các field lấy tên từ tag form
+	Query any
+	// Response là kiểu của field data trong SucceedResponse, nil thì data là null
+	Response any
+	// Status là mã HTTP khi thành công, mặc định 200
+	Status int
+	// ContentType của response thành công, mặc định application/json
+	ContentType string
+	// Public: route không cần bearer token, ví dụ webhook xác thực bằng chữ ký
+	Public bool
+}
+
+// Operations được đánh key theo "METHOD /path" đúng như khi đăng ký route với gin
+type Operations map[string]Operation
+
+// Envelope là các response bọc ngoài mọi API: data của route được ghép vào Succeed
+type Envelope struct {
+	Succeed any
+	Failed  any
+}
+
+// Spec là tài liệu OpenAPI 3 sinh từ route của gin, dùng cho /openapi.json và để kiểm tra request
+type Spec struct {
+	title    string
+	version  string
+	envelope Envelope
+
+	mu     sync.RWMutex
+	json   []byte
+	router routers.Router
+}
+
+func New(title, version string, envelope Envelope) *Spec {
+	return &Spec{title: title, version: version, envelope: envelope}
+}
+
+// Build sinh spec cho mọi route đã đăng ký. Route không có trong ops là lỗi
+// để spec không âm thầm thiếu route.
+func (s *Spec) Build(routes gin.RoutesInfo, ops Operations) error {
+	b := newBuilder()
+	doc := &openapi3.T{
+		OpenAPI: "3.0.3",
+		Info:    &openapi3.Info{Title: s.title, Version: s.version},
+		Paths:   openapi3.NewPaths(),
+		Components: &openapi3.Components{
+			Schemas: b.schemas,
+			SecuritySchemes: openapi3.SecuritySchemes{
+				bearerAuth: &openapi3.SecuritySchemeRef{Value: openapi3.NewJWTSecurityScheme()},
+			},
+		},
+	}
+
+	succeedRef, err := b.schemaRef(s.envelope.Succeed)
+	if err != nil {
+		return err
+	}
+	failedRef, err := b.schemaRef(s.envelope.Failed)
+	if err != nil {
+		return err
+	}
+
+	var missing []string
+	usedIDs := map[string]bool{}
+	for _, route := range routes {
+		key := route.Method + " " + route.Path
+		op, ok := ops[key]
+		if !ok {
+			missing = append(missing, key)
+			continue
+		}
+
+		operation, err := b.operation(route, op, succeedRef, failedRef)
+		if err != nil {
+			return fmt.Errorf("%s: %w", key, err)
+		}
+		if usedIDs[operation.OperationID] {
+			operation.OperationID = lowerFirst(operation.Tags[0]) + upperFirst(operation.OperationID)
+		}
+		usedIDs[operation.OperationID] = true
+
+		path, _ := convertPath(route.Path)
+		item := doc.Paths.Value(path)
+		if item == nil {
+			item = &openapi3.PathItem{}
+			doc.Paths.Set(path, item)
+		}
+		item.SetOperation(route.Method, operation)
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("routes without OpenAPI operation: %s", strings.Join(missing, ", "))
+	}
+
+	// openapi3gen để trống Value của các $ref, nạp lại từ JSON để loader resolve trước khi kiểm tra
+	raw, err := json.Marshal(doc)
+	if err != nil {
+		return err
+	}
+	loader := openapi3.NewLoader()
+	loaded, err := loader.LoadFromData(raw)
+	if err != nil {
+		return err
+	}
+	if err := loaded.Validate(loader.Context); err != nil {
+		return fmt.Errorf("invalid OpenAPI document: %w", err)
+	}
+	router, err := gorillamux.NewRouter(loaded)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	s.json, s.router = raw, router
+	s.mu.Unlock()
+	return nil
+}
+
+// ServeJSON trả về spec cho GET /openapi.json
+func (s *Spec) ServeJSON(c *gin.Context) {
+	s.mu.RLock()
+	raw := s.json
+	s.mu.RUnlock()
+
+	if raw == nil {
+		c.Status(http.StatusServiceUnavailable)
+		return
+	}
+	c.Data(http.StatusOK, "application/json; charset=utf-8", raw)
+}
+
+// builder sinh schema từ DTO, component dùng chung được đặt tên theo tên kiểu Go
+type builder struct {
+	gen     *openapi3gen.Generator
+	schemas openapi3.Schemas
+}
+
+func newBuilder() *builder {
+	return &builder{
+		gen: openapi3gen.NewGenerator(
+			openapi3gen.UseAllExportedFields(),
+			openapi3gen.CreateComponentSchemas(openapi3gen.ExportComponentSchemasOptions{
+				ExportComponentSchemas: true,
+				ExportTopLevelSchema:   true,
+			}),
+			openapi3gen.SchemaCustomizer(customizeSchema),
+		),
+		schemas: openapi3.Schemas{},
+	}
+}
+
+func (b *builder) schemaRef(value any) (*openapi3.SchemaRef, error) {
+	return b.gen.NewSchemaRefForValue(value, b.schemas)
+}
+
+func (b *builder) operation(route gin.RouteInfo, op Operation, succeedRef, failedRef *openapi3.SchemaRef) (*openapi3.Operation, error) {
+	receiver, method := handlerName(route.Handler)
+	operation := &openapi3.Operation{
+		OperationID: lowerFirst(method),
+		Summary:     op.Summary,
+		Tags:        []string{receiver},
+		Responses:   openapi3.NewResponses(),
+	}
+
+	_, pathParams := convertPath(route.Path)
+	for _, name := range pathParams {
+		operation.AddParameter(openapi3.NewPathParameter(name).WithSchema(openapi3.NewStringSchema()))
+	}
+
+	if op.Query != nil {
+		params, err := b.queryParameters(op.Query)
+		if err != nil {
+			return nil, err
+		}
+		for _, param := range params {
+			operation.AddParameter(param)
+		}
+	}
+
+	if op.Body != nil {
+		ref, err := b.schemaRef(op.Body)
+		if err != nil {
+			return nil, err
+		}
+		body := openapi3.NewRequestBody().WithJSONSchemaRef(ref).WithRequired(!op.OptionalBody)
+		operation.RequestBody = &openapi3.RequestBodyRef{Value: body}
+	}
+
+	status := op.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	success := openapi3.NewResponse().WithDescription(http.StatusText(status))
+	if op.ContentType != "" {
+		success.WithContent(openapi3.NewContentWithSchema(openapi3.NewStringSchema(), []string{op.ContentType}))
+	} else {
+		dataRef := openapi3.NewSchemaRef("", &openapi3.Schema{Nullable: true})
+		if op.Response != nil {
+			ref, err := b.schemaRef(op.Response)
+			if err != nil {
+				return nil, err
+			}
+			dataRef = ref
+		}
+		success.WithJSONSchema(&openapi3.Schema{AllOf: openapi3.SchemaRefs{
+			succeedRef,
+			openapi3.NewSchemaRef("", openapi3.NewObjectSchema().WithPropertyRef("data", dataRef)),
+		}})
+	}
+	operation.AddResponse(status, success)
+	operation.Responses.Set("default", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().WithDescription("Error").WithJSONSchemaRef(failedRef),
+	})
+
+	if op.Public {
+		operation.Security = openapi3.NewSecurityRequirements()
+	} else {
+		operation.Security = openapi3.NewSecurityRequirements().With(openapi3.NewSecurityRequirement().Authenticate(bearerAuth))
+	}
+	return operation, nil
+}
+
+// queryParameters đọc tag form của DTO query, ràng buộc lấy từ tag binding như với body
+func (b *builder) queryParameters(query any) ([]*openapi3.Parameter, error) {
+	t := reflect.TypeOf(query)
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	var params []*openapi3.Parameter
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("form"), ",")
+		if name == "" || name == "-" {
+			continue
+		}
+
+		schemaRef, err := b.schemaRef(reflect.New(field.Type).Elem().Interface())
+		if err != nil {
+			return nil, err
+		}
+		schema := *schemaRef.Value
+		applyBinding(&schema, field.Type, field.Type.Kind() == reflect.Pointer, field.Tag.Get("binding"))
+		schema.Nullable = false
+
+		param := openapi3.NewQueryParameter(name).WithSchema(&schema)
+		param.Required = hasRule(field.Tag.Get("binding"), "required")
+		params = append(params, param)
+	}
+	return params, nil
+}
+
+var objectIDType = reflect.TypeOf(primitive.ObjectID{})
+
+// customizeSchema chuyển tag binding của validator thành ràng buộc OpenAPI
+func customizeSchema(_ string, t reflect.Type, tag reflect.StructTag, schema *openapi3.Schema) error {
+	if t == objectIDType {
+		*schema = *openapi3.NewStringSchema()
+		return nil
+	}
+
+	base := t
+	for base.Kind() == reflect.Pointer {
+		base = base.Elem()
+	}
+	if base.Kind() == reflect.Struct && base != objectIDType {
+		for i := 0; i < base.NumField(); i++ {
+			field := base.Field(i)
+			name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+			if name == "" || name == "-" || !field.IsExported() {
+				continue
+			}
+			if hasRule(field.Tag.Get("binding"), "required") && !slices.Contains(schema.Required, name) {
+				schema.Required = append(schema.Required, name)
+			}
+		}
+	}
+
+	// openapi3gen bỏ con trỏ khỏi t trước khi gọi customizer, field con trỏ chỉ còn dấu hiệu Nullable
+	applyBinding(schema, t, schema.Nullable, tag.Get("binding"))
+	return nil
+}
+
+// applyBinding áp các rule oneof/min/max/url lên schema, rule sau "dive" áp cho phần tử của mảng.
+// min của field omitempty không phải con trỏ bị bỏ qua vì validator không kiểm tra giá trị rỗng.
+func applyBinding(schema *openapi3.Schema, t reflect.Type, pointer bool, binding string) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if pointer || t.Kind() == reflect.Slice || t.Kind() == reflect.Map {
+		schema.Nullable = true
+	}
+	if binding == "" {
+		return
+	}
+
+	rules := strings.Split(binding, ",")
+	if i := slices.Index(rules, "dive"); i >= 0 {
+		if items := itemsSchema(schema); items != nil {
+			elem := t.Elem()
+			applyBinding(items, elem, elem.Kind() == reflect.Pointer, strings.Join(rules[i+1:], ","))
+		}
+		rules = rules[:i]
+	}
+
+	omitEmpty := slices.Contains(rules, "omitempty") && !pointer
+	for _, rule := range rules {
+		key, value, _ := strings.Cut(rule, "=")
+		switch key {
+		case "oneof":
+			for _, v := range strings.Fields(value) {
+				schema.Enum = append(schema.Enum, v)
+			}
+		case "min":
+			if omitEmpty && value != "0" {
+				continue
+			}
+			setLimit(schema, value, true)
+		case "max":
+			setLimit(schema, value, false)
+		case "url", "http_url":
+			schema.Format = "uri"
+		}
+	}
+}
+
+func itemsSchema(schema *openapi3.Schema) *openapi3.Schema {
+	// Ref của kiểu cơ bản chỉ là tên kiểu, ref tới component thì không sửa được ở đây
+	if schema.Items == nil || schema.Items.Value == nil || strings.HasPrefix(schema.Items.Ref, "#/") {
+		return nil
+	}
+	return schema.Items.Value
+}
+
+func setLimit(schema *openapi3.Schema, value string, isMin bool) {
+	var n float64
+	if _, err := fmt.Sscan(value, &n); err != nil {
+		return
+	}
+	count := uint64(n)
+
+	switch schema.Type.Slice()[0] {
+	case openapi3.TypeString:
+		if isMin {
+			schema.MinLength = count
+		} else {
+			schema.MaxLength = &count
+		}
+	case openapi3.TypeArray:
+		if isMin {
+			schema.MinItems = count
+		} else {
+			schema.MaxItems = &count
+		}
+	case openapi3.TypeInteger, openapi3.TypeNumber:
+		if isMin {
+			schema.Min = &n
+		} else {
+			schema.Max = &n
+		}
+	}
+}
+
+func hasRule(binding, rule string) bool {
+	for _, r := range strings.Split(binding, ",") {
+		if r == "dive" {
+			return false
+		}
+		if r == rule {
+			return true
+		}
+	}
+	return false
+}
+
+var pathParamPattern = regexp.MustCompile(`[:*]([A-Za-z0-9_]+)`)
+
+// convertPath đổi "/topic/:id" của gin thành "/topic/{id}" và trả về tên các tham số
+func convertPath(path string) (string, []string) {
+	var names []string
+	converted := pathParamPattern.ReplaceAllStringFunc(path, func(m string) string {
+		names = append(names, m[1:])
+		return "{" + m[1:] + "}"
+	})
+	return converted, names
+}
+
+// handlerName tách "topic-service/internal/topic/handler.(*TopicItemHandler).CreateItem-fm"
+// thành tag "TopicItem" và tên method "CreateItem"
+func handlerName(name string) (string, string) {
+	name = strings.TrimSuffix(name, "-fm")
+	receiver, method := "", name
+	if i := strings.LastIndex(name, "."); i >= 0 {
+		receiver, method = name[:i], name[i+1:]
+	}
+	if i := strings.LastIndex(receiver, "."); i >= 0 {
+		receiver = receiver[i+1:]
+	}
+	receiver = strings.Trim(receiver, "(*)")
+	receiver = strings.TrimSuffix(receiver, "Handler")
+	if receiver == "" {
+		receiver = "default"
+	}
+	return receiver, method
+}
+
+func lowerFirst(s string) string {
+	if s == "" {
+		return s
+	}
+	r := []rune(s)
+	r[0] = unicode.ToLower(r[0])
+	return string(r)
+}
+
+func upperFirst(s string) string {
+	if s == "" {
+		return s
+	}
+	r := []rune(s)
+	r[0] = unicode.ToUpper(r[0])
+	return string(r)
+}
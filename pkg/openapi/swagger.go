@@ -0,0 +1,42 @@
+package openapi
+
+import (
+	"embed"
+	"io/fs"
+	"net/http"
+	"path"
+
+	"github.com/gin-gonic/gin"
+)
+
+// static chứa trang Swagger UI và swagger-ui-dist được vendor bằng scripts/vendor-swagger-ui.sh,
+// version ghim trong static/swagger-ui/VERSION
+//
+//go:embed static
+var static embed.FS
+
+var (
+	swaggerUI, _  = static.ReadFile("static/swagger.html")
+	uiAssets, _   = fs.Sub(static, "static/swagger-ui")
+	uiVendored, _ = fs.Stat(uiAssets, "swagger-ui-bundle.js")
+)
+
+// UIVendored cho biết swagger-ui-dist đã được embed vào binary hay chưa
+func UIVendored() bool {
+	return uiVendored != nil
+}
+
+// ServeUI trả về trang Swagger UI cho GET /docs. Chưa vendor swagger-ui-dist thì trả 503,
+// spec vẫn đọc được ở /openapi.json.
+func ServeUI(c *gin.Context) {
+	if uiVendored == nil {
+		c.String(http.StatusServiceUnavailable, "Swagger UI assets are not vendored, run scripts/vendor-swagger-ui.sh. The spec is at /openapi.json.")
+		return
+	}
+	c.Data(http.StatusOK, "text/html; charset=utf-8", swaggerUI)
+}
+
+// ServeUIAssets trả về file của swagger-ui-dist cho GET /docs/assets/*filepath
+func ServeUIAssets(c *gin.Context) {
+	c.FileFromFS(path.Clean("/"+c.Param("filepath")), http.FS(uiAssets))
+}
@@ -0,0 +1,105 @@
+package router
+
+import (
+	"net/http"
+	"topic-service/internal/topic/dto/request"
+	"topic-service/internal/topic/dto/response"
+	"topic-service/pkg/i18n"
+	"topic-service/pkg/openapi"
+
+	"github.com/gin-gonic/gin"
+)
+
+const apiVersion = "1.0.0"
+
+// newAPISpec tạo spec rỗng, nội dung được Build sau khi mọi route đã đăng ký
+func newAPISpec() *openapi.Spec {
+	return openapi.New("topic-service API", apiVersion, openapi.Envelope{
+		Succeed: response.SucceedResponse{},
+		Failed:  response.FailedResponse{},
+	})
+}
+
+// respondInvalidRequest là lỗi của middleware kiểm tra request theo spec
+func respondInvalidRequest(c *gin.Context, err error) {
+	c.AbortWithStatusJSON(http.StatusBadRequest, response.FailedResponse{
+		Code:    http.StatusBadRequest,
+		Message: i18n.T(c, "request.invalid"),
+		Error:   err.Error(),
+	})
+}
+
+// apiOperations mô tả từng route bằng DTO mà handler bind và trả về. Thêm route mới vào SetupRouter
+// mà thiếu ở đây thì Build báo lỗi.
+var apiOperations = openapi.Operations{
+	"POST /api/v1/topic":             {Summary: "Create a topic", Body: request.CreateTopicRequest{}, Response: response.TopicResponse{}, Status: http.StatusCreated},
+	"GET /api/v1/topic/:id":          {Summary: "Get a topic, following merge redirects", Response: response.TopicResponse{}},
+	"PUT /api/v1/topic/:id":          {Summary: "Update the title in the default locale", Body: request.UpdateTopicRequest{}},
+	"DELETE /api/v1/topic/:id":       {Summary: "Delete a topic"},
+	"GET /api/v1/topic":              {Summary: "List topics", Query: request.ListTopicsQuery{}, Response: []response.TopicResponse{}},
+	"GET /api/v1/topic/templates":    {Summary: "List template topics", Query: request.ListTopicsQuery{}, Response: []response.TopicResponse{}},
+	"GET /api/v1/topic/search":       {Summary: "Search topics by title", Query: request.SearchTopicsQuery{}, Response: []response.TopicResponse{}},
+	"GET /api/v1/topic/stream":       {Summary: "Stream topic changes as Server-Sent Events", Query: request.StreamTopicsQuery{}, ContentType: "text/event-stream"},
+	"GET /api/v1/topic/changes":      {Summary: "Read topic changes since a sync token", Query: request.TopicChangesQuery{}, Response: response.TopicChangesResponse{}},
+	"POST /api/v1/topic/:id/clone":   {Summary: "Clone a topic", Body: request.CloneTopicRequest{}, OptionalBody: true, Response: response.TopicResponse{}, Status: http.StatusCreated},
+	"PUT /api/v1/topic/:id/template": {Summary: "Mark or unmark a topic as template (admin)", Body: request.SetTemplateRequest{}, Response: response.TopicResponse{}},
+	"POST /api/v1/topic/merge":       {Summary: "Merge two topics (admin)", Body: request.MergeTopicsRequest{}, Response: response.TopicResponse{}},
+	"GET /api/v1/topic/:id/audit":    {Summary: "Get the audit trail of a topic (admin)", Response: []response.TopicAuditLogResponse{}},
+
+	"GET /api/v1/topic/analytics/most-viewed": {Summary: "Most viewed topics", Query: request.TopicAnalyticsQuery{}, Response: []response.TopicPopularityResponse{}},
+	"GET /api/v1/topic/analytics/trending":    {Summary: "Trending topics", Query: request.TopicAnalyticsQuery{}, Response: []response.TopicTrendResponse{}},
+	"GET /api/v1/topic/analytics/never-used":  {Summary: "Topics never viewed", Query: request.TopicAnalyticsQuery{}, Response: []response.TopicResponse{}},
+
+	"GET /api/v1/topic/favorites":       {Summary: "List favorite topics", Response: []response.TopicResponse{}},
+	"GET /api/v1/topic/recent":          {Summary: "List recently viewed topics", Response: []response.TopicResponse{}},
+	"POST /api/v1/topic/:id/favorite":   {Summary: "Add a topic to favorites"},
+	"DELETE /api/v1/topic/:id/favorite": {Summary: "Remove a topic from favorites"},
+
+	"GET /api/v1/topic/:id/comments":                        {Summary: "List comments", Query: request.ListCommentsQuery{}, Response: response.CommentPageResponse{}},
+	"POST /api/v1/topic/:id/comments":                       {Summary: "Create a comment", Body: request.CreateCommentRequest{}, Response: response.CommentResponse{}, Status: http.StatusCreated},
+	"PUT /api/v1/topic/:id/comments/:comment_id":            {Summary: "Edit a comment", Body: request.UpdateCommentRequest{}, Response: response.CommentResponse{}},
+	"DELETE /api/v1/topic/:id/comments/:comment_id":         {Summary: "Delete a comment"},
+	"PUT /api/v1/topic/:id/comments/:comment_id/moderation": {Summary: "Hide or show a comment (admin)", Body: request.ModerateCommentRequest{}, Response: response.CommentResponse{}},
+
+	"GET /api/v1/topic/:id/rating":    {Summary: "Get the current user's rating", Response: response.RatingResponse{}},
+	"PUT /api/v1/topic/:id/rating":    {Summary: "Rate a topic", Body: request.RateTopicRequest{}, Response: response.RatingResponse{}},
+	"DELETE /api/v1/topic/:id/rating": {Summary: "Delete the current user's rating"},
+	"GET /api/v1/topic/:id/ratings":   {Summary: "List ratings", Query: request.ListRatingsQuery{}, Response: response.RatingPageResponse{}},
+
+	"GET /api/v1/topic/:id/related": {Summary: "List related topics", Query: request.RelatedTopicsQuery{}, Response: []response.RelatedTopicResponse{}},
+
+	"GET /api/v1/topic/:id/translations":            {Summary: "List translations", Response: response.TopicTranslationsResponse{}},
+	"PUT /api/v1/topic/:id/translations/:locale":    {Summary: "Create or update a translation", Body: request.UpsertTranslationRequest{}, Response: response.TopicTranslationsResponse{}},
+	"DELETE /api/v1/topic/:id/translations/:locale": {Summary: "Delete a translation", Response: response.TopicTranslationsResponse{}},
+
+	"GET /api/v1/topic/:id/items":             {Summary: "List items", Response: []response.TopicItemResponse{}},
+	"POST /api/v1/topic/:id/items":            {Summary: "Create an item", Body: request.CreateTopicItemRequest{}, Response: response.TopicItemResponse{}, Status: http.StatusCreated},
+	"PUT /api/v1/topic/:id/items/order":       {Summary: "Reorder items", Body: request.ReorderTopicItemsRequest{}, Response: []response.TopicItemResponse{}},
+	"GET /api/v1/topic/:id/items/:item_id":    {Summary: "Get an item", Response: response.TopicItemResponse{}},
+	"PUT /api/v1/topic/:id/items/:item_id":    {Summary: "Update an item", Body: request.UpdateTopicItemRequest{}},
+	"DELETE /api/v1/topic/:id/items/:item_id": {Summary: "Delete an item"},
+
+	"POST /api/v1/topic/learning-path":                        {Summary: "Order topics by prerequisites", Body: request.LearningPathRequest{}, Response: []response.TopicResponse{}},
+	"GET /api/v1/topic/:id/prerequisites":                     {Summary: "List prerequisites", Response: []response.TopicResponse{}},
+	"POST /api/v1/topic/:id/prerequisites":                    {Summary: "Add a prerequisite", Body: request.AddPrerequisiteRequest{}, Response: response.TopicResponse{}},
+	"DELETE /api/v1/topic/:id/prerequisites/:prerequisite_id": {Summary: "Remove a prerequisite"},
+
+	"POST /api/v1/curriculum":             {Summary: "Create a curriculum", Body: request.CreateCurriculumRequest{}, Response: response.CurriculumResponse{}, Status: http.StatusCreated},
+	"GET /api/v1/curriculum":              {Summary: "List curricula", Response: []response.CurriculumResponse{}},
+	"GET /api/v1/curriculum/:id":          {Summary: "Get a curriculum", Response: response.CurriculumResponse{}},
+	"PUT /api/v1/curriculum/:id":          {Summary: "Update a curriculum", Body: request.UpdateCurriculumRequest{}, Response: response.CurriculumResponse{}},
+	"DELETE /api/v1/curriculum/:id":       {Summary: "Delete a curriculum"},
+	"PUT /api/v1/curriculum/:id/order":    {Summary: "Reorder curriculum topics", Body: request.ReorderCurriculumRequest{}, Response: response.CurriculumResponse{}},
+	"POST /api/v1/curriculum/:id/clone":   {Summary: "Clone a curriculum", Body: request.CloneCurriculumRequest{}, OptionalBody: true, Response: response.CurriculumResponse{}, Status: http.StatusCreated},
+	"POST /api/v1/curriculum/:id/publish": {Summary: "Publish a curriculum", Response: response.CurriculumResponse{}},
+
+	"POST /api/v1/webhooks/user-events": {Summary: "Receive user lifecycle events, signed with X-Signature over X-Signature-Timestamp and the body", Body: request.UserEventRequest{}, Response: response.UserEventResultResponse{}, Public: true},
+
+	"POST /api/v1/webhooks/subscriptions":                                    {Summary: "Create a webhook subscription (admin)", Body: request.CreateWebhookSubscriptionRequest{}, Response: response.WebhookSubscriptionResponse{}, Status: http.StatusCreated},
+	"GET /api/v1/webhooks/subscriptions":                                     {Summary: "List webhook subscriptions (admin)", Response: []response.WebhookSubscriptionResponse{}},
+	"GET /api/v1/webhooks/subscriptions/:id":                                 {Summary: "Get a webhook subscription (admin)", Response: response.WebhookSubscriptionResponse{}},
+	"PUT /api/v1/webhooks/subscriptions/:id":                                 {Summary: "Update a webhook subscription (admin)", Body: request.UpdateWebhookSubscriptionRequest{}, Response: response.WebhookSubscriptionResponse{}},
+	"DELETE /api/v1/webhooks/subscriptions/:id":                              {Summary: "Delete a webhook subscription (admin)"},
+	"GET /api/v1/webhooks/subscriptions/:id/deliveries":                      {Summary: "List webhook deliveries (admin)", Query: request.ListWebhookDeliveriesQuery{}, Response: response.WebhookDeliveryPageResponse{}},
+	"POST /api/v1/webhooks/subscriptions/:id/deliveries/:delivery_id/replay": {Summary: "Replay a webhook delivery (admin)", Response: response.WebhookDeliveryResponse{}, Status: http.StatusAccepted},
+}
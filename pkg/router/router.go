@@ -1,42 +1,227 @@
 package router
 
 import (
+	"log"
+	"time"
 	"topic-service/internal/gateway"
 	"topic-service/internal/topic/handler"
 	"topic-service/internal/topic/middleware"
 	"topic-service/internal/topic/repository"
 	"topic-service/internal/topic/service"
+	"topic-service/pkg/i18n"
+	"topic-service/pkg/openapi"
 
 	"github.com/gin-gonic/gin"
-	"github.com/hashicorp/consul/api"
-	"go.mongodb.org/mongo-driver/mongo"
 )
 
-func SetupRouter(topicCol *mongo.Collection) *gin.Engine {
-	r := gin.Default()
+// Options gom các phụ thuộc tuỳ chọn của router, để trống thì router tự dựng với giá trị mặc định
+type Options struct {
+	// UserLifecycle dùng chung với consumer Kafka, nil thì router tự tạo không có fallback owner
+	UserLifecycle service.UserLifecycleService
+	// UserEventsWebhookSecret dùng để kiểm tra chữ ký webhook từ go-main-service, rỗng thì không đăng ký route webhook
+	UserEventsWebhookSecret string
+	// TopicChanges cấp event cho GET /topic/stream, nil thì router tự tạo hub không có nguồn event
+	TopicChanges service.TopicChangeHub
+	// StreamHeartbeat là chu kỳ gửi heartbeat trên stream SSE
+	StreamHeartbeat time.Duration
+	// ValidateRequests bật kiểm tra path, query và body của request theo OpenAPI spec trước khi tới handler
+	ValidateRequests bool
+}
+
+// Router là gin engine kèm các worker nền do router tạo ra
+type Router struct {
+	*gin.Engine
+	// TopicChanges nên được đóng khi server bắt đầu shutdown để các stream SSE kết thúc
+	TopicChanges service.TopicChangeHub
+
+	viewRecorder service.ViewRecorder
+	relatedJob   service.RelatedTopicJob
+}
 
-	// Init Consul client
-	consulClient, _ := api.NewClient(api.DefaultConfig())
+// Close đóng các stream SSE, ghi nốt lượt xem còn trong bộ đệm và dừng job nền.
+// Gọi sau khi http.Server đã Shutdown để không còn request nào ghi lượt xem.
+func (r *Router) Close() {
+	r.TopicChanges.Close()
+	r.viewRecorder.Close()
+	r.relatedJob.Close()
+}
 
-	// Tạo UserGateway
-	userGateway := gateway.NewUserGateway("go-main-service", consulClient)
+func SetupRouter(repos *repository.Repositories, userGateway gateway.UserGateway, opts Options) *Router {
+	r := gin.Default()
+	r.Use(i18n.Middleware())
+	if err := i18n.RegisterValidator(); err != nil {
+		log.Printf("failed to register validation translations: %v", err)
+	}
 
 	// Init repository và service
-	topicRepo := repository.NewTopicRepository(topicCol)
-	topicSvc := service.NewTopicService(topicRepo, userGateway)
-	topicHandler := handler.NewTopicHandler(topicSvc)
+	topicRepo := repos.Topic
+	topicItemRepo := repos.TopicItem
+	curriculumRepo := repos.Curriculum
+	topicRedirectRepo := repos.Redirect
+	topicAuditRepo := repos.Audit
+	topicViewStatRepo := repos.ViewStat
+	topicFavoriteRepo := repos.Favorite
+	recentTopicRepo := repos.RecentTopic
+	topicCommentRepo := repos.Comment
+	topicRatingRepo := repos.Rating
+	topicCoViewRepo := repos.CoView
+	topicRelatedRepo := repos.Related
+	outboxRepo := repos.Outbox
+	transactions := repos.Transactions
+	topicSvc := service.NewTopicService(topicRepo, topicItemRepo, curriculumRepo, topicRedirectRepo, topicFavoriteRepo, topicCommentRepo, topicRatingRepo, outboxRepo, transactions, userGateway)
+	topicItemSvc := service.NewTopicItemService(topicItemRepo, topicRepo, transactions)
+	prerequisiteSvc := service.NewPrerequisiteService(topicRepo, repos.Leases, outboxRepo, transactions)
+	curriculumSvc := service.NewCurriculumService(curriculumRepo, topicRepo)
+	mergeSvc := service.NewMergeService(topicRepo, topicItemRepo, curriculumRepo, topicRedirectRepo, topicAuditRepo, topicFavoriteRepo, topicCommentRepo, topicRatingRepo, outboxRepo, transactions)
+	analyticsSvc := service.NewTopicAnalyticsService(topicViewStatRepo, topicRepo)
+	viewRecorder := service.NewViewRecorder(topicViewStatRepo, recentTopicRepo, topicCoViewRepo)
+	favoriteSvc := service.NewTopicFavoriteService(topicFavoriteRepo, recentTopicRepo, topicRepo)
+	commentSvc := service.NewTopicCommentService(topicCommentRepo, topicRepo, userGateway)
+	ratingSvc := service.NewTopicRatingService(topicRatingRepo, topicRepo)
+	relatedSvc := service.NewRelatedTopicService(topicRepo, topicRelatedRepo)
+	translationSvc := service.NewTopicTranslationService(topicRepo, outboxRepo, transactions)
+	// Job chạy nền tính lại gợi ý topic liên quan
+	relatedJob := service.NewRelatedTopicJob(topicRepo, topicCoViewRepo, topicRelatedRepo, repos.Leases, service.DefaultRelatedRefreshInterval)
+	topicChanges := opts.TopicChanges
+	if topicChanges == nil {
+		topicChanges = service.NewTopicChangeHub(outboxRepo, service.DefaultTopicChangeResumeLimit)
+	}
+	userLifecycleSvc := opts.UserLifecycle
+	if userLifecycleSvc == nil {
+		userLifecycleSvc = service.NewUserLifecycleService(topicRepo, topicAuditRepo, repos.ProcessedEvents, outboxRepo, transactions, userGateway, service.UserLifecycleOptions{})
+	}
+	topicHandler := handler.NewTopicHandler(topicSvc, viewRecorder)
+	topicItemHandler := handler.NewTopicItemHandler(topicItemSvc)
+	prerequisiteHandler := handler.NewPrerequisiteHandler(prerequisiteSvc)
+	curriculumHandler := handler.NewCurriculumHandler(curriculumSvc)
+	mergeHandler := handler.NewMergeHandler(mergeSvc)
+	analyticsHandler := handler.NewTopicAnalyticsHandler(analyticsSvc)
+	favoriteHandler := handler.NewTopicFavoriteHandler(favoriteSvc)
+	commentHandler := handler.NewTopicCommentHandler(commentSvc)
+	ratingHandler := handler.NewTopicRatingHandler(ratingSvc)
+	relatedHandler := handler.NewRelatedTopicHandler(relatedSvc)
+	translationHandler := handler.NewTopicTranslationHandler(translationSvc)
+	userEventHandler := handler.NewUserEventHandler(userLifecycleSvc)
+	syncHandler := handler.NewTopicSyncHandler(service.NewTopicSyncService(topicRepo, outboxRepo))
+	streamHandler := handler.NewTopicStreamHandler(topicChanges, opts.StreamHeartbeat)
+	webhookHandler := handler.NewWebhookHandler(service.NewWebhookService(repos.WebhookSubscriptions, repos.WebhookDeliveries))
+
+	// Spec được Build sau khi đăng ký xong route, validate đặt sau middleware xác thực
+	// để request thiếu token vẫn nhận 401/403 thay vì lỗi validate
+	spec := newAPISpec()
+	validate := func(c *gin.Context) { c.Next() }
+	if opts.ValidateRequests {
+		validate = spec.ValidateRequests(respondInvalidRequest)
+	}
 
 	v1 := r.Group("/api/v1")
 	{
-		topicGroup := v1.Group("/topic", middleware.Secured())
+		topicGroup := v1.Group("/topic", middleware.Secured(), validate)
 		{
 			topicGroup.POST("", topicHandler.CreateTopic)
 			topicGroup.GET("/:id", topicHandler.GetTopicByID)
 			topicGroup.PUT("/:id", topicHandler.UpdateTopic)
 			topicGroup.DELETE("/:id", topicHandler.DeleteTopic)
 			topicGroup.GET("", topicHandler.ListTopics)
+			topicGroup.GET("/templates", topicHandler.ListTemplates)
+			topicGroup.GET("/search", topicHandler.SearchTopics)
+			topicGroup.GET("/stream", streamHandler.StreamTopics)
+			topicGroup.GET("/changes", syncHandler.GetChanges)
+			topicGroup.POST("/:id/clone", topicHandler.CloneTopic)
+			topicGroup.PUT("/:id/template", middleware.RequireAdmin(), topicHandler.SetTemplate)
+			topicGroup.POST("/merge", middleware.RequireAdmin(), mergeHandler.MergeTopics)
+			topicGroup.GET("/:id/audit", middleware.RequireAdmin(), mergeHandler.GetAuditTrail)
+
+			topicGroup.GET("/analytics/most-viewed", analyticsHandler.MostViewed)
+			topicGroup.GET("/analytics/trending", analyticsHandler.Trending)
+			topicGroup.GET("/analytics/never-used", analyticsHandler.NeverUsed)
+
+			topicGroup.GET("/favorites", favoriteHandler.ListFavorites)
+			topicGroup.GET("/recent", favoriteHandler.ListRecentlyViewed)
+			topicGroup.POST("/:id/favorite", favoriteHandler.AddFavorite)
+			topicGroup.DELETE("/:id/favorite", favoriteHandler.RemoveFavorite)
+
+			topicGroup.GET("/:id/comments", commentHandler.ListComments)
+			topicGroup.POST("/:id/comments", commentHandler.CreateComment)
+			topicGroup.PUT("/:id/comments/:comment_id", commentHandler.UpdateComment)
+			topicGroup.DELETE("/:id/comments/:comment_id", commentHandler.DeleteComment)
+			topicGroup.PUT("/:id/comments/:comment_id/moderation", middleware.RequireAdmin(), commentHandler.ModerateComment)
+
+			topicGroup.GET("/:id/rating", ratingHandler.GetMyRating)
+			topicGroup.PUT("/:id/rating", ratingHandler.RateTopic)
+			topicGroup.DELETE("/:id/rating", ratingHandler.DeleteRating)
+			topicGroup.GET("/:id/ratings", ratingHandler.ListRatings)
+
+			topicGroup.GET("/:id/related", relatedHandler.GetRelated)
+
+			topicGroup.GET("/:id/translations", translationHandler.GetTranslations)
+			topicGroup.PUT("/:id/translations/:locale", translationHandler.UpsertTranslation)
+			topicGroup.DELETE("/:id/translations/:locale", translationHandler.DeleteTranslation)
+
+			topicGroup.GET("/:id/items", topicItemHandler.ListItems)
+			topicGroup.POST("/:id/items", topicItemHandler.CreateItem)
+			topicGroup.PUT("/:id/items/order", topicItemHandler.ReorderItems)
+			topicGroup.GET("/:id/items/:item_id", topicItemHandler.GetItem)
+			topicGroup.PUT("/:id/items/:item_id", topicItemHandler.UpdateItem)
+			topicGroup.DELETE("/:id/items/:item_id", topicItemHandler.DeleteItem)
+
+			topicGroup.POST("/learning-path", prerequisiteHandler.GetLearningPath)
+			topicGroup.GET("/:id/prerequisites", prerequisiteHandler.GetPrerequisites)
+			topicGroup.POST("/:id/prerequisites", prerequisiteHandler.AddPrerequisite)
+			topicGroup.DELETE("/:id/prerequisites/:prerequisite_id", prerequisiteHandler.RemovePrerequisite)
+		}
+
+		curriculumGroup := v1.Group("/curriculum", middleware.Secured(), validate)
+		{
+			curriculumGroup.POST("", curriculumHandler.CreateCurriculum)
+			curriculumGroup.GET("", curriculumHandler.ListCurricula)
+			curriculumGroup.GET("/:id", curriculumHandler.GetCurriculumByID)
+			curriculumGroup.PUT("/:id", curriculumHandler.UpdateCurriculum)
+			curriculumGroup.DELETE("/:id", curriculumHandler.DeleteCurriculum)
+			curriculumGroup.PUT("/:id/order", curriculumHandler.ReorderCurriculum)
+			curriculumGroup.POST("/:id/clone", curriculumHandler.CloneCurriculum)
+			curriculumGroup.POST("/:id/publish", curriculumHandler.PublishCurriculum)
+		}
+
+		// Webhook từ service khác không có token user, xác thực bằng chữ ký.
+		// Chưa cấu hình secret thì không mở route để không ai gửi được event chưa ký.
+		if opts.UserEventsWebhookSecret != "" {
+			webhookGroup := v1.Group("/webhooks")
+			{
+				webhookGroup.POST("/user-events", middleware.VerifySignature(opts.UserEventsWebhookSecret), validate, userEventHandler.HandleUserEvent)
+			}
+		} else {
+			log.Printf("user_events.webhook_secret is not set, POST /api/v1/webhooks/user-events is disabled")
+		}
+
+		// Đăng ký webhook gửi đi cho hệ thống của đối tác, chỉ admin của tổ chức quản lý
+		subscriptionGroup := v1.Group("/webhooks/subscriptions", middleware.Secured(), middleware.RequireAdmin(), validate)
+		{
+			subscriptionGroup.POST("", webhookHandler.CreateSubscription)
+			subscriptionGroup.GET("", webhookHandler.ListSubscriptions)
+			subscriptionGroup.GET("/:id", webhookHandler.GetSubscription)
+			subscriptionGroup.PUT("/:id", webhookHandler.UpdateSubscription)
+			subscriptionGroup.DELETE("/:id", webhookHandler.DeleteSubscription)
+			subscriptionGroup.GET("/:id/deliveries", webhookHandler.ListDeliveries)
+			subscriptionGroup.POST("/:id/deliveries/:delivery_id/replay", webhookHandler.ReplayDelivery)
 		}
 	}
 
-	return r
+	// Route thiếu trong apiOperations là lỗi lập trình, dừng ngay khi khởi động thay vì chạy với spec rỗng
+	if err := spec.Build(r.Routes(), apiOperations); err != nil {
+		log.Fatalf("failed to build OpenAPI spec: %v", err)
+	}
+	r.GET("/openapi.json", spec.ServeJSON)
+	if !openapi.UIVendored() {
+		log.Printf("Swagger UI assets are not embedded, /docs answers 503 until scripts/vendor-swagger-ui.sh is run")
+	}
+	r.GET("/docs", openapi.ServeUI)
+	r.GET("/docs/assets/*filepath", openapi.ServeUIAssets)
+
+	return &Router{
+		Engine:       r,
+		TopicChanges: topicChanges,
+		viewRecorder: viewRecorder,
+		relatedJob:   relatedJob,
+	}
 }
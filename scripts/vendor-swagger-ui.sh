@@ -0,0 +1,20 @@
+#!/usr/bin/env bash
+# Tải swagger-ui-dist theo version trong pkg/openapi/static/swagger-ui/VERSION vào cùng thư mục
+# để /docs được embed vào binary, không phụ thuộc CDN. Chạy lại sau khi đổi VERSION rồi commit các file tải về.
+set -euo pipefail
+
+dir="$(cd "$(dirname "$0")/.." && pwd)/pkg/openapi/static/swagger-ui"
+version="$(tr -d '[:space:]' < "$dir/VERSION")"
+tmp="$(mktemp -d)"
+trap 'rm -rf "$tmp"' EXIT
+
+url="https://registry.npmjs.org/swagger-ui-dist/-/swagger-ui-dist-${version}.tgz"
+if ! curl -fsSL -o "$tmp/swagger-ui-dist.tgz" "$url"; then
+    echo "failed to download $url" >&2
+    exit 1
+fi
+tar -xzf "$tmp/swagger-ui-dist.tgz" -C "$tmp"
+for file in swagger-ui.css swagger-ui-bundle.js LICENSE; do
+    cp "$tmp/package/$file" "$dir/$file"
+done
+echo "swagger-ui-dist ${version} vendored into $dir"